@@ -0,0 +1,109 @@
+// creaper is the minimal container reaper started as the parent of each
+// command by -container-reaper-path, it is single threaded without runtime so
+// that it is not noticeable in the proc and memory limits of the command.
+//
+// Build statically since it is executed inside the container:
+//
+//	cc -static -Os -o creaper cmd/creaper/creaper.c
+//
+// usage: creaper reaper <files> <exec fd> <args...>
+//
+// The first <files> fds are inherited by the command, it is executed by the
+// <exec fd> (-1 executes args[0]). The reaper is the parent of the command as
+// a child subreaper, it reaps the orphans until the command exits and exits
+// with the same status (or signal) as the command.
+#define _GNU_SOURCE
+#include <errno.h>
+#include <fcntl.h>
+#include <signal.h>
+#include <stdio.h>
+#include <stdlib.h>
+#include <string.h>
+#include <sys/prctl.h>
+#include <sys/wait.h>
+#include <unistd.h>
+
+#ifndef PR_SET_CHILD_SUBREAPER
+#define PR_SET_CHILD_SUBREAPER 36
+#endif
+
+// signals forwarded to the command
+static const int forward_signals[] = {SIGTERM, SIGINT, SIGHUP, SIGQUIT, SIGUSR1, SIGUSR2};
+
+static volatile pid_t child;
+
+static void forward(int sig) {
+	if (child > 0) {
+		kill(child, sig);
+	}
+}
+
+static int fail(const char *msg) {
+	fprintf(stderr, "reaper: %s: %s\n", msg, strerror(errno));
+	return 126;
+}
+
+int main(int argc, char **argv, char **envp) {
+	if (argc < 5 || strcmp(argv[1], "reaper") != 0) {
+		fprintf(stderr, "usage: %s reaper <files> <exec fd> <args...>\n", argv[0]);
+		return 126;
+	}
+	int exec_fd = atoi(argv[3]);
+	char **args = argv + 4;
+
+	if (prctl(PR_SET_CHILD_SUBREAPER, 1, 0, 0, 0) < 0) {
+		return fail("prctl");
+	}
+	// the exec fd is not inherited by the command
+	if (exec_fd >= 0 && fcntl(exec_fd, F_SETFD, FD_CLOEXEC) < 0) {
+		return fail("fcntl");
+	}
+
+	struct sigaction act;
+	memset(&act, 0, sizeof(act));
+	act.sa_handler = forward;
+	act.sa_flags = SA_RESTART;
+	for (size_t i = 0; i < sizeof(forward_signals) / sizeof(forward_signals[0]); i++) {
+		sigaction(forward_signals[i], &act, NULL);
+	}
+
+	pid_t pid = fork();
+	if (pid < 0) {
+		return fail("fork");
+	}
+	if (pid == 0) {
+		// the forwarded signals are reset to default by exec
+		if (exec_fd >= 0) {
+			fexecve(exec_fd, args, envp);
+		} else {
+			execve(args[0], args, envp);
+		}
+		_exit(fail(args[0]));
+	}
+	child = pid;
+
+	for (;;) {
+		int status;
+		pid_t wpid = waitpid(-1, &status, 0);
+		if (wpid < 0) {
+			if (errno == EINTR) {
+				continue;
+			}
+			return fail("waitpid");
+		}
+		if (wpid != pid) {
+			continue;
+		}
+		if (WIFSIGNALED(status)) {
+			int sig = WTERMSIG(status);
+			sigset_t set;
+			signal(sig, SIG_DFL);
+			sigemptyset(&set);
+			sigaddset(&set, sig);
+			sigprocmask(SIG_UNBLOCK, &set, NULL);
+			kill(getpid(), sig);
+			return 128 + sig;
+		}
+		return WEXITSTATUS(status);
+	}
+}
@@ -1,7 +1,10 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/criyle/go-judge/envexec"
@@ -11,36 +14,120 @@ import (
 // Config defines executor server configuration
 type Config struct {
 	// container
-	ContainerInitPath  string `flagUsage:"container init path"`
-	PreFork            int    `flagUsage:"control # of the prefork workers" default:"1"`
-	TmpFsParam         string `flagUsage:"tmpfs mount data (only for default mount with no mount.yaml)" default:"size=16m,nr_inodes=4k"`
-	NetShare           bool   `flagUsage:"share net namespace with host"`
-	MountConf          string `flagUsage:"specifies mount configuration file" default:"mount.yaml"`
-	SeccompConf        string `flagUsage:"specifies seccomp filter" default:"seccomp.yaml"`
-	Parallelism        int    `flagUsage:"control the # of concurrency execution" default:"4"`
-	CgroupPrefix       string `flagUsage:"control cgroup prefix" default:"executor_server"`
-	ContainerCredStart int    `flagUsage:"control the start uid&gid for container" default:"10000"`
+	ContainerInitPath   string        `flagUsage:"container init path"`
+	ContainerInitEnv    []string      `flagUsage:"specifies comma separated KEY=VALUE env for the container init process (e.g. LANG=C.UTF-8)"`
+	ContainerInitArgs   []string      `flagUsage:"specifies comma separated flags appended to the container init args"`
+	ContainerReaper     bool          `flagUsage:"start the executorserver as the parent of each cmd to reap its orphans (native sandbox, not with seccomp)"`
+	ContainerReaperPath string        `flagUsage:"start the static reaper (cmd/creaper) as the parent of each cmd instead of the executorserver"`
+	PreFork             int           `flagUsage:"control # of the prefork workers" default:"1"`
+	PreForkMin          int           `flagUsage:"min # of the prefork workers built to start, failed builds above are logged and tolerated" default:"1"`
+	EnvIdleTimeout      time.Duration `flagUsage:"destroy pooled containers idle over the timeout while keeping prefork count, 0 disables"`
+	VerifyReset         bool          `flagUsage:"verify pooled containers (work dir empty) and cgroups (counters zeroed) after reset, destroys the failed ones"`
+	TmpFsParam          string        `flagUsage:"tmpfs mount data (only for default mount with no mount.yaml)" default:"size=16m,nr_inodes=4k"`
+	NetShare            bool          `flagUsage:"share net namespace with host"`
+	HostName            string        `flagUsage:"specifies container host name (override mount.yaml, not mount images)"`
+	DomainName          string        `flagUsage:"specifies container domain name (override mount.yaml, not mount images)"`
+	ResolvConf          string        `flagUsage:"specifies file mounted as /etc/resolv.conf inside container"`
+	HostsFile           string        `flagUsage:"specifies file mounted as /etc/hosts inside container"`
+	Timezone            string        `flagUsage:"specifies timezone (e.g. UTC, Asia/Shanghai) mounted as /etc/localtime inside container from /usr/share/zoneinfo"`
+	MountConf           string        `flagUsage:"specifies mount configuration file" default:"mount.yaml"`
+	Devices             []string      `flagUsage:"specifies comma separated device nodes in container /dev (null, zero, full, random, urandom), overridden by devices in mount.yaml" default:"null,zero,random,urandom"`
+	MountImages         []string      `flagUsage:"specifies named mount configuration files selectable by request image (e.g. gcc12=mount-gcc12.yaml)"`
+	ImagePreFork        int           `flagUsage:"control # of the prefork containers of each mount image"`
+	SeccompConf         string        `flagUsage:"specifies seccomp filter" default:"seccomp.yaml"`
+	ProfileConf         string        `flagUsage:"specifies profile configuration file" default:"profile.yaml"`
+	Parallelism         int           `flagUsage:"control the # of concurrency execution" default:"4"`
+	MaxQueue            int           `flagUsage:"specifies max queued requests, exceeded are rejected as busy instead of waiting, 0 waits"`
+	BusyRetryAfter      time.Duration `flagUsage:"specifies Retry-After hint of busy responses" default:"1s"`
+	CgroupPrefix        string        `flagUsage:"control cgroup prefix" default:"executor_server"`
+	ContainerCredStart  int           `flagUsage:"control the start uid&gid for container" default:"10000"`
+
+	// sandbox backend
+	Sandbox       string `flagUsage:"specifies sandbox backend (native, gvisor), gvisor falls back to native if runsc is not available" default:"native"`
+	RunscPath     string `flagUsage:"specifies runsc binary path for gvisor sandbox" default:"runsc"`
+	RunscPlatform string `flagUsage:"specifies runsc platform for gvisor sandbox (e.g. systrap, ptrace, kvm), empty for runsc default"`
 
 	// file store
-	SrcPrefix string `flagUsage:"specifies directory prefix for source type copyin"`
-	Dir       string `flagUsage:"specifies directory to store file upload / download (in memory by default)"`
+	SrcPrefix   string        `flagUsage:"specifies directory prefix for source type copyin"`
+	Dir         string        `flagUsage:"specifies directory to store file upload / download (in memory by default)"`
+	FileStore   string        `flagUsage:"specifies registered file store type (memory, local or custom), configured by dir, default local if dir is set otherwise memory"`
+	FileTimeout time.Duration `flagUsage:"specifies the default time-to-live for files in file store (0 never expires)"`
+
+	// preserve work dir for inspection (requires auth token)
+	PreserveDir     string        `flagUsage:"specifies directory to preserve work dir of failed runs requested with preserveOnFailure"`
+	PreserveTimeout time.Duration `flagUsage:"specifies the time after preserved work dir is removed (0 never)" default:"1h"`
 
 	// runner limit
 	TimeLimitCheckerInterval time.Duration `flagUsage:"specifies time limit checker interval" default:"100ms"`
 	ExtraMemoryLimit         *envexec.Size `flagUsage:"specifies extra memory buffer for check memory limit" default:"16k"`
 	OutputLimit              *envexec.Size `flagUsage:"specifies POSIX rlimit for output for each command" default:"256m"`
 	CopyOutLimit             *envexec.Size `flagUsage:"specifies default file copy out max" default:"64m"`
+	CopyOutTotalLimit        *envexec.Size `flagUsage:"specifies default max total size of copy out files of each cmd, 0 unlimited" default:"0"`
+	CopyOutCollision         string        `flagUsage:"specifies action for colliding copy out file names (overwrite, error, keep-first, rename)" default:"overwrite"`
+	CopyOutCaseInsensitive   bool          `flagUsage:"compares copy out file names case insensitively for collision"`
+	CopyOutFileLimit         int           `flagUsage:"specifies max number of copy out files of each cmd including files inside copyOutDirs, 0 unlimited"`
+	MaxCPULimit              time.Duration `flagUsage:"specifies max cpu time limit of each cmd, 0 unlimited"`
+	MaxClockLimit            time.Duration `flagUsage:"specifies max clock time limit of each cmd, 0 unlimited"`
+	MaxMemoryLimit           *envexec.Size `flagUsage:"specifies max memory limit of each cmd, 0 unlimited" default:"0"`
+	MaxStackLimit            *envexec.Size `flagUsage:"specifies max stack limit of each cmd, 0 unlimited" default:"0"`
+	MaxProcLimit             uint64        `flagUsage:"specifies max proc limit of each cmd, 0 unlimited"`
+	MaxKillGracePeriod       time.Duration `flagUsage:"specifies max kill grace period of each cmd, 0 unlimited"`
+	MaxCollectorSize         *envexec.Size `flagUsage:"specifies max size of each pipe collector (including stdinTee) buffered in memory, 0 unlimited" default:"0"`
+	PostExecTimeLimit        time.Duration `flagUsage:"specifies cpu & clock time limit of the post exec cmd" default:"1s"`
+	ReapDeadline             time.Duration `flagUsage:"specifies max time to wait killed program to be reaped, exceeded environment is destroyed, 0 waits until reaped"`
+	ClampLimits              bool          `flagUsage:"clamp limits over the max limits instead of rejecting"`
+	CompileCacheSize         int           `flagUsage:"specifies max entries of compile cache for cmd with cache set, 0 disables"`
+	CompileCacheTTL          time.Duration `flagUsage:"specifies time-to-live of compile cache entries, 0 never expires"`
+	CopyInLimit              *envexec.Size `flagUsage:"specifies max size of each copy in file, 0 unlimited" default:"0"`
+	CopyInTotalLimit         *envexec.Size `flagUsage:"specifies max total size of copy in files for each request, 0 unlimited" default:"0"`
+	ArgsSizeLimit            *envexec.Size `flagUsage:"specifies max total size of args for each cmd, 0 unlimited" default:"0"`
+	EnvSizeLimit             *envexec.Size `flagUsage:"specifies max total size of env (including secrets) for each cmd, 0 unlimited" default:"0"`
+	EnvAllow                 []string      `flagUsage:"specifies comma separated env names (NAME* for prefix) cmd could set by env, secrets and envFile, empty allows all"`
+	EnvDeny                  []string      `flagUsage:"specifies comma separated env names (NAME* for prefix, e.g. LD_*) cmd could not set, takes precedence over env-allow"`
+	EnvStrip                 bool          `flagUsage:"strips the env not allowed with a warning instead of rejecting the request"`
+	TimeLimitEnv             string        `flagUsage:"specifies env name exposing the cpu time limit in ms to cmd (e.g. TIME_LIMIT_MS), empty disables"`
+	ClockLimitEnv            string        `flagUsage:"specifies env name exposing the clock time limit in ms to cmd, empty disables"`
+	RetryInternalError       int           `flagUsage:"specifies max retries for runs end with internal error, 0 disables"`
+	RetryBackoff             time.Duration `flagUsage:"specifies initial backoff between retries, doubles for each retry" default:"100ms"`
+	BufferPoolLimit          *envexec.Size `flagUsage:"specifies max size of output buffer to reuse, 0 disables buffer pool" default:"4m"`
+	URLAllowHosts            []string      `flagUsage:"specifies comma separated hosts (*.example.com for sub domains) allowed for url copy in, empty disables url copy in"`
+	URLTimeout               time.Duration `flagUsage:"specifies timeout for url copy in" default:"30s"`
+	URLMaxSize               *envexec.Size `flagUsage:"specifies max size for url copy in" default:"64m"`
+	URLAllowPrivate          bool          `flagUsage:"allow url copy in from loopback / private network addresses"`
 	Cpuset                   string        `flagUsage:"control the usage of cpuset for all containerd process"`
 	EnableCPURate            bool          `flagUsage:"enable cpu cgroup rate control"`
 	CPUCfsPeriod             time.Duration `flagUsage:"set cpu.cfs_period" default:"100ms"`
+	CgroupPool               bool          `flagUsage:"reuse cgroups reset after each run unless fresh cgroup requested"`
+	MemoryExcludeCache       bool          `flagUsage:"report memory as cgroup peak minus page cache (estimate), the raw peak is reported as memoryPeak"`
+	DisableSwap              bool          `flagUsage:"limit memory and swap of the cgroup to the memory limit (memory.swap.max 0 on v2, memory.memsw.limit_in_bytes on v1), swapUsage is reported otherwise"`
+	MountPropagation         string        `flagUsage:"specifies propagation of the container mounts (private, slave, unbindable), empty keeps the inherited, mount.yaml overrides it"`
+	GPUDevices               []string      `flagUsage:"specifies comma separated gpu device nodes (e.g. nvidia0) accessible to profiles with gpuDevices (cgroup v1 devices controller)"`
+	InstructionCounter       bool          `flagUsage:"enable instructionLimit by hardware perf counter (native sandbox, fails if not available)"`
+	StdbufLibrary            string        `flagUsage:"specifies libstdbuf.so path inside container (e.g. /usr/libexec/coreutils/libstdbuf.so) preloaded for stdoutBuffering, empty disables"`
+	NprocFromProcLimit       bool          `flagUsage:"sets RLIMIT_NPROC of cmd without nprocLimit to its procLimit (cgroup pids limit still applies)"`
+	AllowedGroups            []int         `flagUsage:"specifies comma separated supplementary group ids cmd could request by groups (gvisor sandbox only), empty disables"`
+	AllowedCapabilities      []string      `flagUsage:"specifies comma separated capabilities (e.g. CAP_NET_BIND_SERVICE) profiles could retain by capabilities (gvisor sandbox only), empty disables"`
+	MinNice                  int           `flagUsage:"specifies the lowest nice value (highest priority, -20 to 0) cmd could request, default 0 only lowers priority"`
+	SignalStatus             []string      `flagUsage:"specifies comma separated signal=status for programs terminated by signal (e.g. SIGSEGV=Segmentation Fault,SIGKILL=Memory Limit Exceeded)"`
 
 	// server config
-	HTTPAddr      string `flagUsage:"specifies the http binding address" default:":5050"`
-	EnableGRPC    bool   `flagUsage:"enable gRPC endpoint"`
-	GRPCAddr      string `flagUsage:"specifies the grpc binding address" default:":5051"`
-	AuthToken     string `flagUsage:"bearer token auth for REST / gRPC"`
-	EnableDebug   bool   `flagUsage:"enable debug endpoint"`
-	EnableMetrics bool   `flagUsage:"enable promethus metrics endpoint"`
+	HTTPAddr        string   `flagUsage:"specifies the http binding address" default:":5050"`
+	EnableGRPC      bool     `flagUsage:"enable gRPC endpoint"`
+	GRPCAddr        string   `flagUsage:"specifies the grpc binding address" default:":5051"`
+	AuthToken       string   `flagUsage:"bearer token auth for REST / gRPC"`
+	AdminToken      string   `flagUsage:"bearer token auth for admin endpoints (runs and preserved endpoints), empty disables them"`
+	WSMaxConn       int      `flagUsage:"specifies max concurrent websocket connections, 0 unlimited"`
+	WSMaxInflight   int      `flagUsage:"specifies max concurrent in-flight runs for each websocket connection, 0 unlimited"`
+	EnableDebug     bool     `flagUsage:"enable debug endpoint, request debug info (with auth token) and runs endpoint (with admin token)"`
+	EnableMetrics   bool     `flagUsage:"enable promethus metrics endpoint"`
+	MetricsTags     []string `flagUsage:"specifies comma separated request tag keys as labels of tagged metrics"`
+	MetricsTagLimit int      `flagUsage:"specifies max distinct values for each tag key, the rest is recorded as other" default:"100"`
+
+	AsyncResultTTL  time.Duration `flagUsage:"specifies time to keep results of async runs, 0 never expires" default:"10m"`
+	AsyncMaxPending int           `flagUsage:"specifies max pending async runs, exceeded are rejected, 0 unlimited" default:"512"`
+
+	WSMaxLifetime    time.Duration `flagUsage:"specifies max lifetime of each websocket connection closed with going away after in-flight runs finished, 0 unlimited"`
+	WSLifetimeCancel bool          `flagUsage:"cancels in-flight runs of the websocket connection once its max lifetime reached"`
 
 	// logger config
 	Release bool `flagUsage:"release level of logs"`
@@ -63,5 +150,56 @@ func (c *Config) Load() error {
 	if os.Getpid() == 1 {
 		c.Release = true
 	}
-	return cl.Load(c)
+	if err := cl.Load(c); err != nil {
+		return err
+	}
+	if err := checkCapabilities(c.AllowedCapabilities); err != nil {
+		return err
+	}
+	if c.AdminToken != "" && c.AdminToken == c.AuthToken {
+		return fmt.Errorf("admin token should not be the same as auth token")
+	}
+	return checkMetricsTags(c.MetricsTags)
+}
+
+// capabilityName is the capability name as defined by capabilities(7)
+var capabilityName = regexp.MustCompile(`^CAP_[A-Z_]+$`)
+
+// checkCapabilities ensures the allowed capabilities are named as the OCI
+// spec expects
+func checkCapabilities(caps []string) error {
+	for _, c := range caps {
+		if !capabilityName.MatchString(c) {
+			return fmt.Errorf("capability %q is not a valid name (e.g. CAP_NET_BIND_SERVICE)", c)
+		}
+	}
+	return nil
+}
+
+// metricsLabelName is the valid prometheus label name
+var metricsLabelName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// reservedMetricsLabels are the labels of the tagged metrics themselves
+var reservedMetricsLabels = map[string]bool{
+	"status":   true,
+	"le":       true, // histogram bucket
+	"quantile": true,
+}
+
+// checkMetricsTags ensures the tag keys are valid and distinct label names
+// since the tagged metrics panic to register otherwise
+func checkMetricsTags(keys []string) error {
+	seen := make(map[string]bool)
+	for _, k := range keys {
+		switch {
+		case !metricsLabelName.MatchString(k) || strings.HasPrefix(k, "__"):
+			return fmt.Errorf("metrics tag %q is not a valid label name", k)
+		case reservedMetricsLabels[k]:
+			return fmt.Errorf("metrics tag %q is reserved", k)
+		case seen[k]:
+			return fmt.Errorf("metrics tag %q is duplicated", k)
+		}
+		seen[k] = true
+	}
+	return nil
 }
@@ -2,20 +2,28 @@ package grpcexecutor
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/creack/pty"
+	"github.com/criyle/go-judge/cmd/executorserver/version"
 	"github.com/criyle/go-judge/envexec"
 	"github.com/criyle/go-judge/filestore"
 	"github.com/criyle/go-judge/pb"
 	"github.com/criyle/go-judge/worker"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
@@ -28,12 +36,19 @@ var buffPool = sync.Pool{
 	},
 }
 
+// Info defines the server capabilities returned by the Version RPC
+type Info struct {
+	Parallelism int
+	Features    []string
+}
+
 // New creates grpc executor server
-func New(worker worker.Worker, fs filestore.FileStore, srcPrefix string, logger *zap.Logger) pb.ExecutorServer {
+func New(worker worker.Worker, fs filestore.FileStore, srcPrefix string, info Info, logger *zap.Logger) pb.ExecutorServer {
 	return &execServer{
 		worker:    worker,
 		fs:        fs,
 		srcPrefix: srcPrefix,
+		info:      info,
 		logger:    logger,
 	}
 }
@@ -43,13 +58,14 @@ type execServer struct {
 	worker    worker.Worker
 	fs        filestore.FileStore
 	srcPrefix string
+	info      Info
 	logger    *zap.Logger
 }
 
 func (e *execServer) Exec(ctx context.Context, req *pb.Request) (*pb.Response, error) {
 	r, si, so, err := convertPBRequest(req, e.srcPrefix)
 	if err != nil {
-		return nil, err
+		return nil, rejectionStatus(err)
 	}
 	if len(si) > 0 || len(so) > 0 {
 		return nil, fmt.Errorf("Stream in / out are not avaliable for exec request")
@@ -58,9 +74,27 @@ func (e *execServer) Exec(ctx context.Context, req *pb.Request) (*pb.Response, e
 	rt := <-e.worker.Submit(ctx, r)
 	e.logger.Sugar().Debugf("response: %+v", rt)
 	if rt.Error != nil {
+		var be *worker.BusyError
+		if errors.As(rt.Error, &be) {
+			grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(be.RetryAfterSeconds())))
+		}
+		if _, ok := worker.Rejection(rt.Error); ok {
+			return nil, rejectionStatus(rt.Error)
+		}
 		return nil, rt.Error
 	}
-	return convertPBResponse(rt), nil
+	return ConvertResponse(rt), nil
+}
+
+func (e *execServer) Version(c context.Context, n *emptypb.Empty) (*pb.VersionInfo, error) {
+	return &pb.VersionInfo{
+		BuildVersion: version.Version,
+		GoVersion:    runtime.Version(),
+		Platform:     runtime.GOARCH,
+		Os:           runtime.GOOS,
+		Parallelism:  int32(e.info.Parallelism),
+		Features:     e.info.Features,
+	}, nil
 }
 
 func (e *execServer) FileList(c context.Context, n *emptypb.Empty) (*pb.FileListType, error) {
@@ -82,7 +116,22 @@ func (e *execServer) FileGet(c context.Context, f *pb.FileID) (*pb.FileContent,
 }
 
 func (e *execServer) FileAdd(c context.Context, fc *pb.FileContent) (*pb.FileID, error) {
-	fid, err := e.fs.Add(fc.GetName(), fc.GetContent())
+	var (
+		fid string
+		err error
+	)
+	if ttl := fc.GetTtl(); ttl > 0 {
+		if ttl > math.MaxInt64 {
+			return nil, status.Error(codes.InvalidArgument, "ttl is too large")
+		}
+		ts, ok := e.fs.(filestore.TTLFileStore)
+		if !ok {
+			return nil, status.Error(codes.InvalidArgument, "file store does not support ttl")
+		}
+		fid, err = ts.AddWithTTL(fc.GetName(), fc.GetContent(), time.Duration(ttl))
+	} else {
+		fid, err = e.fs.Add(fc.GetName(), fc.GetContent())
+	}
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
@@ -99,7 +148,39 @@ func (e *execServer) FileDelete(c context.Context, f *pb.FileID) (*emptypb.Empty
 	return &emptypb.Empty{}, nil
 }
 
-func convertPBResponse(r worker.Response) *pb.Response {
+// rejectCodes maps the reject code to the grpc status code
+var rejectCodes = map[worker.RejectCode]codes.Code{
+	worker.RejectInvalidArgument: codes.InvalidArgument,
+	worker.RejectLimitExceeded:   codes.InvalidArgument,
+	worker.RejectFileNotFound:    codes.NotFound,
+	worker.RejectNotAllowed:      codes.PermissionDenied,
+	worker.RejectBusy:            codes.ResourceExhausted,
+}
+
+// rejectionStatus converts the error of rejected request into grpc status
+// with ErrorInfo detail (reason is the reject code, metadata field is the
+// field path), errors other than worker.RejectError are invalid argument
+func rejectionStatus(err error) error {
+	re, ok := worker.Rejection(err)
+	if !ok {
+		re = &worker.RejectError{Code: worker.RejectInvalidArgument, Err: err}
+	}
+	st := status.New(rejectCodes[re.Code], re.Error())
+	info := &errdetails.ErrorInfo{
+		Reason: string(re.Code),
+		Domain: "executorserver",
+	}
+	if re.Field != "" {
+		info.Metadata = map[string]string{"field": re.Field}
+	}
+	if s, err := st.WithDetails(info); err == nil {
+		st = s
+	}
+	return st.Err()
+}
+
+// ConvertResponse converts the worker response to protobuf response
+func ConvertResponse(r worker.Response) *pb.Response {
 	res := &pb.Response{
 		RequestID: r.RequestID,
 		Results:   make([]*pb.Response_Result, 0, len(r.Results)),
@@ -109,20 +190,119 @@ func convertPBResponse(r worker.Response) *pb.Response {
 	}
 	if r.Error != nil {
 		res.Error = r.Error.Error()
+		if re, ok := worker.Rejection(r.Error); ok {
+			res.ErrorCode = string(re.Code)
+			res.ErrorField = re.Field
+		}
+	}
+	if r.Seed != nil {
+		res.Seed = *r.Seed
+	}
+	if r.Checker != nil {
+		res.Checker = convertPBResult(*r.Checker)
+	}
+	res.Verdict = pb.Response_Result_StatusType(r.Verdict)
+	res.ClockBudgetExceeded = r.ClockBudgetExceeded
+	res.CheckerComment = r.CheckerComment
+	if c := r.Compare; c != nil {
+		res.Compare = &pb.Response_CompareResult{
+			Match:    c.Match,
+			Line:     int32(c.Line),
+			Column:   int32(c.Column),
+			Expected: c.Expected,
+			Actual:   c.Actual,
+			Error:    c.Error,
+		}
 	}
 	return res
 }
 
 func convertPBResult(r worker.Result) *pb.Response_Result {
 	return &pb.Response_Result{
-		Status:     pb.Response_Result_StatusType(r.Status),
-		ExitStatus: int32(r.ExitStatus),
-		Error:      r.Error,
-		Time:       uint64(r.Time),
-		RunTime:    uint64(r.RunTime),
-		Memory:     uint64(r.Memory),
-		Files:      r.Files,
-		FileIDs:    r.FileIDs,
+		Status:          pb.Response_Result_StatusType(r.Status),
+		ExitStatus:      int32(r.ExitStatus),
+		Error:           r.Error,
+		Time:            uint64(r.Time),
+		RunTime:         uint64(r.RunTime),
+		Memory:          uint64(r.Memory),
+		Files:           r.Files,
+		FileIDs:         r.FileIDs,
+		EnvironmentTime: uint64(r.EnvironmentTime),
+		CgroupTime:      uint64(r.CgroupTime),
+		Retries:         int32(r.Retries),
+		MemoryWarning:   r.MemoryWarning,
+		ProcPeak:        r.ProcPeak,
+		Warning:         r.Warning,
+		Debug:           convertPBDebug(r.Debug),
+		CacheHit:        r.CacheHit,
+		Preserved:       r.Preserved,
+		LimitsClamped:   r.LimitsClamped,
+		UserTime:        uint64(r.UserTime),
+		SystemTime:      uint64(r.SystemTime),
+		PostExec:        convertPBPostExec(r.PostExec),
+		ExitReason:      pb.Response_Result_ExitReasonType(r.ExitReason),
+		MemoryPeak:      uint64(r.MemoryPeak),
+		SwapUsage:       uint64(r.SwapUsage),
+		EarlyResult:     r.EarlyResult,
+		ReapTimeout:     r.ReapTimeout,
+		Instructions:    r.Instructions,
+		OmittedFiles:    convertPBFileSizes(r.OmittedFiles),
+		SocketPeak:      r.SocketPeak,
+		ProcLimitHit:    pb.Response_Result_ProcLimitHitType(r.ProcLimitHit),
+		CopyOutBytes:    uint64(r.CopyOutBytes),
+		PipeBytes:       uint64(r.PipeBytes),
+		TruncatedFiles:  convertPBFileSizes(r.TruncatedFiles),
+	}
+}
+
+func convertPBFileSizes(f map[string]envexec.Size) map[string]uint64 {
+	if len(f) == 0 {
+		return nil
+	}
+	rt := make(map[string]uint64, len(f))
+	for n, s := range f {
+		rt[n] = uint64(s)
+	}
+	return rt
+}
+
+func convertPBPostExec(p *envexec.PostExecResult) *pb.Response_PostExecResult {
+	if p == nil {
+		return nil
+	}
+	return &pb.Response_PostExecResult{
+		Status:     pb.Response_Result_StatusType(p.Status),
+		ExitStatus: int32(p.ExitStatus),
+		Error:      p.Error,
+		Time:       uint64(p.Time),
+	}
+}
+
+func convertPBDebug(d *worker.DebugInfo) *pb.Response_DebugInfo {
+	if d == nil {
+		return nil
+	}
+	return &pb.Response_DebugInfo{
+		Profile:           d.Profile,
+		Seccomp:           d.Seccomp,
+		Args:              d.Args,
+		Env:               d.Env,
+		CpuTimeLimit:      uint64(d.CPULimit),
+		ClockTimeLimit:    uint64(d.ClockLimit),
+		MemoryLimit:       uint64(d.MemoryLimit),
+		StackLimit:        uint64(d.StackLimit),
+		OutputLimit:       uint64(d.OutputLimit),
+		ProcLimit:         d.ProcLimit,
+		CPURateLimit:      d.CPURateLimit,
+		StrictMemoryLimit: d.StrictMemoryLimit,
+		CopyOutMax:        uint64(d.CopyOutMax),
+		Cgroup:            d.Cgroup,
+		CgroupVersion:     int32(d.CgroupVersion),
+		CgroupControllers: d.CgroupControllers,
+		OpenFileCount:     int32(len(d.OpenFiles)),
+		OpenFiles:         d.OpenFiles,
+		Syscalls:          d.Syscalls,
+		Diagnosis:         d.Diagnosis,
 	}
 }
 
@@ -143,6 +323,21 @@ func convertPBRequest(r *pb.Request, srcPrefix string) (req *worker.Request, str
 		RequestID:   r.RequestID,
 		Cmd:         make([]worker.Cmd, 0, len(r.Cmd)),
 		PipeMapping: make([]worker.PipeMap, 0, len(r.PipeMapping)),
+		Tags:        r.GetTags(),
+		Debug:       r.GetDebug(),
+
+		Image:        r.GetImage(),
+		ReturnFields: r.GetReturnFields(),
+		WarmOnly:     r.GetWarmOnly(),
+		GenerateSeed: r.GetGenerateSeed(),
+
+		PreserveOnFailure: r.GetPreserveOnFailure(),
+		ClockBudget:       time.Duration(r.GetClockBudget()),
+		AcquireTimeout:    time.Duration(r.GetAcquireTimeout()),
+		StopOnFailure:     r.GetStopOnFailure(),
+	}
+	if s := r.GetSeed(); s != 0 {
+		req.Seed = &s
 	}
 	for _, c := range r.Cmd {
 		cm, si, so, err := convertPBCmd(c, srcPrefix)
@@ -157,9 +352,87 @@ func convertPBRequest(r *pb.Request, srcPrefix string) (req *worker.Request, str
 		pm := convertPBPipeMap(p)
 		req.PipeMapping = append(req.PipeMapping, pm)
 	}
+	if ck := r.GetChecker(); ck != nil {
+		cm, si, so, err := convertPBCmd(ck.GetCmd(), srcPrefix)
+		streamIn = append(streamIn, si...)
+		streamOut = append(streamOut, so...)
+		if err != nil {
+			return nil, streamIn, streamOut, err
+		}
+		req.Checker = convertPBChecker(ck, cm)
+	}
+	for _, in := range r.GetInputs() {
+		si, err := convertPBSequenceInput(in, srcPrefix)
+		if err != nil {
+			return nil, streamIn, streamOut, err
+		}
+		req.Inputs = append(req.Inputs, si)
+	}
+	if c := r.GetCompare(); c != nil {
+		answer, err := convertPBFile(c.GetAnswer(), srcPrefix)
+		if err != nil {
+			return nil, streamIn, streamOut, err
+		}
+		req.Compare = &worker.Compare{
+			Index:   int(c.GetIndex()),
+			Name:    c.GetName(),
+			Answer:  answer,
+			Policy:  worker.ComparePolicy(c.GetPolicy()),
+			Epsilon: c.GetEpsilon(),
+		}
+	}
 	return req, streamIn, streamOut, nil
 }
 
+// convertPBSequenceInput converts the input, streams are not supported as
+// the runs are sequential
+func convertPBSequenceInput(in *pb.Request_Input, srcPrefix string) (si worker.SequenceInput, err error) {
+	if f := in.GetStdin(); f != nil {
+		if si.Stdin, err = convertPBFile(f, srcPrefix); err != nil {
+			return si, err
+		}
+	}
+	if copyIn := in.GetCopyIn(); copyIn != nil {
+		si.CopyIn = make(map[string]worker.CmdFile)
+		for k, f := range copyIn {
+			cf, err := convertPBFile(f, srcPrefix)
+			if err != nil {
+				return si, err
+			}
+			si.CopyIn[k] = cf
+		}
+	}
+	return si, nil
+}
+
+func convertPBChecker(c *pb.Request_Checker, cm worker.Cmd) *worker.Checker {
+	ck := &worker.Checker{
+		Cmd:    cm,
+		Inputs: make([]worker.CheckerInput, 0, len(c.GetInputs())),
+	}
+	for _, in := range c.GetInputs() {
+		ck.Inputs = append(ck.Inputs, worker.CheckerInput{
+			Index: int(in.GetIndex()),
+			Name:  in.GetName(),
+			Dst:   in.GetDst(),
+		})
+	}
+	if es := c.GetExitStatus(); es != nil {
+		ck.ExitStatus = make(map[int]envexec.Status, len(es))
+		for k, v := range es {
+			ck.ExitStatus[int(k)] = envexec.Status(v)
+		}
+	}
+	if t := c.GetTestlib(); t != nil {
+		ck.Testlib = &worker.TestlibChecker{
+			Input:  t.GetInput(),
+			Output: t.GetOutput(),
+			Answer: t.GetAnswer(),
+		}
+	}
+	return ck
+}
+
 func convertPBPipeMap(p *pb.Request_PipeMap) worker.PipeMap {
 	return worker.PipeMap{
 		In: worker.PipeIndex{
@@ -189,10 +462,14 @@ func convertPBCmd(c *pb.Request_CmdType, srcPrefix string) (cm worker.Cmd, strea
 	cm = worker.Cmd{
 		Args:              c.GetArgs(),
 		Env:               c.GetEnv(),
+		Secrets:           c.GetSecrets(),
+		EnvFile:           c.GetEnvFile(),
 		TTY:               c.GetTty(),
+		Profile:           c.GetProfile(),
 		CPULimit:          time.Duration(c.GetCpuTimeLimit()),
 		ClockLimit:        time.Duration(c.GetClockTimeLimit()),
 		MemoryLimit:       envexec.Size(c.GetMemoryLimit()),
+		MemorySoftLimit:   envexec.Size(c.GetMemorySoftLimit()),
 		StackLimit:        envexec.Size(c.GetStackLimit()),
 		ProcLimit:         c.GetProcLimit(),
 		CPURateLimit:      c.GetCPURateLimit(),
@@ -201,6 +478,45 @@ func convertPBCmd(c *pb.Request_CmdType, srcPrefix string) (cm worker.Cmd, strea
 		CopyOutCached:     c.GetCopyOutCached(),
 		CopyOutMax:        c.GetCopyOutMax(),
 		CopyOutDir:        c.GetCopyOutDir(),
+
+		SyscallRateLimit:  c.GetSyscallRateLimit(),
+		KillOnSyscallRate: c.GetKillOnSyscallRate(),
+
+		WriteLimit: envexec.Size(c.GetWriteLimit()),
+		FileLimit:  c.GetFileLimit(),
+
+		SocketLimit:   c.GetSocketLimit(),
+		OpenFileLimit: c.GetOpenFileLimit(),
+		NprocLimit:    c.GetNprocLimit(),
+
+		FileSizeLimit: envexec.Size(c.GetFileSizeLimit()),
+		FreshCgroup:   c.GetFreshCgroup(),
+
+		MemoryAccounting: envexec.MemoryAccounting(c.GetMemoryAccounting()),
+		TimeAccounting:   envexec.TimeAccounting(c.GetTimeAccounting()),
+		InstructionLimit: c.GetInstructionLimit(),
+		Nice:             int(c.GetNice()),
+		StdoutBuffering:  worker.StdoutBuffering(c.GetStdoutBuffering()),
+
+		CopyOutPartial: c.GetCopyOutPartial(),
+		CleanWorkDir:   c.GetCleanWorkDir(),
+		Groups:         convertPBGroups(c.GetGroups()),
+		CountSyscalls:  c.GetCountSyscalls(),
+
+		CopyOutTotalMax:     c.GetCopyOutTotalMax(),
+		CopyOutIncludePipes: c.GetCopyOutIncludePipes(),
+
+		EarlyResultQuietPeriod: time.Duration(c.GetEarlyResultQuietPeriod()),
+
+		KillSignal:      int(c.GetKillSignal()),
+		KillGracePeriod: time.Duration(c.GetKillGracePeriod()),
+
+		Cache: c.GetCache(),
+
+		PostExec: c.GetPostExec(),
+	}
+	if t := c.GetStdinTee(); t != nil {
+		cm.StdinTee = &worker.PipeCollector{Name: t.GetName(), Max: t.GetMax()}
 	}
 	var (
 		fPty, fTty *os.File
@@ -268,9 +584,26 @@ func convertPBCmd(c *pb.Request_CmdType, srcPrefix string) (cm worker.Cmd, strea
 			cm.CopyIn[k] = cf
 		}
 	}
+	for _, d := range c.GetCopyOutDirs() {
+		cm.CopyOutDirs = append(cm.CopyOutDirs, worker.CopyOutDir{
+			Src:    d.GetSrc(),
+			Prefix: d.GetPrefix(),
+		})
+	}
 	return cm, streamIn, streamOut, nil
 }
 
+func convertPBGroups(g []uint32) []int {
+	if len(g) == 0 {
+		return nil
+	}
+	rt := make([]int, 0, len(g))
+	for _, v := range g {
+		rt = append(rt, int(v))
+	}
+	return rt
+}
+
 func convertPBFile(c *pb.Request_File, srcPrefix string) (worker.CmdFile, error) {
 	switch c := c.File.(type) {
 	case nil:
@@ -285,13 +618,23 @@ func convertPBFile(c *pb.Request_File, srcPrefix string) (worker.CmdFile, error)
 				return nil, fmt.Errorf("file (%s) does not under (%s)", c.Local.GetSrc(), srcPrefix)
 			}
 		}
-		return &worker.LocalFile{Src: c.Local.GetSrc()}, nil
+		return &worker.LocalFile{Src: c.Local.GetSrc(), Link: c.Local.GetLink()}, nil
 	case *pb.Request_File_Memory:
 		return &worker.MemoryFile{Content: c.Memory.GetContent()}, nil
 	case *pb.Request_File_Cached:
 		return &worker.CachedFile{FileID: c.Cached.GetFileID()}, nil
+	case *pb.Request_File_Url:
+		return &worker.URLFile{URL: c.Url.GetUrl()}, nil
+	case *pb.Request_File_Empty:
+		return &worker.EmptyFile{}, nil
 	case *pb.Request_File_Pipe:
-		return &worker.PipeCollector{Name: c.Pipe.GetName(), Max: c.Pipe.GetMax()}, nil
+		return &worker.PipeCollector{
+			Name:   c.Pipe.GetName(),
+			Max:    c.Pipe.GetMax(),
+			Policy: envexec.CollectorPolicy(c.Pipe.GetOnExceed()),
+
+			Backpressure: c.Pipe.GetBackpressure(),
+		}, nil
 	}
 	return nil, fmt.Errorf("request file type not supported yet %v", c)
 }
@@ -24,7 +24,7 @@ func (e *execServer) ExecStream(es pb.Executor_ExecStreamServer) error {
 	}
 	rq, streamIn, streamOut, err := convertPBRequest(req, e.srcPrefix)
 	if err != nil {
-		return status.Errorf(codes.InvalidArgument, "convert exec request: %v", err)
+		return rejectionStatus(err)
 	}
 	e.logger.Sugar().Debugf("request: %+v", rq)
 	defer func() {
@@ -41,15 +41,21 @@ func (e *execServer) ExecStream(es pb.Executor_ExecStreamServer) error {
 	errCh := make(chan error, 1)
 	var wg sync.WaitGroup
 
-	// stream in
+	// stream in (abort is received even without stream in)
+	recv := func() {
+		if err := streamInput(ctx, es, streamIn, cancel); err != nil {
+			writeErrCh(errCh, err)
+		}
+	}
 	if len(streamIn) > 0 {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			if err := streamInput(ctx, es, streamIn); err != nil {
-				writeErrCh(errCh, err)
-			}
+			recv()
 		}()
+	} else {
+		// not waited since Recv only returns once the stream finished
+		go recv()
 	}
 
 	// stream out
@@ -67,8 +73,27 @@ func (e *execServer) ExecStream(es pb.Executor_ExecStreamServer) error {
 		}
 	}
 
-	rtCh := e.worker.Execute(es.Context(), rq)
-	err = execStreamLoop(es, errCh, outCh, rtCh, e.logger)
+	// partial result (buffered to avoid blocking the worker)
+	var resCh chan *pb.StreamResponse_ExecResult
+	if req.GetPartialResult() {
+		n := len(rq.Cmd)
+		if len(rq.Inputs) > n {
+			n = len(rq.Inputs)
+		}
+		resCh = make(chan *pb.StreamResponse_ExecResult, n)
+		rq.ResultCallback = func(i int, r worker.Result) {
+			resCh <- &pb.StreamResponse_ExecResult{
+				ExecResult: &pb.StreamResponse_PartialResult{
+					Index:  int32(i),
+					Result: convertPBResult(r),
+				},
+			}
+		}
+	}
+
+	// abort cancels the request, the rest inputs are not run
+	rtCh := e.worker.Execute(ctx, rq)
+	err = execStreamLoop(es, errCh, outCh, resCh, rtCh, e.logger)
 
 	// Ensure all goroutine are exited
 	cancel()
@@ -76,7 +101,8 @@ func (e *execServer) ExecStream(es pb.Executor_ExecStreamServer) error {
 	return err
 }
 
-func execStreamLoop(es pb.Executor_ExecStreamServer, errCh chan error, outCh chan *pb.StreamResponse_ExecOutput, rtCh <-chan worker.Response, logger *zap.Logger) error {
+func execStreamLoop(es pb.Executor_ExecStreamServer, errCh chan error, outCh chan *pb.StreamResponse_ExecOutput,
+	resCh chan *pb.StreamResponse_ExecResult, rtCh <-chan worker.Response, logger *zap.Logger) error {
 	for {
 		select {
 		case <-es.Context().Done():
@@ -97,17 +123,39 @@ func execStreamLoop(es pb.Executor_ExecStreamServer, errCh chan error, outCh cha
 			}
 			buffPool.Put(o.ExecOutput.Content[:cap(o.ExecOutput.Content)])
 
+		case r := <-resCh:
+			if err := es.Send(&pb.StreamResponse{Response: r}); err != nil {
+				return status.Errorf(codes.Aborted, "partial result: %v", err)
+			}
+
 		case rt := <-rtCh:
 			logger.Sugar().Debugf("response: %+v", rt)
+			// ensure all partial results are sent before the response
+			if err := drainPartialResult(es, resCh); err != nil {
+				return status.Errorf(codes.Aborted, "partial result: %v", err)
+			}
 			return es.Send(&pb.StreamResponse{
 				Response: &pb.StreamResponse_ExecResponse{
-					ExecResponse: convertPBResponse(rt),
+					ExecResponse: ConvertResponse(rt),
 				},
 			})
 		}
 	}
 }
 
+func drainPartialResult(es pb.Executor_ExecStreamServer, resCh chan *pb.StreamResponse_ExecResult) error {
+	for {
+		select {
+		case r := <-resCh:
+			if err := es.Send(&pb.StreamResponse{Response: r}); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+	}
+}
+
 func streamOutput(ctx context.Context, outCh chan<- *pb.StreamResponse_ExecOutput, so *fileStreamOut) error {
 	for {
 		select {
@@ -130,7 +178,7 @@ func streamOutput(ctx context.Context, outCh chan<- *pb.StreamResponse_ExecOutpu
 	}
 }
 
-func streamInput(ctx context.Context, es pb.Executor_ExecStreamServer, streamIn []*fileStreamIn) error {
+func streamInput(ctx context.Context, es pb.Executor_ExecStreamServer, streamIn []*fileStreamIn, abort func()) error {
 	inf := make(map[string]*fileStreamIn)
 	for _, f := range streamIn {
 		inf[f.Name()] = f
@@ -163,6 +211,10 @@ func streamInput(ctx context.Context, es pb.Executor_ExecStreamServer, streamIn
 				return fmt.Errorf("write to input %s with err %w", i.ExecInput.GetName(), err)
 			}
 
+		case *pb.StreamRequest_ExecAbort:
+			abort()
+			return nil
+
 		case *pb.StreamRequest_ExecResize:
 			f, ok := inf[i.ExecResize.GetName()]
 			if !ok {
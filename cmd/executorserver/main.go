@@ -4,6 +4,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"log"
 	"net"
@@ -40,6 +41,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v2"
 )
 
 var logger *zap.Logger
@@ -50,11 +52,18 @@ func main() {
 	logger.Sugar().Infof("config loaded: %+v", conf)
 
 	// Init environment pool
-	fs := newFilsStore(conf.Dir)
-	b := newEnvBuilder(conf)
-	envPool := pool.NewPool(b)
-	prefork(envPool, conf.PreFork)
-	work := newWorker(conf, envPool, fs)
+	fs := newFilsStore(conf.FileStore, conf.Dir, conf.FileTimeout)
+	b := newEnvBuilder(conf, conf.MountConf)
+	if gs, ok := b.(pool.GroupsSupporter); len(conf.AllowedGroups) > 0 && (!ok || !gs.SupportsGroups()) {
+		logger.Sugar().Warn("Supplementary groups are rejected by the native sandbox, -allowed-groups only applies to gVisor")
+	}
+	if cs, ok := b.(pool.CapabilitiesSupporter); len(conf.AllowedCapabilities) > 0 && (!ok || !cs.SupportsCapabilities()) {
+		logger.Sugar().Warn("Capabilities are rejected by the native sandbox, -allowed-capabilities only applies to gVisor")
+	}
+	envPool := pool.NewIdlePool(b, conf.EnvIdleTimeout, conf.PreFork, resetCheck(conf, "environment"))
+	prefork(envPool, conf.PreFork, conf.PreForkMin)
+	imagePools := newImagePools(conf)
+	work := newWorker(conf, envPool, imagePools, fs)
 	work.Start()
 	logger.Sugar().Infof("Starting worker with parallelism=%d, workdir=%s, timeLimitCheckInterval=%v",
 		conf.Parallelism, conf.Dir, conf.TimeLimitCheckerInterval)
@@ -74,7 +83,11 @@ func main() {
 	// Init gRPC server
 	var grpcServer *grpc.Server
 	if conf.EnableGRPC {
-		esServer := grpcexecutor.New(work, fs, conf.SrcPrefix, logger)
+		info := grpcexecutor.Info{
+			Parallelism: conf.Parallelism,
+			Features:    enabledFeatures(conf, b),
+		}
+		esServer := grpcexecutor.New(work, fs, conf.SrcPrefix, info, logger)
 		grpcServer = newGRPCServer(conf, esServer)
 
 		lis, err := net.Listen("tcp", conf.GRPCAddr)
@@ -104,6 +117,21 @@ func main() {
 		return nil
 	})
 
+	eg.Go(func() error {
+		envPool.Shutdown()
+		for _, p := range imagePools {
+			p.Shutdown()
+		}
+		logger.Sugar().Info("Environment pool shutdown")
+		return nil
+	})
+
+	eg.Go(func() error {
+		fs.Shutdown()
+		logger.Sugar().Info("File store shutdown")
+		return nil
+	})
+
 	eg.Go(func() error {
 		logger.Sugar().Info("Http server shutdown")
 		return srv.Shutdown(ctx)
@@ -154,24 +182,48 @@ func initLogger(conf *config.Config) {
 	}
 }
 
-func prefork(envPool envexec.EnvironmentPool, prefork int) {
+// prefork builds the prefork containers of the pool, the failed builds are
+// logged and tolerated unless fewer than min containers are built
+func prefork(envPool envexec.EnvironmentPool, prefork, min int) {
 	if prefork <= 0 {
 		return
 	}
 	logger.Sugar().Info("create ", prefork, " prefork containers")
 	m := make([]envexec.Environment, 0, prefork)
+	var lastErr error
 	for i := 0; i < prefork; i++ {
 		e, err := envPool.Get()
 		if err != nil {
-			log.Fatalln("prefork environment failed", err)
+			logger.Warn("prefork environment failed", zap.Int("index", i), zap.Error(err), zap.String("diagnosis", diagnosis(err)))
+			lastErr = err
+			continue
 		}
 		m = append(m, e)
 	}
+	if min > prefork {
+		min = prefork
+	}
+	if len(m) < min {
+		log.Fatalln("prefork environment failed, built", len(m), "of", prefork, "less than", min, lastErr)
+	}
+	if len(m) < prefork {
+		logger.Sugar().Warn("created ", len(m), " of ", prefork, " prefork containers")
+	}
 	for _, e := range m {
 		envPool.Put(e)
 	}
 }
 
+// diagnosis returns the diagnosis of the environment failure (e.g. the stderr
+// of the container init)
+func diagnosis(err error) string {
+	var d envexec.Diagnoser
+	if errors.As(err, &d) {
+		return d.Diagnosis()
+	}
+	return ""
+}
+
 func initHTTPMux(conf *config.Config, work worker.Worker, fs filestore.FileStore) http.Handler {
 	var r *gin.Engine
 	if conf.Release {
@@ -187,12 +239,30 @@ func initHTTPMux(conf *config.Config, work worker.Worker, fs filestore.FileStore
 
 	// Metrics Handle
 	if conf.EnableMetrics {
+		initTagMetrics(conf.MetricsTags, conf.MetricsTagLimit)
 		initGinMetrics(r)
 	}
 
 	// Version handle
 	r.GET("/version", handleVersion)
 
+	// Admin handle (registered before the auth token thus only the admin
+	// token is accepted)
+	if conf.AdminToken != "" {
+		admin := r.Group("", tokenAuth(conf.AdminToken))
+		logger.Sugar().Info("Attach admin token auth with token:", conf.AdminToken)
+
+		// Preserved work dir handle
+		if conf.PreserveDir != "" {
+			initPreserveHandle(admin, conf.PreserveDir)
+		}
+
+		// Executing request handle
+		if conf.EnableDebug {
+			initRunsHandle(admin, work)
+		}
+	}
+
 	// Add auth token
 	if conf.AuthToken != "" {
 		r.Use(tokenAuth(conf.AuthToken))
@@ -200,11 +270,16 @@ func initHTTPMux(conf *config.Config, work worker.Worker, fs filestore.FileStore
 	}
 
 	// Rest Handle
-	restHandle := restexecutor.New(work, fs, conf.SrcPrefix, logger)
+	restHandle := restexecutor.New(work, fs, conf.SrcPrefix, conf.AsyncResultTTL, conf.AsyncMaxPending, logger)
 	restHandle.Register(r)
 
 	// WebSocket Handle
-	wsHandle := wsexecutor.New(work, conf.SrcPrefix, logger)
+	wsHandle := wsexecutor.New(work, conf.SrcPrefix, wsexecutor.Limit{
+		MaxConn:        conf.WSMaxConn,
+		MaxInflight:    conf.WSMaxInflight,
+		MaxLifetime:    conf.WSMaxLifetime,
+		CancelOnExpire: conf.WSLifetimeCancel,
+	}, logger)
 	wsHandle.Register(r)
 
 	// pprof
@@ -275,29 +350,63 @@ func grpcTokenAuth(token string) func(context.Context) (context.Context, error)
 	}
 }
 
-func newFilsStore(dir string) filestore.FileStore {
-	var fs filestore.FileStore
-	if dir == "" {
-		fs = filestore.NewFileMemoryStore()
-	} else {
-		os.MkdirAll(dir, 0755)
-		fs = filestore.NewFileLocalStore(dir)
+// newFilsStore creates the file store registered by storeType (custom stores
+// are linked in by importing their registering package)
+func newFilsStore(storeType, dir string, fileTimeout time.Duration) filestore.TTLFileStore {
+	if storeType == "" {
+		storeType = "memory"
+		if dir != "" {
+			storeType = "local"
+		}
+	}
+	fs, err := filestore.New(storeType, dir)
+	if err != nil {
+		log.Fatalln("create file store failed", err)
 	}
-	return fs
+	logger.Sugar().Info("Created file store: ", storeType)
+	return filestore.NewTTLStore(fs, fileTimeout)
 }
 
-func newEnvBuilder(conf *config.Config) pool.EnvBuilder {
+func newEnvBuilder(conf *config.Config, mountConf string) pool.EnvBuilder {
+	reaperPath := conf.ContainerReaperPath
+	if reaperPath == "" && conf.ContainerReaper {
+		p, err := os.Executable()
+		if err != nil {
+			log.Fatalln("get executable for container reaper failed", err)
+		}
+		reaperPath = p
+	}
 	b, err := env.NewBuilder(env.Config{
 		ContainerInitPath:  conf.ContainerInitPath,
-		MountConf:          conf.MountConf,
+		ContainerInitEnv:   conf.ContainerInitEnv,
+		ContainerInitArgs:  conf.ContainerInitArgs,
+		MountConf:          mountConf,
+		ResolvConf:         conf.ResolvConf,
+		HostsFile:          conf.HostsFile,
+		Timezone:           conf.Timezone,
+		Devices:            conf.Devices,
 		TmpFsParam:         conf.TmpFsParam,
 		NetShare:           conf.NetShare,
+		HostName:           conf.HostName,
+		DomainName:         conf.DomainName,
 		CgroupPrefix:       conf.CgroupPrefix,
 		Cpuset:             conf.Cpuset,
 		ContainerCredStart: conf.ContainerCredStart,
 		EnableCPURate:      conf.EnableCPURate,
 		CPUCfsPeriod:       conf.CPUCfsPeriod,
+		CgroupPool:         conf.CgroupPool,
+		MemoryExcludeCache: conf.MemoryExcludeCache,
+		DisableSwap:        conf.DisableSwap,
+		VerifyReset:        conf.VerifyReset,
+		OnResetFailed:      resetCheck(conf, "cgroup").OnFailed,
+		GPUDevices:         conf.GPUDevices,
+		MountPropagation:   conf.MountPropagation,
+		InstructionCounter: conf.InstructionCounter,
+		ReaperPath:         reaperPath,
 		SeccompConf:        conf.SeccompConf,
+		Sandbox:            conf.Sandbox,
+		RunscPath:          conf.RunscPath,
+		RunscPlatform:      conf.RunscPlatform,
 		Logger:             logger.Sugar(),
 	})
 	if err != nil {
@@ -306,20 +415,195 @@ func newEnvBuilder(conf *config.Config) pool.EnvBuilder {
 	return b
 }
 
-func newWorker(conf *config.Config, envPool envexec.EnvironmentPool, fs filestore.FileStore) worker.Worker {
+// newImagePools creates environment pool for each named mount configuration
+func newImagePools(conf *config.Config) map[string]pool.IdlePool {
+	rt := make(map[string]pool.IdlePool)
+	for _, s := range conf.MountImages {
+		kv := strings.SplitN(s, "=", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			log.Fatalln("invalid mount image (expect name=mount.yaml):", s)
+		}
+		name, mountConf := kv[0], kv[1]
+		if _, err := os.Stat(mountConf); err != nil {
+			log.Fatalln("mount image", name, "config not found:", err)
+		}
+		logger.Sugar().Info("Create environment pool for image ", name, " with ", mountConf)
+		// the host name of the image is set by its own mount config
+		ic := *conf
+		ic.HostName, ic.DomainName = "", ""
+		rt[name] = pool.NewIdlePool(newEnvBuilder(&ic, mountConf), conf.EnvIdleTimeout, conf.ImagePreFork, resetCheck(conf, "environment"))
+		prefork(rt[name], conf.ImagePreFork, conf.PreForkMin)
+	}
+	return rt
+}
+
+// resetCheck returns the check of the pooled kind (environment / cgroup) put
+// back, the failed ones are logged and counted
+func resetCheck(conf *config.Config, kind string) pool.ResetCheck {
+	return pool.ResetCheck{
+		Verify: conf.VerifyReset,
+		OnFailed: func(err error) {
+			logger.Warn("pooled resource destroyed after reset failed", zap.String("kind", kind), zap.Error(err))
+			execResetFailedCount.WithLabelValues(kind).Inc()
+		},
+	}
+}
+
+// eventObserve logs the lifecycle events of requests in debug level
+func eventObserve(e worker.Event) {
+	logger.Debug("exec event", zap.String("type", e.Type.String()), zap.String("requestId", e.RequestID),
+		zap.Int("index", e.Index), zap.Time("time", e.Time))
+}
+
+// seccompName returns the seccomp config name if it is loaded
+func seccompName(p string) string {
+	if _, err := os.Stat(p); err != nil {
+		return ""
+	}
+	return p
+}
+
+func newWorker(conf *config.Config, envPool envexec.EnvironmentPool, imagePools map[string]pool.IdlePool, fs filestore.FileStore) worker.Worker {
+	images := make(map[string]envexec.EnvironmentPool, len(imagePools))
+	for k, p := range imagePools {
+		images[k] = p
+	}
 	return worker.New(worker.Config{
 		FileStore:             fs,
 		EnvironmentPool:       envPool,
+		ImagePools:            images,
 		Parallelism:           conf.Parallelism,
 		WorkDir:               conf.Dir,
 		TimeLimitTickInterval: conf.TimeLimitCheckerInterval,
 		ExtraMemoryLimit:      *conf.ExtraMemoryLimit,
 		OutputLimit:           *conf.OutputLimit,
 		CopyOutLimit:          *conf.CopyOutLimit,
+		CopyOutTotalLimit:     *conf.CopyOutTotalLimit,
+		CopyInLimit:           *conf.CopyInLimit,
+		CopyInTotalLimit:      *conf.CopyInTotalLimit,
+		ArgsSizeLimit:         *conf.ArgsSizeLimit,
+		EnvSizeLimit:          *conf.EnvSizeLimit,
+		BufferPoolLimit:       *conf.BufferPoolLimit,
+		RetryLimit:            conf.RetryInternalError,
+		RetryBackoff:          conf.RetryBackoff,
+		URLAllowHosts:         conf.URLAllowHosts,
+		URLTimeout:            conf.URLTimeout,
+		URLMaxSize:            *conf.URLMaxSize,
+		URLAllowPrivate:       conf.URLAllowPrivate,
+		EnableDebug:           conf.EnableDebug && conf.AuthToken != "",
+		CompileCacheSize:      conf.CompileCacheSize,
+		CompileCacheTTL:       conf.CompileCacheTTL,
+		PreserveDir:           conf.PreserveDir,
+		PreserveTimeout:       conf.PreserveTimeout,
+		SeccompName:           seccompName(conf.SeccompConf),
+		Profiles:              newProfiles(conf.ProfileConf),
 		ExecObserver:          execObserve,
+		EventObserver:         eventObserve,
+		PostExecTimeLimit:     conf.PostExecTimeLimit,
+		ReapDeadline:          conf.ReapDeadline,
+		CgroupPool:            conf.CgroupPool,
+		MaxQueue:              conf.MaxQueue,
+		BusyRetryAfter:        conf.BusyRetryAfter,
+		SignalStatus:          newSignalStatus(conf.SignalStatus),
+		GPUDevices:            conf.GPUDevices,
+		InstructionCounter:    conf.InstructionCounter,
+		MinNice:               newMinNice(conf.MinNice),
+		StdbufLibrary:         conf.StdbufLibrary,
+		NprocFromProcLimit:    conf.NprocFromProcLimit,
+		AllowedGroups:         conf.AllowedGroups,
+		AllowedCapabilities:   conf.AllowedCapabilities,
+
+		CopyOutCollision:       newCollisionPolicy(conf.CopyOutCollision),
+		CopyOutCaseInsensitive: conf.CopyOutCaseInsensitive,
+		CopyOutFileLimit:       conf.CopyOutFileLimit,
+
+		LimitCap: worker.LimitCap{
+			CPULimit:    conf.MaxCPULimit,
+			ClockLimit:  conf.MaxClockLimit,
+			MemoryLimit: *conf.MaxMemoryLimit,
+			StackLimit:  *conf.MaxStackLimit,
+			ProcLimit:   conf.MaxProcLimit,
+			Clamp:       conf.ClampLimits,
+
+			KillGracePeriod: conf.MaxKillGracePeriod,
+
+			CollectorMax: *conf.MaxCollectorSize,
+		},
+		EnvPolicy: worker.EnvPolicy{
+			Allow: conf.EnvAllow,
+			Deny:  conf.EnvDeny,
+			Strip: conf.EnvStrip,
+		},
+		LimitEnv: worker.LimitEnv{
+			CPU:   conf.TimeLimitEnv,
+			Clock: conf.ClockLimitEnv,
+		},
 	})
 }
 
+func newCollisionPolicy(s string) envexec.CollisionPolicy {
+	for p := envexec.CollisionPolicyOverwrite; p <= envexec.CollisionPolicyRename; p++ {
+		if p.String() == s {
+			return p
+		}
+	}
+	log.Fatalln("invalid copy out collision policy:", s)
+	return envexec.CollisionPolicyOverwrite
+}
+
+func newMinNice(n int) int {
+	if n < -20 || n > 0 {
+		log.Fatalln("invalid min nice (expect -20 to 0):", n)
+	}
+	return n
+}
+
+func newProfiles(profileConf string) map[string]worker.Profile {
+	d, err := os.ReadFile(profileConf)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Fatalln("read profile config failed", err)
+		}
+		return nil
+	}
+	var p map[string]worker.Profile
+	if err := yaml.Unmarshal(d, &p); err != nil {
+		log.Fatalln("parse profile config failed", err)
+	}
+	logger.Sugar().Info("Loaded profiles from ", profileConf)
+	return p
+}
+
+// enabledFeatures lists the optional features enabled by the config for
+// capability discovery
+func enabledFeatures(conf *config.Config, b pool.EnvBuilder) []string {
+	var f []string
+	add := func(enabled bool, name string) {
+		if enabled {
+			f = append(f, name)
+		}
+	}
+	debug := conf.EnableDebug && conf.AuthToken != ""
+	add(debug, "debug")
+	add(debug && conf.PreserveDir != "", "preserveOnFailure")
+	add(conf.CompileCacheSize > 0, "compileCache")
+	add(len(conf.URLAllowHosts) > 0, "urlFile")
+	add(conf.EnableCPURate, "cpuRate")
+	add(conf.CgroupPool, "cgroupPool")
+	add(len(conf.GPUDevices) > 0, "gpuDevices")
+	add(conf.InstructionCounter, "instructionLimit")
+	add(conf.StdbufLibrary != "", "stdoutBuffering")
+	add(len(conf.MountImages) > 0, "image")
+	// the native container does not set supplementary groups
+	if gs, ok := b.(pool.GroupsSupporter); ok && gs.SupportsGroups() {
+		add(len(conf.AllowedGroups) > 0, "groups")
+	}
+	if cs, ok := b.(pool.CapabilitiesSupporter); ok && cs.SupportsCapabilities() {
+		add(len(conf.AllowedCapabilities) > 0, "capabilities")
+	}
+	return f
+}
+
 func handleVersion(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"buildVersion": version.Version,
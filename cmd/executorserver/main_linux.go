@@ -1,9 +1,11 @@
 package main
 
 import (
+	"github.com/criyle/go-judge/env/linuxcontainer"
 	"github.com/criyle/go-sandbox/container"
 )
 
 func init() {
+	linuxcontainer.Reaper()
 	container.Init()
 }
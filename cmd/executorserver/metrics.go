@@ -50,6 +50,44 @@ var (
 		Buckets:   memoryBucket,
 	}, []string{"status"})
 
+	execEnvTimeHist = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "environment_time_seconds",
+		Help:      "Histogram for the time to get environment from pool",
+		Buckets:   timeBuckets,
+	})
+
+	execStatusCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "run_total",
+		Help:      "Number of completed runs by the final status",
+	}, []string{"status"})
+
+	execRetryCount = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "retry",
+		Help:      "Number of retries because of internal error",
+	})
+
+	execReapTimeoutCount = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "reap_timeout",
+		Help:      "Number of runs not reaped within the reap deadline after killed",
+	})
+
+	execResetFailedCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "reset_failed",
+		Help:      "Number of pooled environments or cgroups destroyed because of reset failure",
+	}, []string{"kind"})
+
+	execCgroupTimeHist = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "cgroup_time_seconds",
+		Help:      "Histogram for the time to get and set up cgroup",
+		Buckets:   timeBuckets,
+	})
+
 	execMemSummary = prometheus.NewSummaryVec(prometheus.SummaryOpts{
 		Namespace:  metricsNamespace,
 		Name:       "memory",
@@ -62,20 +100,36 @@ func init() {
 	prometheus.MustRegister(execErrorCount)
 	prometheus.MustRegister(execTimeHist, execTimeSummary)
 	prometheus.MustRegister(execMemHist, execMemSummary)
+	prometheus.MustRegister(execEnvTimeHist, execCgroupTimeHist)
+	prometheus.MustRegister(execRetryCount, execStatusCount, execReapTimeoutCount, execResetFailedCount)
 }
 
 func execObserve(res worker.Response) {
 	if res.Error != nil {
 		execErrorCount.Inc()
 	}
+	if len(res.Results) > 0 {
+		execRetryCount.Add(float64(res.Results[0].Retries))
+	}
 	for _, r := range res.Results {
 		status := r.Status.String()
 		d := time.Duration(r.Time)
 		ob := d.Seconds()
 		mob := float64(r.Memory)
+		execStatusCount.WithLabelValues(status).Inc()
 		execTimeHist.WithLabelValues(status).Observe(ob)
 		execTimeSummary.WithLabelValues(status).Observe(ob)
 		execMemHist.WithLabelValues(status).Observe(mob)
 		execMemSummary.WithLabelValues(status).Observe(mob)
+		execEnvTimeHist.Observe(r.EnvironmentTime.Seconds())
+		if r.ReapTimeout {
+			execReapTimeoutCount.Inc()
+		}
+		if r.CgroupTime > 0 {
+			execCgroupTimeHist.Observe(r.CgroupTime.Seconds())
+		}
+	}
+	if execTagMetrics != nil {
+		execTagMetrics.observe(res)
 	}
 }
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/criyle/go-judge/worker"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// otherTagValue replaces tag values beyond the cardinality limit
+const otherTagValue = "other"
+
+// tagMetrics records per run metrics labeled by the allowed request tags
+type tagMetrics struct {
+	keys  []string
+	limit int
+
+	mu     sync.Mutex
+	values map[string]map[string]bool // key -> seen values
+
+	runCount *prometheus.CounterVec
+	timeHist *prometheus.HistogramVec
+	memHist  *prometheus.HistogramVec
+}
+
+var execTagMetrics *tagMetrics
+
+// initTagMetrics registers tagged metrics for allowed tag keys, each key keeps
+// at most limit distinct values and the rest are recorded as other
+func initTagMetrics(keys []string, limit int) {
+	if len(keys) == 0 {
+		return
+	}
+	labels := append([]string{"status"}, keys...)
+	m := &tagMetrics{
+		keys:   keys,
+		limit:  limit,
+		values: make(map[string]map[string]bool),
+		runCount: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "tagged_run",
+			Help:      "Number of runs by status and tags",
+		}, labels),
+		timeHist: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "tagged_time_seconds",
+			Help:      "Histogram for the command execution time by status and tags",
+			Buckets:   timeBuckets,
+		}, labels),
+		memHist: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "tagged_memory_bytes",
+			Help:      "Histogram for the memory by status and tags",
+			Buckets:   memoryBucket,
+		}, labels),
+	}
+	for _, k := range keys {
+		m.values[k] = make(map[string]bool)
+	}
+	prometheus.MustRegister(m.runCount, m.timeHist, m.memHist)
+	execTagMetrics = m
+}
+
+func (m *tagMetrics) observe(res worker.Response) {
+	tags := m.labelValues(res.Tags)
+	for _, r := range res.Results {
+		l := append([]string{r.Status.String()}, tags...)
+		m.runCount.WithLabelValues(l...).Inc()
+		m.timeHist.WithLabelValues(l...).Observe(r.Time.Seconds())
+		m.memHist.WithLabelValues(l...).Observe(float64(r.Memory))
+	}
+}
+
+// labelValues returns tag values for allowed keys limited by cardinality
+func (m *tagMetrics) labelValues(tags map[string]string) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rt := make([]string, 0, len(m.keys))
+	for _, k := range m.keys {
+		v := tags[k]
+		seen := m.values[k]
+		if !seen[v] {
+			if m.limit > 0 && len(seen) >= m.limit {
+				v = otherTagValue
+			} else {
+				seen[v] = true
+			}
+		}
+		rt = append(rt, v)
+	}
+	return rt
+}
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -16,8 +17,19 @@ type CmdFile struct {
 	Src     *string `json:"src"`
 	Content *string `json:"content"`
 	FileID  *string `json:"fileId"`
+	URL     *string `json:"url"`
+	Empty   bool    `json:"empty"`
 	Name    *string `json:"name"`
 	Max     *int64  `json:"max"`
+
+	// OnExceed is the pipe collector policy when max exceeded (fail, truncate, kill)
+	OnExceed string `json:"onExceed,omitempty"`
+
+	// Backpressure closes the pipe collector once the program writes past max
+	Backpressure bool `json:"backpressure,omitempty"`
+
+	// Link links the src file into the work dir as copy in instead of copying
+	Link bool `json:"link,omitempty"`
 }
 
 // Cmd defines command and limits to start a program using in envexec
@@ -27,21 +39,72 @@ type Cmd struct {
 	Files []*CmdFile `json:"files,omitempty"`
 	TTY   bool       `json:"tty,omitempty"`
 
+	Secrets map[string]string `json:"secrets,omitempty"`
+	EnvFile string            `json:"envFile,omitempty"`
+
+	Profile string `json:"profile,omitempty"`
+
 	CPULimit          uint64  `json:"cpuLimit"`
 	RealCPULimit      uint64  `json:"realCpuLimit"`
 	ClockLimit        uint64  `json:"clockLimit"`
 	MemoryLimit       uint64  `json:"memoryLimit"`
+	MemorySoftLimit   uint64  `json:"memorySoftLimit,omitempty"`
 	StackLimit        uint64  `json:"stackLimit"`
 	ProcLimit         uint64  `json:"procLimit"`
 	CPURateLimit      float64 `json:"cpuRateLimit"`
 	StrictMemoryLimit bool    `json:"strictMemoryLimit"`
 
+	SyscallRateLimit  uint64 `json:"syscallRateLimit,omitempty"`
+	KillOnSyscallRate bool   `json:"killOnSyscallRate,omitempty"`
+
+	WriteLimit uint64 `json:"writeLimit,omitempty"`
+	FileLimit  uint64 `json:"fileLimit,omitempty"`
+
+	SocketLimit   uint64 `json:"socketLimit,omitempty"`
+	OpenFileLimit uint64 `json:"openFileLimit,omitempty"`
+	NprocLimit    uint64 `json:"nprocLimit,omitempty"`
+
+	FileSizeLimit uint64 `json:"fileSizeLimit,omitempty"`
+	FreshCgroup   bool   `json:"freshCgroup,omitempty"`
+
+	MemoryAccounting string `json:"memoryAccounting,omitempty"`
+	TimeAccounting   string `json:"timeAccounting,omitempty"`
+	InstructionLimit uint64 `json:"instructionLimit,omitempty"`
+	Nice             int    `json:"nice,omitempty"`
+	StdoutBuffering  string `json:"stdoutBuffering,omitempty"`
+
+	StdinTee *CmdFile `json:"stdinTee,omitempty"`
+
+	EarlyResultQuietPeriod uint64 `json:"earlyResultQuietPeriod,omitempty"`
+
+	KillSignal      int    `json:"killSignal,omitempty"`
+	KillGracePeriod uint64 `json:"killGracePeriod,omitempty"`
+
 	CopyIn map[string]CmdFile `json:"copyIn"`
 
 	CopyOut       []string `json:"copyOut"`
 	CopyOutCached []string `json:"copyOutCached"`
 	CopyOutMax    uint64   `json:"copyOutMax"`
 	CopyOutDir    string   `json:"copyOutDir"`
+
+	CopyOutDirs    []CopyOutDir `json:"copyOutDirs,omitempty"`
+	CopyOutPartial bool         `json:"copyOutPartial,omitempty"`
+	CleanWorkDir   bool         `json:"cleanWorkDir,omitempty"`
+	Groups         []int        `json:"groups,omitempty"`
+	CountSyscalls  bool         `json:"countSyscalls,omitempty"`
+
+	CopyOutTotalMax     uint64 `json:"copyOutTotalMax,omitempty"`
+	CopyOutIncludePipes bool   `json:"copyOutIncludePipes,omitempty"`
+
+	Cache bool `json:"cache,omitempty"`
+
+	PostExec []string `json:"postExec,omitempty"`
+}
+
+// CopyOutDir defines a dir to collect files with name prefix
+type CopyOutDir struct {
+	Src    string `json:"src"`
+	Prefix string `json:"prefix"`
 }
 
 // PipeIndex defines indexing for a pipe fd
@@ -61,6 +124,78 @@ type Request struct {
 	RequestID   string    `json:"requestId"`
 	Cmd         []Cmd     `json:"cmd"`
 	PipeMapping []PipeMap `json:"pipeMapping"`
+
+	Tags  map[string]string `json:"tags,omitempty"`
+	Debug bool              `json:"debug,omitempty"`
+
+	Image        string   `json:"image,omitempty"`
+	ReturnFields []string `json:"returnFields,omitempty"`
+	WarmOnly     bool     `json:"warmOnly,omitempty"`
+
+	Seed         *uint64 `json:"seed,omitempty"`
+	GenerateSeed bool    `json:"generateSeed,omitempty"`
+
+	Checker *Checker `json:"checker,omitempty"`
+
+	PreserveOnFailure bool `json:"preserveOnFailure,omitempty"`
+
+	ClockBudget    uint64 `json:"clockBudget,omitempty"`
+	AcquireTimeout uint64 `json:"acquireTimeout,omitempty"`
+
+	Inputs []SequenceInput `json:"inputs,omitempty"`
+
+	Compare *Compare `json:"compare,omitempty"`
+
+	StopOnFailure bool `json:"stopOnFailure,omitempty"`
+}
+
+// Compare defines the comparison of the output file name of cmd index with
+// the answer
+type Compare struct {
+	Index   int     `json:"index"`
+	Name    string  `json:"name"`
+	Answer  CmdFile `json:"answer"`
+	Policy  string  `json:"policy,omitempty"`
+	Epsilon float64 `json:"epsilon,omitempty"`
+}
+
+// CompareResult defines the result of the comparison
+type CompareResult struct {
+	Match    bool   `json:"match"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// SequenceInput defines an input of the single cmd run in sequence
+type SequenceInput struct {
+	Stdin  *CmdFile           `json:"stdin,omitempty"`
+	CopyIn map[string]CmdFile `json:"copyIn,omitempty"`
+}
+
+// Checker defines the cmd judges the outputs of the cmds
+type Checker struct {
+	Cmd        Cmd            `json:"cmd"`
+	Inputs     []CheckerInput `json:"inputs"`
+	ExitStatus map[int]Status `json:"exitStatus,omitempty"`
+
+	Testlib *TestlibChecker `json:"testlib,omitempty"`
+}
+
+// TestlibChecker defines the files passed to the testlib checker
+type TestlibChecker struct {
+	Input  string `json:"input"`
+	Output string `json:"output"`
+	Answer string `json:"answer"`
+}
+
+// CheckerInput defines result file of cmd index copied into checker
+type CheckerInput struct {
+	Index int    `json:"index"`
+	Name  string `json:"name"`
+	Dst   string `json:"dst"`
 }
 
 // Status offers JSON marshal for envexec.Status
@@ -71,6 +206,33 @@ func (s Status) MarshalJSON() ([]byte, error) {
 	return []byte("\"" + (envexec.Status)(s).String() + "\""), nil
 }
 
+// UnmarshalJSON convert string into status
+func (s *Status) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+	if st, ok := envexec.ParseStatus(str); ok {
+		*s = Status(st)
+		return nil
+	}
+	return fmt.Errorf("status %s is not valid", str)
+}
+
+// MarshalBinary convert status into string for binary encodings (msgpack)
+func (s Status) MarshalBinary() ([]byte, error) {
+	return []byte((envexec.Status)(s).String()), nil
+}
+
+// UnmarshalBinary convert string into status for binary encodings
+func (s *Status) UnmarshalBinary(b []byte) error {
+	if st, ok := envexec.ParseStatus(string(b)); ok {
+		*s = Status(st)
+		return nil
+	}
+	return fmt.Errorf("status %s is not valid", b)
+}
+
 // Result defines single command result
 type Result struct {
 	Status     Status            `json:"status"`
@@ -79,8 +241,73 @@ type Result struct {
 	Time       uint64            `json:"time"`
 	Memory     uint64            `json:"memory"`
 	RunTime    uint64            `json:"runTime"`
+	EnvTime    uint64            `json:"environmentTime"`
+	CgroupTime uint64            `json:"cgroupTime,omitempty"`
+	Retries    int               `json:"retries,omitempty"`
+	MemWarning bool              `json:"memoryWarning,omitempty"`
+	ProcPeak   uint64            `json:"procPeak,omitempty"`
+	Warning    string            `json:"warning,omitempty"`
 	Files      map[string]string `json:"files,omitempty"`
 	FileIDs    map[string]string `json:"fileIds,omitempty"`
+	Debug      *DebugInfo        `json:"debug,omitempty"`
+	CacheHit   bool              `json:"cacheHit,omitempty"`
+	Preserved  string            `json:"preserved,omitempty"`
+
+	LimitsClamped []string `json:"limitsClamped,omitempty"`
+	UserTime      uint64   `json:"userTime,omitempty"`
+	SystemTime    uint64   `json:"systemTime,omitempty"`
+
+	PostExec   *PostExecResult `json:"postExec,omitempty"`
+	ExitReason string          `json:"exitReason,omitempty"`
+	MemoryPeak uint64          `json:"memoryPeak,omitempty"`
+	SwapUsage  uint64          `json:"swapUsage,omitempty"`
+
+	EarlyResult bool `json:"earlyResult,omitempty"`
+	ReapTimeout bool `json:"reapTimeout,omitempty"`
+
+	Instructions uint64            `json:"instructions,omitempty"`
+	OmittedFiles map[string]uint64 `json:"omittedFiles,omitempty"`
+	SocketPeak   uint64            `json:"socketPeak,omitempty"`
+	ProcLimitHit string            `json:"procLimitHit,omitempty"`
+	CopyOutBytes uint64            `json:"copyOutBytes,omitempty"`
+	PipeBytes    uint64            `json:"pipeBytes,omitempty"`
+
+	TruncatedFiles map[string]uint64 `json:"truncatedFiles,omitempty"`
+}
+
+// PostExecResult defines the result of the post exec cmd
+type PostExecResult struct {
+	Status     Status `json:"status"`
+	ExitStatus int    `json:"exitStatus"`
+	Error      string `json:"error,omitempty"`
+	Time       uint64 `json:"time"`
+}
+
+// DebugInfo defines the resolved invocation of the command
+type DebugInfo struct {
+	Profile           string   `json:"profile,omitempty"`
+	Seccomp           string   `json:"seccomp,omitempty"`
+	Args              []string `json:"args"`
+	Env               []string `json:"env"`
+	CPULimit          uint64   `json:"cpuLimit"`
+	ClockLimit        uint64   `json:"clockLimit"`
+	MemoryLimit       uint64   `json:"memoryLimit"`
+	StackLimit        uint64   `json:"stackLimit"`
+	OutputLimit       uint64   `json:"outputLimit"`
+	ProcLimit         uint64   `json:"procLimit"`
+	CPURateLimit      float64  `json:"cpuRateLimit"`
+	StrictMemoryLimit bool     `json:"strictMemoryLimit"`
+	CopyOutMax        uint64   `json:"copyOutMax"`
+	Cgroup            string   `json:"cgroup,omitempty"`
+
+	CgroupVersion     int      `json:"cgroupVersion"`
+	CgroupControllers []string `json:"cgroupControllers"`
+
+	OpenFileCount *int     `json:"openFileCount,omitempty"`
+	OpenFiles     []string `json:"openFiles,omitempty"`
+
+	Syscalls  map[string]uint64 `json:"syscalls,omitempty"`
+	Diagnosis string            `json:"diagnosis,omitempty"`
 }
 
 // Response defines worker response for single request
@@ -88,19 +315,77 @@ type Response struct {
 	RequestID string   `json:"requestId"`
 	Results   []Result `json:"results"`
 	ErrorMsg  string   `json:"error,omitempty"`
+	Seed      *uint64  `json:"seed,omitempty"`
+	Checker   *Result  `json:"checker,omitempty"`
+	Verdict   *Status  `json:"verdict,omitempty"`
+
+	ErrorCode  string `json:"errorCode,omitempty"`
+	ErrorField string `json:"errorField,omitempty"`
+
+	ClockBudgetExceeded bool   `json:"clockBudgetExceeded,omitempty"`
+	CheckerComment      string `json:"checkerComment,omitempty"`
+
+	Compare *CompareResult `json:"compare,omitempty"`
+}
+
+// Rejection defines the structured error of the rejected request
+type Rejection struct {
+	Code  string `json:"code"`
+	Field string `json:"field,omitempty"`
+	Error string `json:"error"`
+}
+
+// ConvertRejection converts the error of rejected request, errors
+// other than worker.RejectError are invalid argument
+func ConvertRejection(err error) Rejection {
+	re, ok := worker.Rejection(err)
+	if !ok {
+		re = &worker.RejectError{Code: worker.RejectInvalidArgument, Err: err}
+	}
+	return Rejection{
+		Code:  string(re.Code),
+		Field: re.Field,
+		Error: re.Error(),
+	}
 }
 
 // ConvertResponse converts
 func ConvertResponse(r worker.Response) Response {
 	ret := Response{
 		RequestID: r.RequestID,
+		Seed:      r.Seed,
 		Results:   make([]Result, 0, len(r.Results)),
+
+		ClockBudgetExceeded: r.ClockBudgetExceeded,
+		CheckerComment:      r.CheckerComment,
 	}
 	for _, r := range r.Results {
 		ret.Results = append(ret.Results, convertResult(r))
 	}
 	if r.Error != nil {
 		ret.ErrorMsg = r.Error.Error()
+		if re, ok := worker.Rejection(r.Error); ok {
+			ret.ErrorCode = string(re.Code)
+			ret.ErrorField = re.Field
+		}
+	}
+	if r.Checker != nil {
+		c := convertResult(*r.Checker)
+		ret.Checker = &c
+	}
+	if r.Verdict != envexec.StatusInvalid {
+		v := Status(r.Verdict)
+		ret.Verdict = &v
+	}
+	if c := r.Compare; c != nil {
+		ret.Compare = &CompareResult{
+			Match:    c.Match,
+			Line:     c.Line,
+			Column:   c.Column,
+			Expected: c.Expected,
+			Actual:   c.Actual,
+			Error:    c.Error,
+		}
 	}
 	return ret
 }
@@ -111,6 +396,19 @@ func ConvertRequest(r *Request, srcPrefix string) (*worker.Request, error) {
 		RequestID:   r.RequestID,
 		Cmd:         make([]worker.Cmd, 0, len(r.Cmd)),
 		PipeMapping: make([]worker.PipeMap, 0, len(r.PipeMapping)),
+		Tags:        r.Tags,
+		Debug:       r.Debug,
+
+		Image:        r.Image,
+		ReturnFields: r.ReturnFields,
+		WarmOnly:     r.WarmOnly,
+		Seed:         r.Seed,
+		GenerateSeed: r.GenerateSeed,
+
+		PreserveOnFailure: r.PreserveOnFailure,
+		ClockBudget:       time.Duration(r.ClockBudget),
+		AcquireTimeout:    time.Duration(r.AcquireTimeout),
+		StopOnFailure:     r.StopOnFailure,
 	}
 	for _, c := range r.Cmd {
 		wc, err := convertCmd(c, srcPrefix)
@@ -122,9 +420,114 @@ func ConvertRequest(r *Request, srcPrefix string) (*worker.Request, error) {
 	for _, p := range r.PipeMapping {
 		req.PipeMapping = append(req.PipeMapping, convertPipe(p))
 	}
+	if r.Checker != nil {
+		ck, err := convertChecker(r.Checker, srcPrefix)
+		if err != nil {
+			return nil, err
+		}
+		req.Checker = ck
+	}
+	for _, in := range r.Inputs {
+		si, err := convertSequenceInput(in, srcPrefix)
+		if err != nil {
+			return nil, err
+		}
+		req.Inputs = append(req.Inputs, si)
+	}
+	if r.Compare != nil {
+		c, err := convertCompare(r.Compare, srcPrefix)
+		if err != nil {
+			return nil, err
+		}
+		req.Compare = c
+	}
 	return req, nil
 }
 
+func convertCompare(c *Compare, srcPrefix string) (*worker.Compare, error) {
+	answer, err := convertCmdFile(&c.Answer, srcPrefix)
+	if err != nil {
+		return nil, err
+	}
+	p, err := convertComparePolicy(c.Policy)
+	if err != nil {
+		return nil, err
+	}
+	return &worker.Compare{
+		Index:   c.Index,
+		Name:    c.Name,
+		Answer:  answer,
+		Policy:  p,
+		Epsilon: c.Epsilon,
+	}, nil
+}
+
+func convertComparePolicy(s string) (worker.ComparePolicy, error) {
+	if s == "" {
+		return worker.ComparePolicyExact, nil
+	}
+	for p := worker.ComparePolicyExact; p <= worker.ComparePolicyFloat; p++ {
+		if p.String() == s {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("compare policy %s is not valid", s)
+}
+
+func convertSequenceInput(in SequenceInput, srcPrefix string) (worker.SequenceInput, error) {
+	var si worker.SequenceInput
+	if in.Stdin != nil {
+		cf, err := convertCmdFile(in.Stdin, srcPrefix)
+		if err != nil {
+			return si, err
+		}
+		si.Stdin = cf
+	}
+	if in.CopyIn != nil {
+		si.CopyIn = make(map[string]worker.CmdFile)
+		for k, f := range in.CopyIn {
+			cf, err := convertCmdFile(&f, srcPrefix)
+			if err != nil {
+				return si, err
+			}
+			si.CopyIn[k] = cf
+		}
+	}
+	return si, nil
+}
+
+func convertChecker(c *Checker, srcPrefix string) (*worker.Checker, error) {
+	cmd, err := convertCmd(c.Cmd, srcPrefix)
+	if err != nil {
+		return nil, err
+	}
+	ck := &worker.Checker{
+		Cmd:    cmd,
+		Inputs: make([]worker.CheckerInput, 0, len(c.Inputs)),
+	}
+	for _, in := range c.Inputs {
+		ck.Inputs = append(ck.Inputs, worker.CheckerInput{
+			Index: in.Index,
+			Name:  in.Name,
+			Dst:   in.Dst,
+		})
+	}
+	if c.ExitStatus != nil {
+		ck.ExitStatus = make(map[int]envexec.Status, len(c.ExitStatus))
+		for k, v := range c.ExitStatus {
+			ck.ExitStatus[k] = envexec.Status(v)
+		}
+	}
+	if t := c.Testlib; t != nil {
+		ck.Testlib = &worker.TestlibChecker{
+			Input:  t.Input,
+			Output: t.Output,
+			Answer: t.Answer,
+		}
+	}
+	return ck, nil
+}
+
 func convertResult(r worker.Result) Result {
 	res := Result{
 		Status:     Status(r.Status),
@@ -133,7 +536,76 @@ func convertResult(r worker.Result) Result {
 		Time:       uint64(r.Time),
 		RunTime:    uint64(r.RunTime),
 		Memory:     uint64(r.Memory),
+		EnvTime:    uint64(r.EnvironmentTime),
+		CgroupTime: uint64(r.CgroupTime),
+		Retries:    r.Retries,
+		MemWarning: r.MemoryWarning,
+		ProcPeak:   r.ProcPeak,
+		Warning:    r.Warning,
 		FileIDs:    r.FileIDs,
+		CacheHit:   r.CacheHit,
+		Preserved:  r.Preserved,
+
+		LimitsClamped: r.LimitsClamped,
+		UserTime:      uint64(r.UserTime),
+		SystemTime:    uint64(r.SystemTime),
+		ExitReason:    r.ExitReason.String(),
+		MemoryPeak:    uint64(r.MemoryPeak),
+		SwapUsage:     uint64(r.SwapUsage),
+		EarlyResult:   r.EarlyResult,
+		ReapTimeout:   r.ReapTimeout,
+		Instructions:  r.Instructions,
+		SocketPeak:    r.SocketPeak,
+		ProcLimitHit:  r.ProcLimitHit.String(),
+		CopyOutBytes:  uint64(r.CopyOutBytes),
+		PipeBytes:     uint64(r.PipeBytes),
+	}
+	if len(r.OmittedFiles) > 0 {
+		res.OmittedFiles = make(map[string]uint64, len(r.OmittedFiles))
+		for n, s := range r.OmittedFiles {
+			res.OmittedFiles[n] = uint64(s)
+		}
+	}
+	if len(r.TruncatedFiles) > 0 {
+		res.TruncatedFiles = make(map[string]uint64, len(r.TruncatedFiles))
+		for n, s := range r.TruncatedFiles {
+			res.TruncatedFiles[n] = uint64(s)
+		}
+	}
+	if p := r.PostExec; p != nil {
+		res.PostExec = &PostExecResult{
+			Status:     Status(p.Status),
+			ExitStatus: p.ExitStatus,
+			Error:      p.Error,
+			Time:       uint64(p.Time),
+		}
+	}
+	if d := r.Debug; d != nil {
+		res.Debug = &DebugInfo{
+			Profile:           d.Profile,
+			Seccomp:           d.Seccomp,
+			Args:              d.Args,
+			Env:               d.Env,
+			CPULimit:          uint64(d.CPULimit),
+			ClockLimit:        uint64(d.ClockLimit),
+			MemoryLimit:       uint64(d.MemoryLimit),
+			StackLimit:        uint64(d.StackLimit),
+			OutputLimit:       uint64(d.OutputLimit),
+			ProcLimit:         d.ProcLimit,
+			CPURateLimit:      d.CPURateLimit,
+			StrictMemoryLimit: d.StrictMemoryLimit,
+			CopyOutMax:        uint64(d.CopyOutMax),
+			Cgroup:            d.Cgroup,
+			CgroupVersion:     d.CgroupVersion,
+			CgroupControllers: d.CgroupControllers,
+			Syscalls:          d.Syscalls,
+			Diagnosis:         d.Diagnosis,
+		}
+		if d.OpenFiles != nil {
+			n := len(d.OpenFiles)
+			res.Debug.OpenFileCount = &n
+			res.Debug.OpenFiles = d.OpenFiles
+		}
 	}
 	if r.Files != nil {
 		res.Files = make(map[string]string)
@@ -165,11 +637,15 @@ func convertCmd(c Cmd, srcPrefix string) (worker.Cmd, error) {
 	w := worker.Cmd{
 		Args:              c.Args,
 		Env:               c.Env,
+		Secrets:           c.Secrets,
+		EnvFile:           c.EnvFile,
 		Files:             make([]worker.CmdFile, 0, len(c.Files)),
 		TTY:               c.TTY,
+		Profile:           c.Profile,
 		CPULimit:          time.Duration(c.CPULimit),
 		ClockLimit:        time.Duration(clockLimit),
 		MemoryLimit:       envexec.Size(c.MemoryLimit),
+		MemorySoftLimit:   envexec.Size(c.MemorySoftLimit),
 		StackLimit:        envexec.Size(c.StackLimit),
 		ProcLimit:         c.ProcLimit,
 		CPURateLimit:      c.CPURateLimit,
@@ -178,6 +654,39 @@ func convertCmd(c Cmd, srcPrefix string) (worker.Cmd, error) {
 		CopyOutCached:     c.CopyOutCached,
 		CopyOutMax:        c.CopyOutMax,
 		CopyOutDir:        c.CopyOutDir,
+
+		SyscallRateLimit:  c.SyscallRateLimit,
+		KillOnSyscallRate: c.KillOnSyscallRate,
+
+		WriteLimit: envexec.Size(c.WriteLimit),
+		FileLimit:  c.FileLimit,
+
+		SocketLimit:   c.SocketLimit,
+		OpenFileLimit: c.OpenFileLimit,
+		NprocLimit:    c.NprocLimit,
+
+		FileSizeLimit: envexec.Size(c.FileSizeLimit),
+		FreshCgroup:   c.FreshCgroup,
+
+		InstructionLimit: c.InstructionLimit,
+		Nice:             c.Nice,
+
+		CopyOutPartial: c.CopyOutPartial,
+		CleanWorkDir:   c.CleanWorkDir,
+		Groups:         c.Groups,
+		CountSyscalls:  c.CountSyscalls,
+
+		CopyOutTotalMax:     c.CopyOutTotalMax,
+		CopyOutIncludePipes: c.CopyOutIncludePipes,
+
+		EarlyResultQuietPeriod: time.Duration(c.EarlyResultQuietPeriod),
+
+		KillSignal:      c.KillSignal,
+		KillGracePeriod: time.Duration(c.KillGracePeriod),
+
+		Cache: c.Cache,
+
+		PostExec: c.PostExec,
 	}
 	for _, f := range c.Files {
 		cf, err := convertCmdFile(f, srcPrefix)
@@ -186,6 +695,33 @@ func convertCmd(c Cmd, srcPrefix string) (worker.Cmd, error) {
 		}
 		w.Files = append(w.Files, cf)
 	}
+	a, err := convertMemoryAccounting(c.MemoryAccounting)
+	if err != nil {
+		return w, err
+	}
+	w.MemoryAccounting = a
+	ta, err := convertTimeAccounting(c.TimeAccounting)
+	if err != nil {
+		return w, err
+	}
+	w.TimeAccounting = ta
+	b, err := convertStdoutBuffering(c.StdoutBuffering)
+	if err != nil {
+		return w, err
+	}
+	w.StdoutBuffering = b
+	if t := c.StdinTee; t != nil {
+		if t.Name == nil || t.Max == nil {
+			return w, fmt.Errorf("stdin tee requires name and max")
+		}
+		w.StdinTee = &worker.PipeCollector{Name: *t.Name, Max: *t.Max}
+	}
+	for _, d := range c.CopyOutDirs {
+		w.CopyOutDirs = append(w.CopyOutDirs, worker.CopyOutDir{
+			Src:    d.Src,
+			Prefix: d.Prefix,
+		})
+	}
 	if c.CopyIn != nil {
 		w.CopyIn = make(map[string]worker.CmdFile)
 		for k, f := range c.CopyIn {
@@ -213,18 +749,71 @@ func convertCmdFile(f *CmdFile, srcPrefix string) (worker.CmdFile, error) {
 				return nil, fmt.Errorf("file (%s) does not under (%s)", *f.Src, srcPrefix)
 			}
 		}
-		return &worker.LocalFile{Src: *f.Src}, nil
+		return &worker.LocalFile{Src: *f.Src, Link: f.Link}, nil
 	case f.Content != nil:
 		return &worker.MemoryFile{Content: []byte(*f.Content)}, nil
 	case f.FileID != nil:
 		return &worker.CachedFile{FileID: *f.FileID}, nil
+	case f.URL != nil:
+		return &worker.URLFile{URL: *f.URL}, nil
+	case f.Empty:
+		return &worker.EmptyFile{}, nil
 	case f.Max != nil && f.Name != nil:
-		return &worker.PipeCollector{Name: *f.Name, Max: *f.Max}, nil
+		p, err := convertCollectorPolicy(f.OnExceed)
+		if err != nil {
+			return nil, err
+		}
+		return &worker.PipeCollector{Name: *f.Name, Max: *f.Max, Policy: p, Backpressure: f.Backpressure}, nil
 	default:
 		return nil, fmt.Errorf("file is not valid for cmd")
 	}
 }
 
+func convertCollectorPolicy(s string) (envexec.CollectorPolicy, error) {
+	if s == "" {
+		return envexec.CollectorPolicyFail, nil
+	}
+	for p := envexec.CollectorPolicyFail; p <= envexec.CollectorPolicyKill; p++ {
+		if p.String() == s {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("pipe collector onExceed %s is not valid", s)
+}
+
+func convertMemoryAccounting(s string) (envexec.MemoryAccounting, error) {
+	if s == "" {
+		return envexec.MemoryAccountingPeak, nil
+	}
+	for a := envexec.MemoryAccountingPeak; a <= envexec.MemoryAccountingCurrent; a++ {
+		if a.String() == s {
+			return a, nil
+		}
+	}
+	return 0, fmt.Errorf("memoryAccounting %s is not valid", s)
+}
+
+func convertTimeAccounting(s string) (envexec.TimeAccounting, error) {
+	if s == "" {
+		return envexec.TimeAccountingCgroup, nil
+	}
+	for a := envexec.TimeAccountingCgroup; a <= envexec.TimeAccountingProcess; a++ {
+		if a.String() == s {
+			return a, nil
+		}
+	}
+	return 0, fmt.Errorf("timeAccounting %s is not valid", s)
+}
+
+func convertStdoutBuffering(s string) (worker.StdoutBuffering, error) {
+	for b := worker.StdoutBufferingDefault; b <= worker.StdoutBufferingNone; b++ {
+		if b.String() == s {
+			return b, nil
+		}
+	}
+	return 0, fmt.Errorf("stdoutBuffering %s is not valid", s)
+}
+
 func checkPathPrefix(path, prefix string) (bool, error) {
 	if filepath.IsAbs(path) {
 		return strings.HasPrefix(filepath.Clean(path), prefix), nil
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// initPreserveHandle registers admin handles for preserved work dirs
+//
+// GET /preserved, DELETE /preserved/:id
+func initPreserveHandle(r gin.IRouter, dir string) {
+	r.GET("/preserved", func(c *gin.Context) {
+		entries, err := os.ReadDir(dir)
+		if err != nil && !os.IsNotExist(err) {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+			return
+		}
+		ids := make([]string, 0, len(entries))
+		for _, e := range entries {
+			if e.IsDir() {
+				ids = append(ids, e.Name())
+			}
+		}
+		c.JSON(http.StatusOK, ids)
+	})
+	r.DELETE("/preserved/:id", func(c *gin.Context) {
+		id := c.Param("id")
+		if id == "" || id == "." || id == ".." || strings.ContainsAny(id, `/\`) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, "invalid preserved id")
+			return
+		}
+		p := filepath.Join(dir, id)
+		if _, err := os.Stat(p); err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, "not found")
+			return
+		}
+		if err := os.RemoveAll(p); err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+}
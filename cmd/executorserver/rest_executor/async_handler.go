@@ -0,0 +1,149 @@
+package restexecutor
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/criyle/go-judge/cmd/executorserver/model"
+	"github.com/criyle/go-judge/worker"
+	"github.com/gin-gonic/gin"
+)
+
+const asyncIDLength = 12
+
+// asyncStore holds results of async runs until they expire
+type asyncStore struct {
+	ttl        time.Duration
+	maxPending int
+
+	mu      sync.Mutex
+	pending int
+	runs    map[string]*asyncRun
+}
+
+// asyncRun is a submitted async run, rt is available after done closed
+type asyncRun struct {
+	done chan struct{}
+	rt   worker.Response
+}
+
+func newAsyncStore(ttl time.Duration, maxPending int) *asyncStore {
+	return &asyncStore{
+		ttl:        ttl,
+		maxPending: maxPending,
+		runs:       make(map[string]*asyncRun),
+	}
+}
+
+// add reserves a pending run, it returns false if too many runs are pending
+func (s *asyncStore) add() (string, *asyncRun, bool, error) {
+	id, err := generateAsyncID()
+	if err != nil {
+		return "", nil, false, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.maxPending > 0 && s.pending >= s.maxPending {
+		return "", nil, false, nil
+	}
+	s.pending++
+	r := &asyncRun{done: make(chan struct{})}
+	s.runs[id] = r
+	return id, r, true, nil
+}
+
+// finish stores the result and removes it after ttl (0 never expires)
+func (s *asyncStore) finish(id string, r *asyncRun, rt worker.Response) {
+	r.rt = rt
+	close(r.done)
+
+	s.mu.Lock()
+	s.pending--
+	s.mu.Unlock()
+
+	if s.ttl > 0 {
+		time.AfterFunc(s.ttl, func() {
+			s.mu.Lock()
+			delete(s.runs, id)
+			s.mu.Unlock()
+		})
+	}
+}
+
+func (s *asyncStore) get(id string) (*asyncRun, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.runs[id]
+	return r, ok
+}
+
+func generateAsyncID() (string, error) {
+	b := make([]byte, asyncIDLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if _, err := base32.NewEncoder(base32.StdEncoding, &buf).Write(b); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// handleRunAsync submits the request and returns its id immediately
+func (h *handle) handleRunAsync(c *gin.Context) {
+	var req model.Request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithRejection(c, err)
+		return
+	}
+
+	if len(req.Cmd) == 0 {
+		abortWithRejection(c, errNoCmd)
+		return
+	}
+	r, err := model.ConvertRequest(&req, h.srcPrefix)
+	if err != nil {
+		abortWithRejection(c, err)
+		return
+	}
+	id, ar, ok, err := h.async.add()
+	if err != nil {
+		c.Error(err)
+		c.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, "too many pending async runs")
+		return
+	}
+	h.logger.Sugar().Debugf("async request %s: %+v", id, r)
+	go func() {
+		// the run outlives the http request
+		rt := <-h.worker.Submit(context.Background(), r)
+		h.logger.Sugar().Debugf("async response %s: %+v", id, rt)
+		h.async.finish(id, ar, rt)
+	}()
+	c.JSON(http.StatusAccepted, gin.H{"id": id})
+}
+
+// handleRunAsyncGet returns 202 while pending or the response once done
+func (h *handle) handleRunAsyncGet(c *gin.Context) {
+	id := c.Param("id")
+	ar, ok := h.async.get(id)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusNotFound, "not found")
+		return
+	}
+	select {
+	case <-ar.done:
+		renderResponse(c, http.StatusOK, ar.rt, true)
+	default:
+		c.JSON(http.StatusAccepted, gin.H{"id": id})
+	}
+}
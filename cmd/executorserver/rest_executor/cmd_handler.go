@@ -1,7 +1,10 @@
 package restexecutor
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/criyle/go-judge/cmd/executorserver/model"
 	"github.com/criyle/go-judge/filestore"
@@ -12,17 +15,20 @@ import (
 
 // Register registers executor the handler
 //
-// POST /run, GET /file, POST /file, GET /file/:fid, DELETE /file/:fid
+// POST /run, POST /run/async, GET /run/async/:id, GET /file, POST /file,
+// GET /file/:fid, DELETE /file/:fid
 type Register interface {
 	Register(*gin.Engine)
 }
 
-// New creates new REST API handler
-func New(worker worker.Worker, fs filestore.FileStore, srcPrefix string, logger *zap.Logger) Register {
+// New creates new REST API handler, async results expire after asyncTTL and
+// at most asyncMaxPending async runs are pending (0 unlimited)
+func New(worker worker.Worker, fs filestore.FileStore, srcPrefix string, asyncTTL time.Duration, asyncMaxPending int, logger *zap.Logger) Register {
 	return &handle{
 		worker:     worker,
 		fileHandle: fileHandle{fs: fs},
 		srcPrefix:  srcPrefix,
+		async:      newAsyncStore(asyncTTL, asyncMaxPending),
 		logger:     logger,
 	}
 }
@@ -31,12 +37,15 @@ type handle struct {
 	worker worker.Worker
 	fileHandle
 	srcPrefix string
+	async     *asyncStore
 	logger    *zap.Logger
 }
 
 func (h *handle) Register(r *gin.Engine) {
 	// Run handle
 	r.POST("/run", h.handleRun)
+	r.POST("/run/async", h.handleRunAsync)
+	r.GET("/run/async/:id", h.handleRunAsyncGet)
 
 	// File handle
 	r.GET("/file", h.fileGet)
@@ -48,28 +57,92 @@ func (h *handle) Register(r *gin.Engine) {
 func (h *handle) handleRun(c *gin.Context) {
 	var req model.Request
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.Error(err)
-		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		abortWithRejection(c, err)
 		return
 	}
 
 	if len(req.Cmd) == 0 {
-		c.AbortWithStatusJSON(http.StatusBadRequest, "no cmd provided")
+		abortWithRejection(c, errNoCmd)
 		return
 	}
 	r, err := model.ConvertRequest(&req, h.srcPrefix)
 	if err != nil {
-		c.Error(err)
-		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		abortWithRejection(c, err)
 		return
 	}
+	var rawName string
+	if raw, _ := strconv.ParseBool(c.Query("raw")); raw {
+		rawName, err = rawCollectorName(r)
+		if err != nil {
+			abortWithRejection(c, err)
+			return
+		}
+	}
 	h.logger.Sugar().Debugf("request: %+v", r)
 	rt := <-h.worker.Submit(c.Request.Context(), r)
 	h.logger.Sugar().Debugf("response: %+v", rt)
 	if rt.Error != nil {
+		if _, ok := worker.Rejection(rt.Error); ok {
+			abortWithRejection(c, rt.Error)
+			return
+		}
 		c.Error(rt.Error)
 		c.AbortWithStatusJSON(http.StatusInternalServerError, rt.Error.Error())
 		return
 	}
-	c.JSON(http.StatusOK, model.ConvertResponse(rt).Results)
+	if rawName != "" {
+		writeRawResult(c, rt.Results[0], rawName)
+		return
+	}
+	full, _ := strconv.ParseBool(c.Query("full"))
+	renderResponse(c, http.StatusOK, rt, full)
+}
+
+// errNoCmd rejects the request without cmd
+var errNoCmd = &worker.RejectError{Code: worker.RejectInvalidArgument, Field: "cmd", Err: errors.New("no cmd provided")}
+
+// abortWithRejection responds the structured rejection of the request with
+// 503 and Retry-After if the worker is busy, otherwise 400
+func abortWithRejection(c *gin.Context, err error) {
+	c.Error(err)
+	var be *worker.BusyError
+	if errors.As(err, &be) {
+		c.Header("Retry-After", strconv.Itoa(be.RetryAfterSeconds()))
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, model.ConvertRejection(err))
+		return
+	}
+	c.AbortWithStatusJSON(http.StatusBadRequest, model.ConvertRejection(err))
+}
+
+// rawCollectorName returns the name of the only pipe collector in the only cmd
+func rawCollectorName(r *worker.Request) (string, error) {
+	if len(r.Cmd) != 1 {
+		return "", errors.New("raw output requires exactly one cmd")
+	}
+	var name string
+	for _, f := range r.Cmd[0].Files {
+		if p, ok := f.(*worker.PipeCollector); ok {
+			if name != "" {
+				return "", errors.New("raw output requires exactly one pipe collector")
+			}
+			name = p.Name
+		}
+	}
+	if name == "" {
+		return "", errors.New("raw output requires exactly one pipe collector")
+	}
+	return name, nil
+}
+
+// writeRawResult writes collected output as body and the result in headers
+func writeRawResult(c *gin.Context, r worker.Result, name string) {
+	c.Header("X-Exec-Status", r.Status.String())
+	c.Header("X-Exec-Exit-Status", strconv.Itoa(r.ExitStatus))
+	c.Header("X-Exec-Time", strconv.FormatInt(int64(r.Time), 10))
+	c.Header("X-Exec-Run-Time", strconv.FormatInt(int64(r.RunTime), 10))
+	c.Header("X-Exec-Memory", strconv.FormatUint(uint64(r.Memory), 10))
+	if r.Error != "" {
+		c.Header("X-Exec-Error", r.Error)
+	}
+	c.Data(http.StatusOK, "application/octet-stream", r.Files[name])
 }
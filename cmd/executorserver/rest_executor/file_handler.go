@@ -6,7 +6,9 @@ import (
 	"mime"
 	"net/http"
 	"path"
+	"time"
 
+	"github.com/criyle/go-judge/file"
 	"github.com/criyle/go-judge/filestore"
 	"github.com/gin-gonic/gin"
 )
@@ -38,7 +40,28 @@ func (f *fileHandle) filePost(c *gin.Context) {
 		return
 	}
 
-	id, err := f.fs.Add(fh.Filename, b)
+	var (
+		id string
+		d  time.Duration
+	)
+	if ttl := c.Query("ttl"); ttl != "" {
+		if d, err = time.ParseDuration(ttl); err != nil {
+			c.AbortWithError(http.StatusBadRequest, err)
+			return
+		}
+		if d <= 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, "ttl must be positive")
+			return
+		}
+		ts, ok := f.fs.(filestore.TTLFileStore)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusBadRequest, "file store does not support ttl")
+			return
+		}
+		id, err = ts.AddWithTTL(fh.Filename, b, d)
+	} else {
+		id, err = f.fs.Add(fh.Filename, b)
+	}
 	if err != nil {
 		c.AbortWithError(http.StatusInternalServerError, err)
 		return
@@ -61,15 +84,32 @@ func (f *fileHandle) fileIDGet(c *gin.Context) {
 		c.AbortWithStatus(http.StatusNotFound)
 		return
 	}
-	content, err := file.Content()
+	r, err := fileReadSeeker(file)
 	if err != nil {
 		c.AbortWithError(http.StatusInternalServerError, err)
 		return
 	}
+	defer r.Close()
 
-	typ := mime.TypeByExtension(path.Ext(file.Name()))
+	if typ := mime.TypeByExtension(path.Ext(file.Name())); typ != "" {
+		c.Header("Content-Type", typ)
+	}
 	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", file.Name()))
-	c.Data(http.StatusOK, typ, content)
+	// serves range requests (Range / If-Range headers) for partial downloads
+	http.ServeContent(c.Writer, c.Request, file.Name(), time.Time{}, r)
+}
+
+// fileReadSeeker opens seekable reader, files without range support are read
+// into memory
+func fileReadSeeker(f file.File) (io.ReadSeekCloser, error) {
+	if rs, ok := f.(file.ReadSeekerOpener); ok {
+		return rs.ReadSeeker()
+	}
+	content, err := f.Content()
+	if err != nil {
+		return nil, err
+	}
+	return file.NewMemFile(f.Name(), content).(file.ReadSeekerOpener).ReadSeeker()
 }
 
 func (f *fileHandle) fileIDDelete(c *gin.Context) {
@@ -0,0 +1,62 @@
+package restexecutor
+
+import (
+	"strings"
+
+	grpcexecutor "github.com/criyle/go-judge/cmd/executorserver/grpc_executor"
+	"github.com/criyle/go-judge/cmd/executorserver/model"
+	"github.com/criyle/go-judge/worker"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/gin-gonic/gin/render"
+)
+
+// response formats negotiated by Accept
+const (
+	formatJSON = iota
+	formatMsgPack
+	formatProtobuf
+)
+
+var acceptFormats = map[string]int{
+	binding.MIMEJSON:                  formatJSON,
+	binding.MIMEMSGPACK:               formatMsgPack,
+	binding.MIMEMSGPACK2:              formatMsgPack,
+	binding.MIMEPROTOBUF:              formatProtobuf,
+	"application/protobuf":            formatProtobuf,
+	"application/vnd.google.protobuf": formatProtobuf,
+}
+
+// acceptFormat returns the first supported format of the Accept header, JSON
+// if none of them is supported
+func acceptFormat(c *gin.Context) int {
+	for _, a := range strings.Split(c.GetHeader("Accept"), ",") {
+		t := strings.TrimSpace(strings.SplitN(a, ";", 2)[0])
+		if f, ok := acceptFormats[strings.ToLower(t)]; ok {
+			return f
+		}
+	}
+	return formatJSON
+}
+
+// renderResponse writes the response in the format negotiated, msgpack
+// encodes the same struct as JSON (files as raw bytes instead of escaped
+// strings) and protobuf encodes the gRPC response, which is always the full
+// response
+func renderResponse(c *gin.Context, code int, rt worker.Response, full bool) {
+	f := acceptFormat(c)
+	if f == formatProtobuf {
+		c.ProtoBuf(code, grpcexecutor.ConvertResponse(rt))
+		return
+	}
+	r := model.ConvertResponse(rt)
+	var data interface{} = r.Results
+	if full {
+		data = r
+	}
+	if f == formatMsgPack {
+		c.Render(code, render.MsgPack{Data: data})
+		return
+	}
+	c.JSON(code, data)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/criyle/go-judge/worker"
+	"github.com/gin-gonic/gin"
+)
+
+type runUsage struct {
+	Time   uint64 `json:"time"`
+	Memory uint64 `json:"memory"`
+}
+
+type runInfo struct {
+	ID        string      `json:"id"`
+	RequestID string      `json:"requestId,omitempty"`
+	Args      [][]string  `json:"args"`
+	Elapsed   uint64      `json:"elapsed"`
+	Usage     []*runUsage `json:"usage"`
+}
+
+// initRunsHandle registers admin handles for executing requests
+//
+// GET /runs, DELETE /runs/:id
+func initRunsHandle(r gin.IRouter, work worker.Worker) {
+	r.GET("/runs", func(c *gin.Context) {
+		runs := work.Inflight()
+		rt := make([]runInfo, 0, len(runs))
+		for _, ri := range runs {
+			usage := make([]*runUsage, 0, len(ri.Usage))
+			for _, u := range ri.Usage {
+				if u == nil {
+					usage = append(usage, nil)
+					continue
+				}
+				usage = append(usage, &runUsage{
+					Time:   uint64(u.Time),
+					Memory: uint64(u.Memory),
+				})
+			}
+			rt = append(rt, runInfo{
+				ID:        ri.ID,
+				RequestID: ri.RequestID,
+				Args:      ri.Args,
+				Elapsed:   uint64(ri.Elapsed),
+				Usage:     usage,
+			})
+		}
+		c.JSON(http.StatusOK, rt)
+	})
+	r.DELETE("/runs/:id", func(c *gin.Context) {
+		if !work.Kill(c.Param("id")) {
+			c.AbortWithStatusJSON(http.StatusNotFound, "not found")
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+}
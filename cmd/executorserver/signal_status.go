@@ -0,0 +1,63 @@
+package main
+
+import (
+	"log"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/criyle/go-judge/envexec"
+)
+
+var signalNames = map[string]syscall.Signal{
+	"SIGABRT": syscall.SIGABRT,
+	"SIGALRM": syscall.SIGALRM,
+	"SIGBUS":  syscall.SIGBUS,
+	"SIGFPE":  syscall.SIGFPE,
+	"SIGHUP":  syscall.SIGHUP,
+	"SIGILL":  syscall.SIGILL,
+	"SIGINT":  syscall.SIGINT,
+	"SIGKILL": syscall.SIGKILL,
+	"SIGPIPE": syscall.SIGPIPE,
+	"SIGQUIT": syscall.SIGQUIT,
+	"SIGSEGV": syscall.SIGSEGV,
+	"SIGTERM": syscall.SIGTERM,
+	"SIGTRAP": syscall.SIGTRAP,
+}
+
+// newSignalStatus parses signal=status mappings, signal is either the name
+// (e.g. SIGSEGV) or the number
+func newSignalStatus(conf []string) map[int]envexec.Status {
+	if len(conf) == 0 {
+		return nil
+	}
+	rt := make(map[int]envexec.Status, len(conf))
+	for _, s := range conf {
+		kv := strings.SplitN(s, "=", 2)
+		if len(kv) != 2 {
+			log.Fatalln("invalid signal status (expect signal=status):", s)
+		}
+		sig, ok := parseSignal(strings.TrimSpace(kv[0]))
+		if !ok {
+			log.Fatalln("invalid signal:", kv[0])
+		}
+		st, ok := envexec.ParseStatus(strings.TrimSpace(kv[1]))
+		if !ok || st == envexec.StatusInvalid {
+			log.Fatalln("invalid status:", kv[1])
+		}
+		rt[sig] = st
+	}
+	return rt
+}
+
+func parseSignal(s string) (int, bool) {
+	if n, err := strconv.Atoi(s); err == nil {
+		return n, n > 0
+	}
+	s = strings.ToUpper(s)
+	if !strings.HasPrefix(s, "SIG") {
+		s = "SIG" + s
+	}
+	sig, ok := signalNames[s]
+	return int(sig), ok
+}
@@ -2,7 +2,10 @@ package wsexecutor
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/criyle/go-judge/cmd/executorserver/model"
@@ -17,12 +20,31 @@ type Register interface {
 	Register(*gin.Engine)
 }
 
-// New creates new websocket handle
-func New(worker worker.Worker, srcPrefix string, logger *zap.Logger) Register {
+// Limit defines the limits of the websocket connections (0 unlimited)
+type Limit struct {
+	MaxConn     int // concurrent connections
+	MaxInflight int // concurrent runs of each connection
+
+	// MaxLifetime is the max lifetime of each connection, the connection
+	// stops reading requests once reached and is closed with going away after
+	// the results of in-flight runs are sent, so that the client reconnects
+	MaxLifetime time.Duration
+
+	// CancelOnExpire cancels the in-flight runs once MaxLifetime reached
+	// instead of waiting them to finish
+	CancelOnExpire bool
+}
+
+// New creates new websocket handle with the connection limits
+func New(worker worker.Worker, srcPrefix string, limit Limit, logger *zap.Logger) Register {
 	return &wsHandle{
-		worker:    worker,
-		srcPrefix: srcPrefix,
-		logger:    logger,
+		worker:         worker,
+		srcPrefix:      srcPrefix,
+		maxConn:        int32(limit.MaxConn),
+		maxInflight:    limit.MaxInflight,
+		maxLifetime:    limit.MaxLifetime,
+		cancelOnExpire: limit.CancelOnExpire,
+		logger:         logger,
 	}
 }
 
@@ -39,9 +61,15 @@ const (
 )
 
 type wsHandle struct {
-	worker    worker.Worker
-	srcPrefix string
-	logger    *zap.Logger
+	worker         worker.Worker
+	srcPrefix      string
+	maxConn        int32
+	maxInflight    int
+	maxLifetime    time.Duration
+	cancelOnExpire bool
+	logger         *zap.Logger
+
+	conn int32 // number of current connections
 }
 
 func (h *wsHandle) Register(r *gin.Engine) {
@@ -49,56 +77,139 @@ func (h *wsHandle) Register(r *gin.Engine) {
 }
 
 func (h *wsHandle) handleWS(c *gin.Context) {
+	if n := atomic.AddInt32(&h.conn, 1); h.maxConn > 0 && n > h.maxConn {
+		atomic.AddInt32(&h.conn, -1)
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable,
+			fmt.Sprintf("too many websocket connections (limit %d)", h.maxConn))
+		return
+	}
 	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
+		atomic.AddInt32(&h.conn, -1)
 		c.Error(err)
 		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
 		return
 	}
 	resultCh := make(chan model.Response, 128)
+	var inflight chan struct{}
+	if h.maxInflight > 0 {
+		inflight = make(chan struct{}, h.maxInflight)
+	}
+
+	// the read deadline is bounded by the lifetime so that the read returns
+	// once expired
+	var expire time.Time
+	if h.maxLifetime > 0 {
+		expire = time.Now().Add(h.maxLifetime)
+	}
+	readDeadline := func() time.Time {
+		d := time.Now().Add(pongWait)
+		if !expire.IsZero() && expire.Before(d) {
+			return expire
+		}
+		return d
+	}
+	expired := make(chan struct{})   // closed after in-flight results sent
+	writeDone := make(chan struct{}) // closed once the writer exited
+
 	// read request
 	go func() {
-		defer conn.Close()
-		conn.SetReadDeadline(time.Now().Add(pongWait))
+		defer atomic.AddInt32(&h.conn, -1)
+		conn.SetReadDeadline(readDeadline())
 		conn.SetPongHandler(func(string) error {
-			conn.SetReadDeadline(time.Now().Add(pongWait))
+			conn.SetReadDeadline(readDeadline())
 			return nil
 		})
 
 		ctx, cancel := context.WithCancel(context.TODO())
 		defer cancel()
 
+		var wg sync.WaitGroup
 		for {
 			req := new(model.Request)
 			if err := conn.ReadJSON(req); err != nil {
+				if !expire.IsZero() && !time.Now().Before(expire) {
+					h.logger.Sugar().Info("ws max lifetime reached, cancel in-flight runs: ", h.cancelOnExpire)
+					if h.cancelOnExpire {
+						cancel()
+					}
+					wg.Wait()
+					close(expired)
+					return
+				}
 				h.logger.Sugar().Warn("ws read error:", err)
+				conn.Close()
 				return
 			}
 			r, err := model.ConvertRequest(req, h.srcPrefix)
 			if err != nil {
 				h.logger.Sugar().Warn("convert error: ", err)
+				conn.Close()
 				return
 			}
+			if inflight != nil {
+				select {
+				case inflight <- struct{}{}:
+				default:
+					resultCh <- model.Response{
+						RequestID: req.RequestID,
+						ErrorMsg:  fmt.Sprintf("too many in-flight requests on the connection (limit %d)", h.maxInflight),
+						ErrorCode: string(worker.RejectBusy),
+					}
+					continue
+				}
+			}
+			wg.Add(1)
 			go func() {
+				defer wg.Done()
 				ret := <-h.worker.Submit(ctx, r)
-				resultCh <- model.ConvertResponse(ret)
+				if inflight != nil {
+					<-inflight
+				}
+				select {
+				case resultCh <- model.ConvertResponse(ret):
+				case <-writeDone:
+				}
 			}()
 		}
 	}()
 
 	// write result
 	go func() {
+		defer close(writeDone)
 		defer conn.Close()
 		ticker := time.NewTicker(pingPeriod)
 		defer ticker.Stop()
+		write := func(r model.Response) bool {
+			conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := conn.WriteJSON(r); err != nil {
+				h.logger.Sugar().Warn("ws write error:", err)
+				return false
+			}
+			return true
+		}
 		for {
 			select {
 			case r := <-resultCh:
-				conn.SetWriteDeadline(time.Now().Add(writeWait))
-				if err := conn.WriteJSON(r); err != nil {
-					h.logger.Sugar().Warn("ws write error:", err)
+				if !write(r) {
 					return
 				}
+			case <-expired:
+				// flush the results of in-flight runs before going away
+			flush:
+				for {
+					select {
+					case r := <-resultCh:
+						if !write(r) {
+							return
+						}
+					default:
+						break flush
+					}
+				}
+				msg := websocket.FormatCloseMessage(websocket.CloseGoingAway, "max connection lifetime reached")
+				conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(writeWait))
+				return
 			case <-ticker.C:
 				conn.SetWriteDeadline(time.Now().Add(writeWait))
 				if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
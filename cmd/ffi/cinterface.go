@@ -49,6 +49,7 @@ func newFilsStore(dir string) filestore.FileStore {
 }
 
 // Init initialize the sandbox environment
+//
 //export Init
 func Init(i *C.char) C.int {
 	is := C.GoString(i)
@@ -100,6 +101,7 @@ func Init(i *C.char) C.int {
 }
 
 // Exec runs command inside container runner
+//
 //export Exec
 func Exec(e *C.char) *C.char {
 	es := C.GoString(e)
@@ -121,6 +123,7 @@ func Exec(e *C.char) *C.char {
 }
 
 // FileList get the list of files in the file store
+//
 //export FileList
 func FileList() *C.char {
 	ids := fs.List()
@@ -133,6 +136,7 @@ func FileList() *C.char {
 }
 
 // FileAdd adds file to the file store
+//
 //export FileAdd
 func FileAdd(e *C.char) *C.char {
 	type fileAdd struct {
@@ -154,6 +158,7 @@ func FileAdd(e *C.char) *C.char {
 }
 
 // FileGet gets file from file store by id
+//
 //export FileGet
 func FileGet(e *C.char) *C.char {
 	type fileGet struct {
@@ -177,6 +182,7 @@ func FileGet(e *C.char) *C.char {
 }
 
 // FileDelete deletes file from file store by id
+//
 //export FileDelete
 func FileDelete(e *C.char) *C.char {
 	type fileDelete struct {
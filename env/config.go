@@ -10,17 +10,62 @@ type Logger interface {
 	Error(args ...interface{})
 }
 
+// Sandbox backends
+const (
+	SandboxNative = "native"
+	SandboxGVisor = "gvisor"
+)
+
 // Config defines parameters to create environment builder
 type Config struct {
 	ContainerInitPath  string
+	ContainerInitEnv   []string // KEY=VALUE env for the container init process
+	ContainerInitArgs  []string // flags appended to the container init args
 	TmpFsParam         string
 	NetShare           bool
+	HostName           string
+	DomainName         string
 	MountConf          string
+	ResolvConf         string
+	HostsFile          string
+	Timezone           string // zoneinfo name mounted as /etc/localtime
+	Devices            []string
 	SeccompConf        string
 	CgroupPrefix       string
 	Cpuset             string
 	ContainerCredStart int
 	EnableCPURate      bool
 	CPUCfsPeriod       time.Duration
+	CgroupPool         bool
+	MemoryExcludeCache bool
+	DisableSwap        bool
+
+	// VerifyReset verifies the pooled cgroups are zeroed after reset, the
+	// failed ones are destroyed and reported to OnResetFailed (optional)
+	VerifyReset   bool
+	OnResetFailed func(error)
+
+	// GPUDevices are the GPU device nodes (e.g. nvidia0) bind mounted in the
+	// container, accessible only to runs requested them by devices cgroup
+	GPUDevices []string
+
+	// MountPropagation sets the propagation of the container mounts
+	// explicitly (private, slave, unbindable), empty keeps the inherited
+	MountPropagation string
+
+	// InstructionCounter enables instruction limit of the runs by the
+	// hardware perf counter (native sandbox only)
+	InstructionCounter bool
+
+	// ReaperPath is the executable (cmd/creaper or calls linuxcontainer.Reaper
+	// in init, statically linked) started as the parent of each command to
+	// reap its orphans (native sandbox only), empty disables
+	ReaperPath string
+
+	// Sandbox selects the sandbox backend (native / gvisor) on linux, gvisor
+	// falls back to native if runsc is not available
+	Sandbox       string
+	RunscPath     string
+	RunscPlatform string
 	Logger
 }
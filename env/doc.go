@@ -1,6 +1,7 @@
 // Package env provides a unified method to create environment for envexec.
 //
-// For linux, the env creates container & cgroup sandbox.
+// For linux, the env creates container & cgroup sandbox, or gVisor (runsc)
+// sandbox if selected and available.
 //
 // For windows, the env creates low mandatory level sandbox.
 //
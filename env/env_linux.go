@@ -44,8 +44,58 @@ func NewBuilder(c Config) (pool.EnvBuilder, error) {
 			return nil, err
 		}
 	}
-	m := mb.FilterNotExist().Mounts
-	c.Info("Created container mount at:", mb)
+	// fixed name resolution files for deterministic dns inside container
+	for _, f := range []struct{ src, dst string }{
+		{c.ResolvConf, "etc/resolv.conf"},
+		{c.HostsFile, "etc/hosts"},
+	} {
+		if f.src == "" {
+			continue
+		}
+		if _, err := os.Stat(f.src); err != nil {
+			return nil, fmt.Errorf("failed to mount /%s: %v", f.dst, err)
+		}
+		mb.WithBind(f.src, f.dst, true)
+	}
+	// fixed local time for deterministic date formatting inside container
+	if c.Timezone != "" {
+		tz, err := zoneinfoPath(c.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("failed to mount /etc/localtime: %v", err)
+		}
+		mb.WithBind(tz, "etc/localtime", true)
+	}
+	// gVisor provides its own /dev, thus the device nodes are not mounted
+	sandboxMounts := append([]mount.Mount{}, mb.FilterNotExist().Mounts...)
+
+	devices := c.Devices
+	if mc != nil && mc.Devices != nil {
+		devices = mc.Devices
+	}
+	if devices == nil {
+		devices = defaultDevices
+	}
+	if err := withDevices(mb, devices); err != nil {
+		return nil, err
+	}
+	var gpuDevices *linuxcontainer.DeviceConfig
+	// gVisor does not expose gpu devices since the mounts are taken above
+	if len(c.GPUDevices) > 0 {
+		gpuDevices, err = withGPUDevices(mb, c.GPUDevices, c.CgroupPrefix)
+		if err != nil {
+			return nil, fmt.Errorf("failed to expose gpu devices: %v", err)
+		}
+		c.Info("Expose gpu devices to the allowed profiles: ", c.GPUDevices)
+	}
+	propagation := c.MountPropagation
+	if mc != nil && mc.Propagation != "" {
+		propagation = mc.Propagation
+	}
+	if err := withPropagation(mb.FilterNotExist(), propagation); err != nil {
+		return nil, err
+	}
+	m := mb.Mounts
+	c.Info("Created container mount at:", mb, " with propagation ", propagation)
 
 	seccomp, err := readSeccompConf(c.SeccompConf)
 	if err != nil {
@@ -81,26 +131,83 @@ func NewBuilder(c Config) (pool.EnvBuilder, error) {
 	cUID := containerCred
 	cGID := containerCred
 	if mc != nil {
-		hostName = mc.HostName
-		domainName = mc.DomainName
+		// the unshared uts namespace keeps the host name of the host if empty
+		if mc.HostName != "" {
+			hostName = mc.HostName
+		}
+		if mc.DomainName != "" {
+			domainName = mc.DomainName
+		}
 		workDir = mc.WorkDir
 		cUID = mc.UID
 		cGID = mc.GID
 	}
+	// the server flags override the mount config
+	if c.HostName != "" {
+		hostName = c.HostName
+	}
+	if c.DomainName != "" {
+		domainName = c.DomainName
+	}
+	if c.Sandbox == SandboxGVisor {
+		b, err := newGVisorBuilder(c, root, sandboxMounts, propagation, hostName, domainName, workDir, cUID, cGID)
+		if err == nil {
+			if c.ReaperPath != "" {
+				c.Warn("Container reaper is ignored by gVisor sandbox")
+			}
+			return b, nil
+		}
+		c.Warn("gVisor sandbox is not available, fall back to native sandbox: ", err)
+	}
+
+	if c.InstructionCounter {
+		if err := linuxcontainer.InstructionCounterAvailable(); err != nil {
+			return nil, fmt.Errorf("failed to enable instruction counter: %v", err)
+		}
+		c.Info("Enabled hardware instruction counter")
+	}
+
+	var reaper *os.File
+	if c.ReaperPath != "" {
+		if seccomp != nil {
+			return nil, fmt.Errorf("container reaper is not supported with seccomp filter")
+		}
+		reaper, err = os.Open(c.ReaperPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open container reaper: %v", err)
+		}
+		c.Info("Enabled container reaper: ", c.ReaperPath)
+	}
+
+	// the launcher is executed by every container built, thus it is kept
+	// until the pool shut down
+	initPath, err := containerInit(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare container init: %v", err)
+	}
+	var launcher string
+	if initPath != c.ContainerInitPath {
+		launcher = initPath
+		c.Info("Created container init launcher at: ", initPath, " env=", c.ContainerInitEnv, ", args=", c.ContainerInitArgs)
+	}
+
 	c.Info("Creating container builder: hostName=", hostName, ", domainName=", domainName, ", workDir=", workDir)
 
-	b := &container.Builder{
-		Root:          root,
-		Mounts:        m,
-		CredGenerator: credGen,
-		Stderr:        os.Stderr,
-		CloneFlags:    unshareFlags,
-		ExecFile:      c.ContainerInitPath,
-		HostName:      hostName,
-		DomainName:    domainName,
-		WorkDir:       workDir,
-		ContainerUID:  cUID,
-		ContainerGID:  cGID,
+	// the stderr of the container init is kept to diagnose its failures
+	b := &linuxcontainer.InitStderrBuilder{
+		Builder: container.Builder{
+			Root:          root,
+			Mounts:        m,
+			CredGenerator: credGen,
+			CloneFlags:    unshareFlags,
+			ExecFile:      initPath,
+			HostName:      hostName,
+			DomainName:    domainName,
+			WorkDir:       workDir,
+			ContainerUID:  cUID,
+			ContainerGID:  cGID,
+		},
+		Stderr: os.Stderr,
 	}
 	cgb := cgroup.NewBuilder(c.CgroupPrefix).WithCPUAcct().WithMemory().WithPids()
 	if c.Cpuset != "" {
@@ -111,6 +218,7 @@ func NewBuilder(c Config) (pool.EnvBuilder, error) {
 	}
 	cgb, err = cgb.FilterByEnv()
 	if err != nil {
+		removeLauncher(launcher)
 		return nil, err
 	}
 	c.Info("Test created cgroup builder with: ", cgb)
@@ -122,18 +230,39 @@ func NewBuilder(c Config) (pool.EnvBuilder, error) {
 		cg.Destroy()
 	}
 
-	var cgroupPool linuxcontainer.CgroupPool
+	// pooled cgroups are reset and reused while fresh cgroups are built and
+	// destroyed for each run
+	var cgroupPool, freshCgroupPool linuxcontainer.CgroupPool
 	if cgb != nil {
-		cgroupPool = linuxcontainer.NewFakeCgroupPool(cgb, c.CPUCfsPeriod)
+		freshCgroupPool = linuxcontainer.NewFakeCgroupPool(cgb, c.CPUCfsPeriod)
+		cgroupPool = freshCgroupPool
+		if c.CgroupPool {
+			cgroupPool = linuxcontainer.NewCgroupListPool(cgb, c.CPUCfsPeriod, linuxcontainer.CgroupResetCheck{
+				Verify:   c.VerifyReset,
+				OnFailed: c.OnResetFailed,
+			})
+			c.Info("Reuse pooled cgroups unless fresh cgroup requested")
+		}
 	}
-	return linuxcontainer.NewEnvBuilder(linuxcontainer.Config{
+	eb := linuxcontainer.NewEnvBuilder(linuxcontainer.Config{
 		Builder:    b,
 		CgroupPool: cgroupPool,
 		WorkDir:    workDir,
 		Cpuset:     c.Cpuset,
 		CPURate:    c.EnableCPURate,
 		Seccomp:    seccomp,
-	}), nil
+
+		FreshCgroupPool:    freshCgroupPool,
+		MemoryExcludeCache: c.MemoryExcludeCache,
+		DisableSwap:        c.DisableSwap,
+		Devices:            gpuDevices,
+		InstructionCounter: c.InstructionCounter,
+		Reaper:             reaper,
+	})
+	if launcher == "" {
+		return eb, nil
+	}
+	return &launcherBuilder{EnvBuilder: eb, launcher: launcher}, nil
 }
 
 type credGen struct {
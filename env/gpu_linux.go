@@ -0,0 +1,89 @@
+package env
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/criyle/go-judge/env/linuxcontainer"
+	"github.com/criyle/go-sandbox/pkg/mount"
+	"golang.org/x/sys/unix"
+)
+
+// gpuControlDevices are the nvidia device nodes shared by all GPUs, they are
+// accessible if any GPU is requested
+var gpuControlDevices = []string{"nvidiactl", "nvidia-uvm", "nvidia-uvm-tools", "nvidia-modeset"}
+
+// defaultDeviceRules allows the default device nodes (null, zero, full,
+// random, urandom, tty, ptmx and pts) for all runs
+var defaultDeviceRules = []string{
+	"c 1:3 rwm",
+	"c 1:5 rwm",
+	"c 1:7 rwm",
+	"c 1:8 rwm",
+	"c 1:9 rwm",
+	"c 5:0 rwm",
+	"c 5:2 rwm",
+	"c 136:* rwm",
+}
+
+// withGPUDevices binds the GPU device nodes (e.g. nvidia0) and the existing
+// control nodes into the container /dev and returns the devices cgroup
+// config allows them only for the runs requested
+func withGPUDevices(b *mount.Builder, gpus []string, prefix string) (*linuxcontainer.DeviceConfig, error) {
+	if err := linuxcontainer.DevicesAvailable(); err != nil {
+		return nil, err
+	}
+	dc := &linuxcontainer.DeviceConfig{
+		Prefix:  prefix,
+		Default: defaultDeviceRules,
+		Devices: make(map[string]string, len(gpus)),
+	}
+	for _, d := range gpus {
+		if !isGPUDevice(d) {
+			return nil, fmt.Errorf("gpu device %s is not allowed (expect nvidia<minor>)", d)
+		}
+		r, err := deviceRule(d)
+		if err != nil {
+			return nil, err
+		}
+		dc.Devices[d] = r
+		b.WithBind(path.Join("/dev", d), path.Join("dev", d), false)
+	}
+	for _, d := range gpuControlDevices {
+		r, err := deviceRule(d)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		dc.Shared = append(dc.Shared, r)
+		b.WithBind(path.Join("/dev", d), path.Join("dev", d), false)
+	}
+	return dc, nil
+}
+
+func isGPUDevice(d string) bool {
+	n := strings.TrimPrefix(d, "nvidia")
+	if n == d || n == "" {
+		return false
+	}
+	_, err := strconv.ParseUint(n, 10, 32)
+	return err == nil
+}
+
+// deviceRule returns the devices cgroup rule allows the char device node
+func deviceRule(d string) (string, error) {
+	var st unix.Stat_t
+	p := path.Join("/dev", d)
+	if err := unix.Stat(p, &st); err != nil {
+		return "", &os.PathError{Op: "stat", Path: p, Err: err}
+	}
+	if st.Mode&unix.S_IFMT != unix.S_IFCHR {
+		return "", fmt.Errorf("%s is not a char device", p)
+	}
+	return fmt.Sprintf("c %d:%d rwm", unix.Major(uint64(st.Rdev)), unix.Minor(uint64(st.Rdev))), nil
+}
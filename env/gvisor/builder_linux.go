@@ -0,0 +1,139 @@
+package gvisor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/criyle/go-judge/env/pool"
+	"github.com/criyle/go-sandbox/pkg/mount"
+)
+
+var _ pool.EnvBuilder = &Builder{}
+
+// Config specifies configuration to build gVisor sandbox environment
+type Config struct {
+	Runsc      string        // runsc binary path
+	Platform   string        // runsc platform (e.g. systrap, ptrace, kvm), empty for runsc default
+	Root       string        // directory for bundles and runsc state
+	Mounts     []mount.Mount // container mounts with relative target
+	WorkDir    string        // work dir inside the sandbox
+	HostName   string
+	DomainName string
+	UID, GID   int
+	Network    bool
+
+	// Propagation is the rootfs propagation (e.g. private, slave)
+	Propagation string
+}
+
+// Builder to create gVisor sandbox environment
+type Builder struct {
+	runsc   string
+	flags   []string
+	root    string
+	mounts  []mount.Mount
+	workDir string
+	host    string
+	domain  string
+	uid     int
+	gid     int
+	network bool
+
+	propagation string
+}
+
+var _ pool.GroupsSupporter = &Builder{}
+
+// SupportsGroups returns true since the groups are set by the spec
+func (b *Builder) SupportsGroups() bool {
+	return true
+}
+
+var _ pool.CapabilitiesSupporter = &Builder{}
+
+// SupportsCapabilities returns true since the capabilities are set by the
+// spec
+func (b *Builder) SupportsCapabilities() bool {
+	return true
+}
+
+// Available returns the resolved runsc path or error if runsc is not usable
+func Available(runsc string) (string, error) {
+	p, err := exec.LookPath(runsc)
+	if err != nil {
+		return "", err
+	}
+	out, err := exec.Command(p, "--version").CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("runsc --version: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return p, nil
+}
+
+// NewBuilder creates Builder to create gVisor sandbox environment
+func NewBuilder(c Config) pool.EnvBuilder {
+	stateDir := filepath.Join(c.Root, "state")
+	flags := []string{"--root", stateDir}
+	if c.Platform != "" {
+		flags = append(flags, "--platform", c.Platform)
+	}
+	if c.Network {
+		flags = append(flags, "--network", "host")
+	} else {
+		flags = append(flags, "--network", "none")
+	}
+	if os.Getuid() != 0 {
+		flags = append(flags, "--rootless", "--ignore-cgroups")
+	}
+	return &Builder{
+		runsc:   c.Runsc,
+		flags:   flags,
+		root:    c.Root,
+		mounts:  c.Mounts,
+		workDir: c.WorkDir,
+		host:    c.HostName,
+		domain:  c.DomainName,
+		uid:     c.UID,
+		gid:     c.GID,
+		network: c.Network,
+
+		propagation: c.Propagation,
+	}
+}
+
+// Build create a gVisor sandbox environment
+func (b *Builder) Build() (pool.Environment, error) {
+	bundle, err := os.MkdirTemp(b.root, "es")
+	if err != nil {
+		return nil, err
+	}
+	rootfs := filepath.Join(bundle, "rootfs")
+	wd := filepath.Join(bundle, "w")
+	for _, d := range []string{rootfs, wd} {
+		if err := os.Mkdir(d, 0755); err != nil {
+			os.RemoveAll(bundle)
+			return nil, err
+		}
+	}
+	// the sandbox user needs write access to the work dir created by host
+	if err := os.Chmod(wd, 0777); err != nil {
+		os.RemoveAll(bundle)
+		return nil, err
+	}
+	wdf, err := os.Open(wd)
+	if err != nil {
+		os.RemoveAll(bundle)
+		return nil, err
+	}
+	return &environment{
+		builder: b,
+		id:      filepath.Base(bundle),
+		bundle:  bundle,
+		wdPath:  wd,
+		wd:      wdf,
+		mounts:  specMounts(b.mounts, b.workDir, wd),
+	}, nil
+}
@@ -0,0 +1,324 @@
+package gvisor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/criyle/go-judge/env/pool"
+	"github.com/criyle/go-judge/envexec"
+	"github.com/criyle/go-sandbox/runner"
+	"golang.org/x/sys/unix"
+)
+
+const outputLimit = 256 << 20 // 256M
+
+var (
+	_ pool.Environment      = &environment{}
+	_ envexec.HostWorkDirer = &environment{}
+)
+
+type environment struct {
+	builder *Builder
+	id      string // prefix of container id
+	runs    uint64
+	bundle  string
+	wdPath  string
+	wd      *os.File
+	mounts  []specMount
+}
+
+func (e *environment) Execve(c context.Context, param envexec.ExecveParam) (envexec.Process, error) {
+	if param.TTY {
+		return nil, errors.New("gvisor: tty is not supported")
+	}
+	if param.ExecFile != 0 {
+		return nil, errors.New("gvisor: exec file is not supported")
+	}
+	if len(param.Devices) > 0 {
+		return nil, errors.New("gvisor: devices are not supported")
+	}
+	if param.CountInstructions {
+		return nil, errors.New("gvisor: instruction counter is not supported")
+	}
+	if param.CountSyscalls {
+		return nil, errors.New("gvisor: syscall counter is not supported")
+	}
+	if param.Limit.TimeAccounting != envexec.TimeAccountingCgroup {
+		return nil, errors.New("gvisor: process time accounting is not supported")
+	}
+	if param.Limit.Nice != 0 {
+		return nil, errors.New("gvisor: nice is not supported")
+	}
+	if len(param.Files) > 3 {
+		return nil, errors.New("gvisor: only stdin, stdout and stderr are supported")
+	}
+
+	e.runs++
+	id := e.id + "-" + strconv.FormatUint(e.runs, 10)
+	if err := e.writeSpec(param); err != nil {
+		return nil, err
+	}
+
+	// duplicate the fds so that the caller could close its files once started
+	files := make([]*os.File, 3)
+	for i, fd := range param.Files {
+		nfd, err := unix.FcntlInt(fd, unix.F_DUPFD_CLOEXEC, 0)
+		if err != nil {
+			closeFiles(files)
+			return nil, err
+		}
+		files[i] = os.NewFile(uintptr(nfd), "")
+	}
+	defer closeFiles(files)
+
+	b := e.builder
+	cmd := exec.Command(b.runsc, b.args("run", "--bundle", e.bundle, id)...)
+	// nil files are redirected to /dev/null by exec
+	if files[0] != nil {
+		cmd.Stdin = files[0]
+	}
+	if files[1] != nil {
+		cmd.Stdout = files[1]
+	}
+	if files[2] != nil {
+		cmd.Stderr = files[2]
+	}
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+
+	sTime := time.Now()
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	p := &process{
+		runsc: b.runsc,
+		flags: b.flags,
+		id:    id,
+		done:  make(chan struct{}),
+	}
+	go func() {
+		defer close(p.done)
+
+		// handle cancel
+		exit := make(chan struct{})
+		go func() {
+			select {
+			case <-c.Done():
+				p.kill()
+			case <-exit:
+			}
+		}()
+
+		err := cmd.Wait()
+		close(exit)
+		fTime := time.Now()
+		// the final sample before the container state is removed
+		u := p.sample(true)
+		p.destroy()
+
+		p.result = runner.Result{
+			Status:      runner.StatusNormal,
+			Time:        u.Time,
+			Memory:      u.Memory,
+			RunningTime: fTime.Sub(sTime),
+		}
+		if u.Time > param.Limit.Time {
+			p.result.Status = runner.StatusTimeLimitExceeded
+		}
+		if u.Memory > param.Limit.Memory {
+			p.result.Status = runner.StatusMemoryLimitExceeded
+		}
+
+		var exitErr *exec.ExitError
+		switch {
+		case err == nil:
+
+		case errors.As(err, &exitErr):
+			status := exitErr.ExitCode()
+			// runsc reports the signalled container as 128 + signal
+			if status <= 128 {
+				p.result.Status = runner.StatusNonzeroExitStatus
+				p.result.ExitStatus = status
+				return
+			}
+			sig := unix.Signal(status - 128)
+			switch {
+			case sig == unix.SIGKILL && !p.killedByKill():
+				// SIGKILL not sent by kill (the time limit checker) is the
+				// sandbox killed the container out of memory
+				p.result.Status = runner.StatusMemoryLimitExceeded
+			case sig == unix.SIGXCPU, sig == unix.SIGKILL:
+				p.result.Status = runner.StatusTimeLimitExceeded
+			case sig == unix.SIGXFSZ:
+				p.result.Status = runner.StatusOutputLimitExceeded
+			case sig == unix.SIGSYS:
+				p.result.Status = runner.StatusDisallowedSyscall
+			default:
+				p.result.Status = runner.StatusSignalled
+			}
+			p.result.ExitStatus = int(sig)
+
+		default:
+			p.result.Status = runner.StatusRunnerError
+			p.result.Error = err.Error()
+		}
+	}()
+	return p, nil
+}
+
+// writeSpec writes the OCI config.json for the command into the bundle
+func (e *environment) writeSpec(param envexec.ExecveParam) error {
+	b := e.builder
+	fileSize := uint64(outputLimit)
+	if s := param.Limit.Output.Byte(); s > 0 && s < fileSize {
+		fileSize = s
+	}
+	if s := param.Limit.FileSize.Byte(); s > 0 && s < fileSize {
+		fileSize = s
+	}
+	cpu := uint64(param.Limit.Time.Truncate(time.Second)/time.Second) + 1
+	rlimits := []specRlimit{
+		{Type: "RLIMIT_CPU", Hard: cpu, Soft: cpu},
+		{Type: "RLIMIT_FSIZE", Hard: fileSize, Soft: fileSize},
+		{Type: "RLIMIT_CORE"},
+	}
+	if s := param.Limit.Stack.Byte(); s > 0 {
+		rlimits = append(rlimits, specRlimit{Type: "RLIMIT_STACK", Hard: s, Soft: s})
+	}
+	if n := param.Limit.OpenFile; n > 0 {
+		rlimits = append(rlimits, specRlimit{Type: "RLIMIT_NOFILE", Hard: n, Soft: n})
+	}
+	if n := param.Limit.Nproc; n > 0 {
+		rlimits = append(rlimits, specRlimit{Type: "RLIMIT_NPROC", Hard: n, Soft: n})
+	}
+
+	res := new(specResources)
+	if m := param.Limit.Memory.Byte(); m > 0 {
+		res.Memory = &specMemory{Limit: int64(m)}
+	}
+	if param.Limit.Proc > 0 {
+		res.Pids = &specPids{Limit: int64(param.Limit.Proc)}
+	}
+
+	groups := make([]uint32, 0, len(param.Groups))
+	for _, g := range param.Groups {
+		groups = append(groups, uint32(g))
+	}
+
+	var caps *specCapabilities
+	if c := param.Capabilities; len(c) > 0 {
+		caps = &specCapabilities{Bounding: c, Effective: c, Inheritable: c, Permitted: c, Ambient: c}
+	}
+
+	var propagation string
+	if b.propagation != "" {
+		propagation = "r" + b.propagation
+	}
+	s := spec{
+		OCIVersion: "1.0.0",
+		Process: specProcess{
+			User:    specUser{UID: uint32(b.uid), GID: uint32(b.gid), AdditionalGids: groups},
+			Args:    param.Args,
+			Env:     param.Env,
+			Cwd:     b.workDir,
+			Rlimits: rlimits,
+
+			Capabilities: caps,
+		},
+		Root:       specRoot{Path: "rootfs", Readonly: true},
+		Hostname:   b.host,
+		Domainname: b.domain,
+		Mounts:     e.mounts,
+		Linux: specLinux{
+			Resources:  res,
+			Namespaces: specNamespaces(b.network),
+
+			RootfsPropagation: propagation,
+		},
+	}
+	d, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(e.bundle, "config.json"), d, 0644)
+}
+
+func (e *environment) WorkDir() *os.File {
+	e.wd.Seek(0, 0)
+	return e.wd
+}
+
+// HostWorkDir returns the host work dir bind mounted into the sandbox, the
+// program runs as the container user unless it is the server user
+func (e *environment) HostWorkDir() (string, bool) {
+	return e.wdPath, e.builder.uid != os.Geteuid()
+}
+
+func (e *environment) Open(p string, flags int, perm os.FileMode) (*os.File, error) {
+	return os.OpenFile(filepath.Join(e.wdPath, p), flags, perm)
+}
+
+func (e *environment) Destroy() error {
+	e.wd.Close()
+	return os.RemoveAll(e.bundle)
+}
+
+func (e *environment) Reset() error {
+	return removeContents(e.wdPath)
+}
+
+// VerifyReset checks the work dir is empty after reset
+func (e *environment) VerifyReset() error {
+	names, err := e.WorkDir().Readdirnames(1)
+	if err != nil && err != io.EOF {
+		return err
+	}
+	if len(names) > 0 {
+		return fmt.Errorf("work dir is not empty: %s", names[0])
+	}
+	return nil
+}
+
+// args prepends the global runsc flags to the command args
+func (b *Builder) args(args ...string) []string {
+	return append(append([]string{}, b.flags...), args...)
+}
+
+// removeContents delete content of a directory
+func removeContents(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	names, err := d.Readdirnames(-1)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		err = os.RemoveAll(filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func closeFiles(files []*os.File) {
+	for _, f := range files {
+		if f != nil {
+			f.Close()
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package gvisor
+
+import (
+	"encoding/json"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/criyle/go-judge/envexec"
+	"github.com/criyle/go-sandbox/runner"
+)
+
+var _ envexec.Process = &process{}
+
+// statsInterval is the min interval to sample the runsc stats, each sample
+// forks runsc
+const statsInterval = 100 * time.Millisecond
+
+// process is the command running inside runsc, its usage is sampled from the
+// runsc stats when Usage is called at most once per statsInterval and once
+// right after exit, thus the usage after the last sample is not counted
+type process struct {
+	runsc string
+	flags []string
+	id    string
+
+	done   chan struct{}
+	result runner.Result
+
+	mu      sync.Mutex
+	exited  bool
+	killed  bool // killed by kill (e.g. time limit) rather than the sandbox
+	sampled time.Time
+	usage   envexec.Usage
+}
+
+// stats defines the runsc events --stats output
+type stats struct {
+	Data struct {
+		CPU struct {
+			Usage struct {
+				Total uint64 `json:"total"`
+			} `json:"usage"`
+		} `json:"cpu"`
+		Memory struct {
+			Usage struct {
+				Usage uint64 `json:"usage"`
+				Max   uint64 `json:"max"`
+			} `json:"usage"`
+		} `json:"memory"`
+	} `json:"data"`
+}
+
+func (p *process) Done() <-chan struct{} {
+	return p.done
+}
+
+func (p *process) Result() runner.Result {
+	<-p.done
+	return p.result
+}
+
+// Usage samples the cpu time and peak memory reported by runsc
+func (p *process) Usage() envexec.Usage {
+	return p.sample(false)
+}
+
+// sample reads the runsc stats unless sampled within statsInterval (or
+// forced), the last sample is returned if failed
+func (p *process) sample(force bool) envexec.Usage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.exited || (!force && time.Since(p.sampled) < statsInterval) {
+		return p.usage
+	}
+	p.sampled = time.Now()
+
+	out, err := exec.Command(p.runsc, p.args("events", "--stats", p.id)...).Output()
+	if err != nil {
+		return p.usage
+	}
+	var s stats
+	if err := json.Unmarshal(out, &s); err != nil {
+		return p.usage
+	}
+	if t := time.Duration(s.Data.CPU.Usage.Total); t > p.usage.Time {
+		p.usage.Time = t
+	}
+	mem := s.Data.Memory.Usage.Max
+	if mem == 0 {
+		mem = s.Data.Memory.Usage.Usage
+	}
+	if m := envexec.Size(mem); m > p.usage.Memory {
+		p.usage.Memory = m
+	}
+	return p.usage
+}
+
+// kill kills all processes inside the sandbox
+func (p *process) kill() {
+	p.mu.Lock()
+	p.killed = true
+	p.mu.Unlock()
+	exec.Command(p.runsc, p.args("kill", "--all", p.id, "KILL")...).Run()
+}
+
+// destroy removes the container state once runsc run exited
+func (p *process) destroy() {
+	p.mu.Lock()
+	p.exited = true
+	p.mu.Unlock()
+
+	exec.Command(p.runsc, p.args("delete", "--force", p.id)...).Run()
+}
+
+// killedByKill returns whether the process was killed by kill
+func (p *process) killedByKill() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.killed
+}
+
+func (p *process) args(args ...string) []string {
+	return append(append([]string{}, p.flags...), args...)
+}
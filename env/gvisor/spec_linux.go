@@ -0,0 +1,143 @@
+package gvisor
+
+import (
+	"path"
+	"strings"
+
+	"github.com/criyle/go-sandbox/pkg/mount"
+)
+
+// spec defines the subset of the OCI runtime spec used by runsc
+type spec struct {
+	OCIVersion string      `json:"ociVersion"`
+	Process    specProcess `json:"process"`
+	Root       specRoot    `json:"root"`
+	Hostname   string      `json:"hostname,omitempty"`
+	Domainname string      `json:"domainname,omitempty"`
+	Mounts     []specMount `json:"mounts,omitempty"`
+	Linux      specLinux   `json:"linux"`
+}
+
+type specProcess struct {
+	User    specUser     `json:"user"`
+	Args    []string     `json:"args"`
+	Env     []string     `json:"env,omitempty"`
+	Cwd     string       `json:"cwd"`
+	Rlimits []specRlimit `json:"rlimits,omitempty"`
+
+	Capabilities *specCapabilities `json:"capabilities,omitempty"`
+}
+
+// specCapabilities are the capability sets of the process, the ambient set
+// keeps them for the non-root user
+type specCapabilities struct {
+	Bounding    []string `json:"bounding"`
+	Effective   []string `json:"effective"`
+	Inheritable []string `json:"inheritable"`
+	Permitted   []string `json:"permitted"`
+	Ambient     []string `json:"ambient"`
+}
+
+type specUser struct {
+	UID uint32 `json:"uid"`
+	GID uint32 `json:"gid"`
+
+	AdditionalGids []uint32 `json:"additionalGids,omitempty"`
+}
+
+type specRlimit struct {
+	Type string `json:"type"`
+	Hard uint64 `json:"hard"`
+	Soft uint64 `json:"soft"`
+}
+
+type specRoot struct {
+	Path     string `json:"path"`
+	Readonly bool   `json:"readonly"`
+}
+
+type specMount struct {
+	Destination string   `json:"destination"`
+	Type        string   `json:"type"`
+	Source      string   `json:"source"`
+	Options     []string `json:"options,omitempty"`
+}
+
+type specLinux struct {
+	Resources         *specResources  `json:"resources,omitempty"`
+	Namespaces        []specNamespace `json:"namespaces"`
+	RootfsPropagation string          `json:"rootfsPropagation,omitempty"`
+}
+
+type specResources struct {
+	Memory *specMemory `json:"memory,omitempty"`
+	Pids   *specPids   `json:"pids,omitempty"`
+}
+
+type specMemory struct {
+	Limit int64 `json:"limit"`
+}
+
+type specPids struct {
+	Limit int64 `json:"limit"`
+}
+
+type specNamespace struct {
+	Type string `json:"type"`
+}
+
+// specMounts converts the container mounts (relative targets) to OCI mounts
+// with the work dir bind mounted read-write
+func specMounts(ms []mount.Mount, workDir, hostWorkDir string) []specMount {
+	rt := make([]specMount, 0, len(ms)+1)
+	for _, m := range ms {
+		dst := path.Join("/", m.Target)
+		switch {
+		case m.IsBindMount():
+			opt := "rw"
+			if m.IsReadOnly() {
+				opt = "ro"
+			}
+			rt = append(rt, specMount{
+				Destination: dst,
+				Type:        "bind",
+				Source:      m.Source,
+				Options:     []string{"rbind", opt},
+			})
+
+		case m.IsTmpFs():
+			var opts []string
+			if m.Data != "" {
+				opts = strings.Split(m.Data, ",")
+			}
+			rt = append(rt, specMount{
+				Destination: dst,
+				Type:        "tmpfs",
+				Source:      "tmpfs",
+				Options:     opts,
+			})
+
+		case m.FsType == "proc":
+			rt = append(rt, specMount{
+				Destination: dst,
+				Type:        "proc",
+				Source:      "proc",
+			})
+		}
+	}
+	rt = append(rt, specMount{
+		Destination: workDir,
+		Type:        "bind",
+		Source:      hostWorkDir,
+		Options:     []string{"rbind", "rw"},
+	})
+	return rt
+}
+
+func specNamespaces(network bool) []specNamespace {
+	rt := []specNamespace{{"pid"}, {"ipc"}, {"uts"}, {"mount"}}
+	if !network {
+		rt = append(rt, specNamespace{"network"})
+	}
+	return rt
+}
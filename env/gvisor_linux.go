@@ -0,0 +1,37 @@
+package env
+
+import (
+	"github.com/criyle/go-judge/env/gvisor"
+	"github.com/criyle/go-judge/env/pool"
+	"github.com/criyle/go-sandbox/pkg/mount"
+)
+
+const defaultRunsc = "runsc"
+
+// newGVisorBuilder creates builder runs commands inside runsc sandbox with
+// the container mounts
+func newGVisorBuilder(c Config, root string, m []mount.Mount, propagation, hostName, domainName, workDir string, uid, gid int) (pool.EnvBuilder, error) {
+	runsc := c.RunscPath
+	if runsc == "" {
+		runsc = defaultRunsc
+	}
+	runsc, err := gvisor.Available(runsc)
+	if err != nil {
+		return nil, err
+	}
+	c.Info("Creating gVisor sandbox builder: runsc=", runsc, ", platform=", c.RunscPlatform, ", workDir=", workDir)
+	return gvisor.NewBuilder(gvisor.Config{
+		Runsc:      runsc,
+		Platform:   c.RunscPlatform,
+		Root:       root,
+		Mounts:     m,
+		WorkDir:    workDir,
+		HostName:   hostName,
+		DomainName: domainName,
+		UID:        uid,
+		GID:        gid,
+		Network:    c.NetShare,
+
+		Propagation: propagation,
+	}), nil
+}
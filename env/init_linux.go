@@ -0,0 +1,77 @@
+package env
+
+import (
+	"os"
+	"strings"
+
+	"github.com/criyle/go-judge/env/pool"
+	"github.com/criyle/go-sandbox/container"
+)
+
+// containerInit returns the executable of the container init process, if
+// init env or args are configured, it creates a launcher script to exec the
+// init with them since the container builder starts the init with fixed
+// env (PATH only) and args
+func containerInit(c Config) (string, error) {
+	if len(c.ContainerInitEnv) == 0 && len(c.ContainerInitArgs) == 0 {
+		return c.ContainerInitPath, nil
+	}
+	exe := c.ContainerInitPath
+	if exe == "" {
+		p, err := os.Executable()
+		if err != nil {
+			return "", err
+		}
+		exe = p
+	}
+
+	var b strings.Builder
+	b.WriteString("#!/bin/sh\nexec env " + shellQuote(container.PathEnv))
+	for _, e := range c.ContainerInitEnv {
+		b.WriteString(" " + shellQuote(e))
+	}
+	// the init argument must be the first argument
+	b.WriteString(" " + shellQuote(exe) + ` "$@"`)
+	for _, a := range c.ContainerInitArgs {
+		b.WriteString(" " + shellQuote(a))
+	}
+	b.WriteString("\n")
+
+	f, err := os.CreateTemp("", "executorserver-init")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if _, err := f.WriteString(b.String()); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	// container init may run with other uid
+	if err := f.Chmod(0755); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// launcherBuilder removes the container init launcher once shut down
+type launcherBuilder struct {
+	pool.EnvBuilder
+	launcher string
+}
+
+var _ pool.BuilderShutdowner = &launcherBuilder{}
+
+func (b *launcherBuilder) Shutdown() {
+	removeLauncher(b.launcher)
+}
+
+func removeLauncher(p string) {
+	if p != "" {
+		os.Remove(p)
+	}
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,96 @@
+package linuxcontainer
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/criyle/go-judge/envexec"
+)
+
+// cgroupReport records the cgroup version and the controllers of the process
+// once it is added to the cgroup, the controllers are those of the
+// hierarchies the process is placed in other cgroups than the server (i.e.
+// set up for the run rather than inherited)
+type cgroupReport struct {
+	mu   sync.Mutex
+	info envexec.CgroupInfo
+	err  error
+}
+
+func (r *cgroupReport) init(pid int32) {
+	info, err := readCgroupInfo(pid)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.info, r.err = info, err
+}
+
+func (r *cgroupReport) read() (envexec.CgroupInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.info, r.err
+}
+
+func readCgroupInfo(pid int32) (envexec.CgroupInfo, error) {
+	self, err := readProcCgroup("self")
+	if err != nil {
+		return envexec.CgroupInfo{}, err
+	}
+	proc, err := readProcCgroup(fmt.Sprint(pid))
+	if err != nil {
+		return envexec.CgroupInfo{}, err
+	}
+	var (
+		rt     envexec.CgroupInfo
+		v1, v2 []string
+	)
+	for k, p := range proc {
+		if self[k] == p {
+			continue
+		}
+		if k == "" {
+			b, err := os.ReadFile(path.Join(cgroupBasePath, p, "cgroup.controllers"))
+			if err != nil {
+				return envexec.CgroupInfo{}, err
+			}
+			v2 = strings.Fields(string(b))
+			continue
+		}
+		for _, c := range strings.Split(k, ",") {
+			// named hierarchies (e.g. name=systemd) have no controller
+			if !strings.Contains(c, "=") {
+				v1 = append(v1, c)
+			}
+		}
+	}
+	switch {
+	case len(v1) > 0:
+		rt.Version, rt.Controllers = 1, v1
+	case v2 != nil:
+		rt.Version, rt.Controllers = 2, v2
+	}
+	sort.Strings(rt.Controllers)
+	return rt, nil
+}
+
+// readProcCgroup returns the cgroup path by the controller list of the
+// hierarchies ("" for cgroup v2) of /proc/[pid]/cgroup
+func readProcCgroup(pid string) (map[string]string, error) {
+	b, err := os.ReadFile("/proc/" + pid + "/cgroup")
+	if err != nil {
+		return nil, err
+	}
+	rt := make(map[string]string)
+	for _, l := range strings.Split(string(b), "\n") {
+		// hierarchy-ID:controller-list:cgroup-path
+		f := strings.SplitN(l, ":", 3)
+		if len(f) != 3 {
+			continue
+		}
+		rt[f[1]] = f[2]
+	}
+	return rt, nil
+}
@@ -1,6 +1,7 @@
 package linuxcontainer
 
 import (
+	"fmt"
 	"sync"
 	"time"
 
@@ -32,14 +33,25 @@ type CgroupPool interface {
 type CgroupListPool struct {
 	builder   CgroupBuilder
 	cfsPeriod time.Duration
+	check     CgroupResetCheck
 
 	cgs []Cgroup
 	mu  sync.Mutex
 }
 
+// CgroupResetCheck defines the check of the cgroup put back to the pool, the
+// cgroup failed to reset (or verify) is destroyed instead of pooled
+type CgroupResetCheck struct {
+	// Verify verifies the usage counters are zeroed after reset
+	Verify bool
+
+	// OnFailed is called once the cgroup is destroyed (optional)
+	OnFailed func(error)
+}
+
 // NewCgroupListPool creates new cgroup pool
-func NewCgroupListPool(builder CgroupBuilder, cfsPeriod time.Duration) CgroupPool {
-	return &CgroupListPool{builder: builder, cfsPeriod: cfsPeriod}
+func NewCgroupListPool(builder CgroupBuilder, cfsPeriod time.Duration, check CgroupResetCheck) CgroupPool {
+	return &CgroupListPool{builder: builder, cfsPeriod: cfsPeriod, check: check}
 }
 
 // Get gets cgroup from pool, if pool is empty, creates new one
@@ -62,13 +74,38 @@ func (w *CgroupListPool) Get() (Cgroup, error) {
 
 // Put puts cgroup into the pool
 func (w *CgroupListPool) Put(c Cgroup) {
+	if err := w.reset(c); err != nil {
+		c.Destroy()
+		if w.check.OnFailed != nil {
+			w.check.OnFailed(err)
+		}
+		return
+	}
+
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
-	c.Reset()
 	w.cgs = append(w.cgs, c)
 }
 
+// reset resets the cgroup and verifies the cpu usage is zeroed if requested
+func (w *CgroupListPool) reset(c Cgroup) error {
+	if err := c.Reset(); err != nil {
+		return fmt.Errorf("reset cgroup: %v", err)
+	}
+	if !w.check.Verify {
+		return nil
+	}
+	t, err := c.CPUUsage()
+	if err != nil {
+		return fmt.Errorf("verify cgroup reset: %v", err)
+	}
+	if t != 0 {
+		return fmt.Errorf("verify cgroup reset: cpu usage %v is not zeroed", t)
+	}
+	return nil
+}
+
 // Shutdown destroy all cgroup
 func (w *CgroupListPool) Shutdown() {
 	w.mu.Lock()
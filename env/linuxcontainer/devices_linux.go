@@ -0,0 +1,82 @@
+package linuxcontainer
+
+import (
+	"fmt"
+	"os"
+	"path"
+)
+
+// DeviceConfig defines the devices cgroup (v1) restricting the device nodes
+// accessible by each run to the default ones and the requested devices
+type DeviceConfig struct {
+	Prefix  string            // devices cgroup prefix (e.g. executor_server)
+	Default []string          // rules allowed for all runs (e.g. c 1:3 rwm)
+	Shared  []string          // rules allowed if any device requested (e.g. nvidiactl)
+	Devices map[string]string // device name to its rule allowed if requested
+}
+
+// deviceCgroup is the devices cgroup created for a single run
+type deviceCgroup struct {
+	path string
+}
+
+// newDeviceCgroup creates devices cgroup denies all devices except the
+// default and the requested ones
+func newDeviceCgroup(c *DeviceConfig, devices []string) (*deviceCgroup, error) {
+	rules := append([]string{}, c.Default...)
+	if len(devices) > 0 {
+		rules = append(rules, c.Shared...)
+	}
+	for _, d := range devices {
+		r, ok := c.Devices[d]
+		if !ok {
+			return nil, fmt.Errorf("device %s is not available", d)
+		}
+		rules = append(rules, r)
+	}
+
+	base := path.Join(cgroupBasePath, "devices", c.Prefix)
+	if err := os.MkdirAll(base, 0755); err != nil {
+		return nil, err
+	}
+	p, err := os.MkdirTemp(base, "d")
+	if err != nil {
+		return nil, err
+	}
+	cg := &deviceCgroup{path: p}
+	if err := cg.write("devices.deny", "a"); err != nil {
+		cg.Destroy()
+		return nil, err
+	}
+	for _, r := range rules {
+		if err := cg.write("devices.allow", r); err != nil {
+			cg.Destroy()
+			return nil, fmt.Errorf("devices.allow %q: %v", r, err)
+		}
+	}
+	return cg, nil
+}
+
+func (c *deviceCgroup) write(name, content string) error {
+	return os.WriteFile(path.Join(c.path, name), []byte(content), 0644)
+}
+
+// AddProc adds the process into the devices cgroup
+func (c *deviceCgroup) AddProc(pid int) error {
+	return c.write("cgroup.procs", fmt.Sprint(pid))
+}
+
+// Destroy removes the devices cgroup once the processes exited
+func (c *deviceCgroup) Destroy() error {
+	return os.Remove(c.path)
+}
+
+// DevicesAvailable checks whether the devices cgroup controller (v1) is
+// mounted
+func DevicesAvailable() error {
+	p := path.Join(cgroupBasePath, "devices")
+	if _, err := os.Stat(path.Join(p, "devices.allow")); err != nil {
+		return fmt.Errorf("devices cgroup controller (v1) is not available at %s: %v", p, err)
+	}
+	return nil
+}
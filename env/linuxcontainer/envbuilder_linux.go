@@ -2,6 +2,7 @@ package linuxcontainer
 
 import (
 	"fmt"
+	"os"
 	"syscall"
 
 	"github.com/criyle/go-judge/env/pool"
@@ -16,15 +17,48 @@ type Config struct {
 	Seccomp    []syscall.SockFilter
 	Cpuset     string
 	CPURate    bool
+
+	// FreshCgroupPool builds and destroys cgroup for each run requested with
+	// fresh cgroup (optional, defaults to CgroupPool)
+	FreshCgroupPool CgroupPool
+
+	// MemoryExcludeCache reports memory as the peak minus page cache of the
+	// cgroup (estimate), the raw peak is reported as memory peak
+	MemoryExcludeCache bool
+
+	// DisableSwap limits the memory and swap of the cgroup to the memory
+	// limit so that memory limit exceeded triggers at physical memory,
+	// otherwise the swap usage is reported
+	DisableSwap bool
+
+	// Devices restricts device access of each run by devices cgroup
+	// (optional, e.g. to expose GPU to the profiles allowed)
+	Devices *DeviceConfig
+
+	// InstructionCounter enables counting instructions of the runs requested
+	// by the hardware perf counter
+	InstructionCounter bool
+
+	// Reaper is the executable (cmd/creaper or calls Reaper in init) started
+	// as the parent of each command to reap its orphans (optional, e.g.
+	// double forked processes left as zombies otherwise)
+	Reaper *os.File
 }
 
 type environmentBuilder struct {
 	builder EnvironmentBuilder
 	cgPool  CgroupPool
+	fcgPool CgroupPool
 	workDir string
 	seccomp []syscall.SockFilter
 	cpuset  string
 	cpuRate bool
+	noCache bool
+	noSwap  bool
+	devices *DeviceConfig
+
+	instructions bool
+	reaper       *os.File
 }
 
 // NewEnvBuilder creates builder for linux container pools
@@ -32,10 +66,17 @@ func NewEnvBuilder(c Config) pool.EnvBuilder {
 	return &environmentBuilder{
 		builder: c.Builder,
 		cgPool:  c.CgroupPool,
+		fcgPool: c.FreshCgroupPool,
 		workDir: c.WorkDir,
 		seccomp: c.Seccomp,
 		cpuset:  c.Cpuset,
 		cpuRate: c.CPURate,
+		noCache: c.MemoryExcludeCache,
+		noSwap:  c.DisableSwap,
+		devices: c.Devices,
+
+		instructions: c.InstructionCounter,
+		reaper:       c.Reaper,
 	}
 }
 
@@ -53,12 +94,24 @@ func (b *environmentBuilder) Build() (pool.Environment, error) {
 	if err != nil {
 		return nil, fmt.Errorf("container: failed to prepare work directory")
 	}
+	var stderr *initStderr
+	if e, ok := m.(*initEnvironment); ok {
+		stderr = e.stderr
+	}
 	return &environ{
 		Environment: m,
+		stderr:      stderr,
 		cgPool:      b.cgPool,
+		fcgPool:     b.fcgPool,
 		wd:          wd[0],
 		cpuset:      b.cpuset,
 		cpuRate:     b.cpuRate,
 		seccomp:     b.seccomp,
+		noCache:     b.noCache,
+		noSwap:      b.noSwap,
+		devices:     b.devices,
+
+		instructions: b.instructions,
+		reaper:       b.reaper,
 	}, nil
 }
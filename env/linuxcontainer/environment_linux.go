@@ -3,13 +3,17 @@ package linuxcontainer
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/criyle/go-judge/envexec"
 	"github.com/criyle/go-sandbox/container"
 	"github.com/criyle/go-sandbox/pkg/rlimit"
+	"github.com/criyle/go-sandbox/runner"
+	"golang.org/x/sys/unix"
 )
 
 var _ envexec.Environment = &environ{}
@@ -18,10 +22,19 @@ var _ envexec.Environment = &environ{}
 type environ struct {
 	container.Environment
 	cgPool  CgroupPool
-	wd      *os.File // container work dir
+	fcgPool CgroupPool // fresh cgroup pool (optional)
+	wd      *os.File   // container work dir
 	cpuset  string
 	seccomp []syscall.SockFilter
 	cpuRate bool
+	noCache bool // memory excludes page cache
+	noSwap  bool // swap limited to 0
+	devices *DeviceConfig
+
+	instructions bool     // instruction counter enabled
+	reaper       *os.File // reaper executable (optional)
+
+	stderr *initStderr // container init stderr (optional)
 }
 
 // Destory destories the environment
@@ -33,18 +46,62 @@ func (c *environ) Reset() error {
 	return c.Environment.Reset()
 }
 
+// VerifyReset checks the work dir is empty after reset
+func (c *environ) VerifyReset() error {
+	names, err := c.WorkDir().Readdirnames(1)
+	if err != nil && err != io.EOF {
+		return err
+	}
+	if len(names) > 0 {
+		return fmt.Errorf("work dir is not empty: %s", names[0])
+	}
+	return nil
+}
+
 // Execve execute process inside the environment
 func (c *environ) Execve(ctx context.Context, param envexec.ExecveParam) (envexec.Process, error) {
 	var (
 		cg       Cgroup
+		cgTime   time.Duration
 		syncFunc func(int) error
 		err      error
 	)
+	// setgroups is denied in the user namespace of the container and the
+	// container init executes the process with its own groups
+	if len(param.Groups) > 0 {
+		return nil, fmt.Errorf("execve: supplementary groups %v are not supported", param.Groups)
+	}
+	// the container init drops all capabilities before execve
+	if len(param.Capabilities) > 0 {
+		return nil, fmt.Errorf("execve: capabilities %v are not supported", param.Capabilities)
+	}
+	if param.CountInstructions && !c.instructions {
+		return nil, fmt.Errorf("execve: instruction counter is not enabled")
+	}
+
+	// devices cgroup is created for each run to apply the requested devices
+	var dcg *deviceCgroup
+	if c.devices != nil {
+		dcg, err = newDeviceCgroup(c.devices, param.Devices)
+		if err != nil {
+			return nil, fmt.Errorf("execve: failed to create devices cgroup %v", err)
+		}
+	} else if len(param.Devices) > 0 {
+		return nil, fmt.Errorf("execve: devices %v are not available", param.Devices)
+	}
 
 	limit := param.Limit
-	if c.cgPool != nil {
-		cg, err = c.cgPool.Get()
+	cgPool := c.cgPool
+	if limit.FreshCgroup && c.fcgPool != nil {
+		cgPool = c.fcgPool
+	}
+	if cgPool != nil {
+		cgStart := time.Now()
+		cg, err = cgPool.Get()
 		if err != nil {
+			if dcg != nil {
+				dcg.Destroy()
+			}
 			return nil, fmt.Errorf("execve: failed to get cgroup %v", err)
 		}
 		if c.cpuset != "" {
@@ -56,31 +113,215 @@ func (c *environ) Execve(ctx context.Context, param envexec.ExecveParam) (envexe
 		cg.SetMemoryLimit(limit.Memory)
 		cg.SetProcLimit(limit.Proc)
 		syncFunc = cg.AddProc
+		cgTime = time.Since(cgStart)
 	}
 
+	fileSize := limit.Output
+	if limit.FileSize > 0 && (fileSize == 0 || limit.FileSize < fileSize) {
+		fileSize = limit.FileSize
+	}
 	rLimits := rlimit.RLimits{
 		CPU:         uint64(limit.Time.Truncate(time.Second)/time.Second) + 1,
-		FileSize:    limit.Output.Byte(),
+		FileSize:    fileSize.Byte(),
 		Stack:       limit.Stack.Byte(),
 		DisableCore: true,
 	}
 
-	if limit.StrictMemory || c.cgPool == nil {
+	if limit.StrictMemory || cgPool == nil {
 		rLimits.Data = limit.Memory.Byte()
 	}
+	rl := rLimits.PrepareRLimit()
+	if limit.OpenFile > 0 {
+		rl = append(rl, rlimit.RLimit{
+			Res:  unix.RLIMIT_NOFILE,
+			Rlim: syscall.Rlimit{Cur: limit.OpenFile, Max: limit.OpenFile},
+		})
+	}
+	if limit.Nproc > 0 {
+		rl = append(rl, rlimit.RLimit{
+			Res:  unix.RLIMIT_NPROC,
+			Rlim: syscall.Rlimit{Cur: limit.Nproc, Max: limit.Nproc},
+		})
+	}
+
+	// records the pid to read its /proc and cgroup files and the cpu stat base
+	// once it is added to the cgroup
+	cs := new(cpuStat)
+	var ms *memStat
+	if c.noCache && cgPool != nil {
+		ms = new(memStat)
+	}
+	var mc *memCurrent
+	if limit.MemoryAccounting == envexec.MemoryAccountingCurrent && cgPool != nil {
+		mc = new(memCurrent)
+	}
+	var su *swapUsage
+	if !c.noSwap && cgPool != nil {
+		su = new(swapUsage)
+	}
+	var pe *pidsEvents
+	var mh *memoryHigh
+	if cgPool != nil {
+		pe = new(pidsEvents)
+		mh = new(memoryHigh)
+	}
+	pid := new(int32)
+	var ic *instructionCounter
+	if param.CountInstructions {
+		ic = new(instructionCounter)
+	}
+	var sc *syscallCounter
+	if param.CountSyscalls {
+		sc = new(syscallCounter)
+	}
+	var pt *procTime
+	if limit.TimeAccounting == envexec.TimeAccountingProcess {
+		pt = &procTime{pid: pid}
+	}
+	var cr *cgroupReport
+	if param.ReportCgroup {
+		cr = new(cgroupReport)
+	}
+	im := c.stderr.mark()
+	startFunc := func(p int) error {
+		atomic.StoreInt32(pid, int32(p))
+		if limit.Nice != 0 {
+			if err := setNice(p, limit.Nice); err != nil {
+				return err
+			}
+		}
+		if dcg != nil {
+			if err := dcg.AddProc(p); err != nil {
+				return err
+			}
+		}
+		// opened before execve so that it is enabled on exec
+		if ic != nil {
+			if err := ic.open(p); err != nil {
+				return err
+			}
+		}
+		if sc != nil {
+			if err := sc.open(p); err != nil {
+				return err
+			}
+		}
+		if syncFunc != nil {
+			if err := syncFunc(p); err != nil {
+				return err
+			}
+			cs.init(int32(p))
+			// cpu controller is only enabled with cpu rate
+			if c.cpuRate {
+				if err := setCPUWeight(int32(p), limit.Nice); err != nil {
+					return err
+				}
+			}
+			if ms != nil {
+				ms.init(int32(p))
+			}
+			if mc != nil {
+				mc.init(int32(p))
+			}
+			if c.noSwap {
+				if err := disableSwap(int32(p), limit.Memory); err != nil {
+					return err
+				}
+			}
+			if su != nil {
+				su.init(int32(p))
+			}
+			if pe != nil {
+				pe.init(int32(p))
+			}
+			if mh != nil {
+				mh.init(int32(p), limit.MemorySoft)
+			}
+		}
+		// after added to the devices cgroup as well
+		if cr != nil {
+			cr.init(int32(p))
+		}
+		return nil
+	}
+
+	// the reaper execs the command itself, the exec file is passed after the
+	// files, and the process time would be of the reaper
+	args, files, execFile := param.Args, param.Files, param.ExecFile
+	if c.reaper != nil && pt == nil {
+		execFd := -1
+		if execFile != 0 {
+			execFd = len(files)
+			files = append(append([]uintptr(nil), files...), execFile)
+		}
+		args, execFile = reaperArgs(args, len(param.Files), execFd), c.reaper.Fd()
+	}
+
+	// the work dir is not used by other runs until the process exited
+	var restoreFileCount func() error
+	if limit.FileCount > 0 && c.FileCountLimited() {
+		if restoreFileCount, err = limitFileCount(c.wd, limit.FileCount); err != nil {
+			if dcg != nil {
+				dcg.Destroy()
+			}
+			if cg != nil {
+				cgPool.Put(cg)
+			}
+			return nil, fmt.Errorf("execve: %v", err)
+		}
+	}
 
 	p := container.ExecveParam{
-		Args:     param.Args,
+		Args:     args,
 		Env:      param.Env,
-		Files:    param.Files,
+		Files:    files,
 		CTTY:     param.TTY,
-		ExecFile: param.ExecFile,
-		RLimits:  rLimits.PrepareRLimit(),
+		ExecFile: execFile,
+		RLimits:  rl,
 		Seccomp:  c.seccomp,
-		SyncFunc: syncFunc,
+		SyncFunc: startFunc,
 	}
 	rt := c.Environment.Execve(ctx, p)
-	return newProcess(rt, cg, c.cgPool), nil
+	if dcg != nil || restoreFileCount != nil {
+		rt = afterExit(rt, func() {
+			if dcg != nil {
+				dcg.Destroy()
+			}
+			if restoreFileCount != nil {
+				restoreFileCount()
+			}
+		})
+	}
+	return newProcess(rt, cg, cgPool, cgTime, pid, samplers{
+		cpuStat:    cs,
+		memStat:    ms,
+		memCurrent: mc,
+		swapUsage:  su,
+		pidsEvents: pe,
+		memoryHigh: mh,
+		insCounter: ic,
+		sysCounter: sc,
+		procTime:   pt,
+		cgReport:   cr,
+		initStderr: im,
+	}), nil
+}
+
+// afterExit calls f (e.g. removes the devices cgroup) once the process exited
+func afterExit(ch <-chan runner.Result, f func()) <-chan runner.Result {
+	rt := make(chan runner.Result, 1)
+	go func() {
+		r := <-ch
+		f()
+		rt <- r
+	}()
+	return rt
+}
+
+// FileCountLimited limits the file count by the nr_inodes if the work dir is
+// tmpfs with it
+func (c *environ) FileCountLimited() bool {
+	return fileCountLimitable(c.wd)
 }
 
 // WorkDir returns opened work directory, should not close after
@@ -1,25 +1,97 @@
 package linuxcontainer
 
 import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/criyle/go-judge/envexec"
 	"github.com/criyle/go-sandbox/runner"
 )
 
-var _ envexec.Process = &process{}
+const cgroupBasePath = "/sys/fs/cgroup"
+
+var (
+	_ envexec.Process     = &process{}
+	_ envexec.CgroupTimer = &process{}
+
+	_ envexec.CPUStater          = &process{}
+	_ envexec.MemoryPeaker       = &process{}
+	_ envexec.SwapReporter       = &process{}
+	_ envexec.ProcCounter        = &process{}
+	_ envexec.WriteCounter       = &process{}
+	_ envexec.Signaler           = &process{}
+	_ envexec.InstructionCounter = &process{}
+	_ envexec.OpenFileLister     = &process{}
+	_ envexec.SocketCounter      = &process{}
+	_ envexec.ProcLimitCounter   = &process{}
+	_ envexec.CgroupReporter     = &process{}
+	_ envexec.Diagnoser          = &process{}
+	_ envexec.MemorySoftReporter = &process{}
+)
+
+// samplers defines the optional samplers of the process
+type samplers struct {
+	cpuStat    *cpuStat            // initialized once the process added to cgroup
+	memStat    *memStat            // nil unless memory excludes page cache
+	memCurrent *memCurrent         // nil unless memory is accounted at exit
+	swapUsage  *swapUsage          // nil if swap disabled
+	pidsEvents *pidsEvents         // nil unless cgroup enabled
+	memoryHigh *memoryHigh         // nil unless cgroup enabled
+	insCounter *instructionCounter // nil unless counting instructions
+	sysCounter *syscallCounter     // nil unless counting syscalls
+	procTime   *procTime           // nil unless time accounted by process
+	cgReport   *cgroupReport       // nil unless reporting cgroup
+	initStderr *initStderrMark     // nil unless init stderr captured
+}
 
 // process defines the running process
 type process struct {
+	samplers
+
 	rt   runner.Result
 	done chan struct{}
 	cg   Cgroup
+
+	cgTime time.Duration // time spent to set up cgroup
+	pid    *int32        // host pid set once the process started
+
+	userTime   time.Duration
+	systemTime time.Duration
+	cpuStatErr error
+
+	memoryPeak envexec.Size // raw peak if memory is estimated
+	swapPeak   envexec.Size
+	swapRead   bool
+
+	pidsOnce sync.Once
+	pidsPath string // pids.current of the process cgroup
+
+	procsOnce sync.Once
+	procsPath string // cgroup.procs of the process cgroup
+
+	ioOnce sync.Once
+	ioPath string // io.stat (v2) or blkio.throttle.io_service_bytes (v1)
+	ioV2   bool
+
+	diagnosis string // init stderr of the runner error
 }
 
-func newProcess(ch <-chan runner.Result, cg Cgroup, cgPool CgroupPool) *process {
+func newProcess(ch <-chan runner.Result, cg Cgroup, cgPool CgroupPool, cgTime time.Duration, pid *int32, s samplers) *process {
 	p := &process{
-		done: make(chan struct{}),
-		cg:   cg,
+		samplers: s,
+		done:     make(chan struct{}),
+		cg:       cg,
+		cgTime:   cgTime,
+		pid:      pid,
 	}
 	go func() {
 		defer close(p.done)
@@ -27,13 +99,43 @@ func newProcess(ch <-chan runner.Result, cg Cgroup, cgPool CgroupPool) *process
 			defer cgPool.Put(cg)
 		}
 		p.rt = <-ch
+		// the container init failed to set up the exec
+		if s.initStderr != nil && p.rt.Status == runner.StatusRunnerError {
+			p.diagnosis = s.initStderr.since()
+		}
+		if s.insCounter != nil {
+			s.insCounter.close()
+		}
+		if s.sysCounter != nil {
+			s.sysCounter.close()
+		}
 		if cg != nil {
-			if t, err := cg.CPUUsage(); err == nil {
+			// the runner reports the rusage of the process
+			if t, err := cg.CPUUsage(); err == nil && s.procTime == nil {
 				p.rt.Time = t
 			}
 			if m, err := cg.MemoryUsage(); err == nil {
-				p.rt.Memory = m
+				p.rt.Memory = p.excludeCache(m)
+				// the cgroup only holds page cache after the program exited,
+				// thus the current memory is the last sample before exit
+				if c, ok := p.memCurrent.last(); ok {
+					p.rt.Memory = c
+				}
+				if p.rt.Memory != m {
+					p.memoryPeak = m
+				}
 			}
+			// read before the cgroup is put back to pool
+			if s.swapUsage != nil {
+				p.swapPeak, p.swapRead = s.swapUsage.peak()
+			}
+		}
+		p.userTime, p.systemTime, p.cpuStatErr = s.cpuStat.elapsed()
+		if s.pidsEvents != nil {
+			s.pidsEvents.finish()
+		}
+		if s.memoryHigh != nil {
+			s.memoryHigh.finish()
 		}
 	}()
 	return p
@@ -53,12 +155,421 @@ func (p *process) Usage() envexec.Usage {
 		t time.Duration
 		m envexec.Size
 	)
-	if p.cg != nil {
+	if p.procTime != nil {
+		t, _ = p.procTime.read()
+	} else if p.cg != nil {
 		t, _ = p.cg.CPUUsage()
+	}
+	if p.cg != nil {
 		m, _ = p.cg.MemoryUsage()
+		m = p.excludeCache(m)
+		if p.memCurrent != nil {
+			if c, err := p.memCurrent.sample(); err == nil {
+				m = p.excludeCache(c)
+				p.memCurrent.set(m)
+			}
+		}
+		if p.swapUsage != nil {
+			p.swapUsage.sample()
+		}
 	}
 	return envexec.Usage{
 		Time:   t,
 		Memory: m,
 	}
 }
+
+func (p *process) CgroupTime() time.Duration {
+	return p.cgTime
+}
+
+// CPUStat returns the user and system cpu time read from the cgroup after the
+// process exited
+func (p *process) CPUStat() (time.Duration, time.Duration, error) {
+	<-p.done
+	return p.userTime, p.systemTime, p.cpuStatErr
+}
+
+// MemoryPeak returns the raw cgroup peak memory if the memory of the result is
+// estimated excluding page cache or accounted as current at exit
+func (p *process) MemoryPeak() (envexec.Size, bool) {
+	<-p.done
+	return p.memoryPeak, p.memoryPeak > 0
+}
+
+// SwapUsage returns the max sampled swap usage of the cgroup if swap is allowed
+func (p *process) SwapUsage() (envexec.Size, bool) {
+	<-p.done
+	return p.swapPeak, p.swapRead
+}
+
+// Diagnosis returns the container init stderr written during the run if the
+// exec failed to set up in the container
+func (p *process) Diagnosis() string {
+	<-p.done
+	return p.diagnosis
+}
+
+// excludeCache estimates the memory as the peak minus current page cache
+func (p *process) excludeCache(m envexec.Size) envexec.Size {
+	if p.memStat == nil {
+		return m
+	}
+	c, err := p.memStat.cache()
+	if err != nil || c >= m {
+		return m
+	}
+	return m - c
+}
+
+// Instructions reads the user space instructions retired by the process and
+// its exited children, it is the final count after the process exited
+func (p *process) Instructions() (uint64, error) {
+	if p.insCounter == nil {
+		return 0, fmt.Errorf("instructions: instruction counter not enabled")
+	}
+	return p.insCounter.read()
+}
+
+// SyscallCounts reads the syscalls entered by the process and its exited
+// children by name, it is the final counts after the process exited
+func (p *process) SyscallCounts() (map[string]uint64, error) {
+	if p.sysCounter == nil {
+		return nil, fmt.Errorf("syscall counts: syscall counter not enabled")
+	}
+	return p.sysCounter.read()
+}
+
+// CgroupInfo reports the cgroup version and the controllers the process was
+// placed in once started
+func (p *process) CgroupInfo() (envexec.CgroupInfo, error) {
+	if p.cgReport == nil {
+		return envexec.CgroupInfo{}, fmt.Errorf("cgroup info: cgroup report not enabled")
+	}
+	return p.cgReport.read()
+}
+
+// OpenFiles reads the targets of the open file descriptors of the process
+// from /proc, it fails if the process exited (fds are closed on exit)
+func (p *process) OpenFiles() ([]string, error) {
+	pid := atomic.LoadInt32(p.pid)
+	if pid == 0 {
+		return nil, fmt.Errorf("open files: process not started")
+	}
+	dir := fmt.Sprintf("/proc/%d/fd", pid)
+	d, err := os.Open(dir)
+	if err != nil {
+		return nil, err
+	}
+	names, err := d.Readdirnames(-1)
+	d.Close()
+	if err != nil {
+		return nil, err
+	}
+	fds := make([]int, 0, len(names))
+	for _, n := range names {
+		if fd, err := strconv.Atoi(n); err == nil {
+			fds = append(fds, fd)
+		}
+	}
+	sort.Ints(fds)
+	rt := make([]string, 0, len(fds))
+	for _, fd := range fds {
+		t, err := os.Readlink(path.Join(dir, strconv.Itoa(fd)))
+		if err != nil { // closed meanwhile
+			continue
+		}
+		rt = append(rt, strconv.Itoa(fd)+" -> "+t)
+	}
+	// the fds of the zombie are already closed
+	if exited(pid) {
+		return nil, fmt.Errorf("open files: process exited")
+	}
+	return rt, nil
+}
+
+// exited checks whether the process is gone or a zombie from /proc/[pid]/stat
+func exited(pid int32) bool {
+	b, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return true
+	}
+	// pid (comm) state ...
+	i := bytes.LastIndexByte(b, ')')
+	if i < 0 || i+2 >= len(b) {
+		return true
+	}
+	return b[i+2] == 'Z' || b[i+2] == 'X'
+}
+
+// ProcCount reads pids.current from the pids cgroup of the process
+func (p *process) ProcCount() (uint64, error) {
+	if p.cg == nil {
+		return 0, fmt.Errorf("proc count: cgroup not enabled")
+	}
+	pid := atomic.LoadInt32(p.pid)
+	if pid == 0 {
+		return 0, fmt.Errorf("proc count: process not started")
+	}
+	p.pidsOnce.Do(func() {
+		p.pidsPath, _ = cgroupFilePath(pid, "pids", "pids.current", "pids.current")
+	})
+	if p.pidsPath == "" {
+		return 0, fmt.Errorf("proc count: pids cgroup not found")
+	}
+	b, err := os.ReadFile(p.pidsPath)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(b)), 10, 64)
+}
+
+// WriteBytes reads the cumulative bytes written to block devices from the io
+// cgroup of the process
+func (p *process) WriteBytes() (uint64, error) {
+	if p.cg == nil {
+		return 0, fmt.Errorf("write bytes: cgroup not enabled")
+	}
+	pid := atomic.LoadInt32(p.pid)
+	if pid == 0 {
+		return 0, fmt.Errorf("write bytes: process not started")
+	}
+	p.ioOnce.Do(func() {
+		p.ioPath, p.ioV2 = cgroupFilePath(pid, "blkio", "io.stat", "blkio.throttle.io_service_bytes")
+	})
+	if p.ioPath == "" {
+		return 0, fmt.Errorf("write bytes: io cgroup not found")
+	}
+	b, err := os.ReadFile(p.ioPath)
+	if err != nil {
+		return 0, err
+	}
+	var rt uint64
+	for _, l := range strings.Split(string(b), "\n") {
+		f := strings.Fields(l)
+		if p.ioV2 {
+			// 8:0 rbytes=1 wbytes=2 rios=3 wios=4 dbytes=5 dios=6
+			for _, kv := range f {
+				if v := strings.TrimPrefix(kv, "wbytes="); v != kv {
+					n, err := strconv.ParseUint(v, 10, 64)
+					if err != nil {
+						return 0, err
+					}
+					rt += n
+				}
+			}
+		} else if len(f) == 3 && f[1] == "Write" {
+			// 8:0 Write 2
+			n, err := strconv.ParseUint(f[2], 10, 64)
+			if err != nil {
+				return 0, err
+			}
+			rt += n
+		}
+	}
+	return rt, nil
+}
+
+// userHZ is the unit of cpuacct.stat (USER_HZ)
+const userHZ = 100
+
+// cpuStat reads user / system cpu time from cpuacct.stat (v1) or cpu.stat (v2)
+// of the process cgroup, the base is read before the program runs since the
+// pooled cgroup cannot reset them
+type cpuStat struct {
+	path         string
+	v2           bool
+	user, system time.Duration
+	err          error
+}
+
+// init resolves the stat file of the process cgroup and reads the base
+func (s *cpuStat) init(pid int32) {
+	s.path, s.v2 = cgroupFilePath(pid, "cpuacct", "cpu.stat", "cpuacct.stat")
+	if s.path == "" {
+		s.err = fmt.Errorf("cpu stat: cpuacct cgroup not found")
+		return
+	}
+	s.user, s.system, s.err = s.read()
+}
+
+func (s *cpuStat) elapsed() (time.Duration, time.Duration, error) {
+	if s.err != nil {
+		return 0, 0, s.err
+	}
+	if s.path == "" {
+		return 0, 0, fmt.Errorf("cpu stat: cgroup not enabled")
+	}
+	u, sys, err := s.read()
+	if err != nil {
+		return 0, 0, err
+	}
+	return u - s.user, sys - s.system, nil
+}
+
+func (s *cpuStat) read() (user, system time.Duration, err error) {
+	b, err := os.ReadFile(s.path)
+	if err != nil {
+		return 0, 0, err
+	}
+	userKey, systemKey, unit := "user", "system", time.Second/userHZ
+	if s.v2 {
+		userKey, systemKey, unit = "user_usec", "system_usec", time.Microsecond
+	}
+	for _, l := range strings.Split(string(b), "\n") {
+		// user 1 / user_usec 1
+		f := strings.Fields(l)
+		if len(f) != 2 || (f[0] != userKey && f[0] != systemKey) {
+			continue
+		}
+		n, err := strconv.ParseUint(f[1], 10, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+		if f[0] == userKey {
+			user = time.Duration(n) * unit
+		} else {
+			system = time.Duration(n) * unit
+		}
+	}
+	return user, system, nil
+}
+
+// memStat reads page cache from memory.stat of the process cgroup ("cache" on
+// v1 / "file" on v2), the path is resolved once the process added to cgroup
+type memStat struct {
+	mu   sync.Mutex
+	path string
+	v2   bool
+}
+
+func (s *memStat) init(pid int32) {
+	path, v2 := cgroupFilePath(pid, "memory", "memory.stat", "memory.stat")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.path, s.v2 = path, v2
+}
+
+func (s *memStat) cache() (envexec.Size, error) {
+	s.mu.Lock()
+	path, v2 := s.path, s.v2
+	s.mu.Unlock()
+	if path == "" {
+		return 0, fmt.Errorf("memory stat: memory cgroup not found")
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	key := "cache"
+	if v2 {
+		key = "file"
+	}
+	for _, l := range strings.Split(string(b), "\n") {
+		// cache 1 / file 1
+		f := strings.Fields(l)
+		if len(f) != 2 || f[0] != key {
+			continue
+		}
+		n, err := strconv.ParseUint(f[1], 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		return envexec.Size(n), nil
+	}
+	return 0, fmt.Errorf("memory stat: %s not found", key)
+}
+
+// memCurrent samples the current memory from memory.usage_in_bytes (v1) or
+// memory.current (v2) of the process cgroup and keeps the last sample, the
+// path is resolved once the process added to cgroup
+type memCurrent struct {
+	mu      sync.Mutex
+	path    string
+	current envexec.Size
+	sampled bool
+}
+
+func (s *memCurrent) init(pid int32) {
+	path, _ := cgroupFilePath(pid, "memory", "memory.current", "memory.usage_in_bytes")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.path = path
+}
+
+func (s *memCurrent) sample() (envexec.Size, error) {
+	s.mu.Lock()
+	path := s.path
+	s.mu.Unlock()
+	if path == "" {
+		return 0, fmt.Errorf("memory current: memory cgroup not found")
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseUint(strings.TrimSpace(string(b)), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return envexec.Size(n), nil
+}
+
+func (s *memCurrent) set(m envexec.Size) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.current, s.sampled = m, true
+}
+
+// last returns the last sample, false if not sampled (e.g. the program exited
+// before the first sample) where the peak is reported instead
+func (s *memCurrent) last() (envexec.Size, bool) {
+	if s == nil {
+		return 0, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.current, s.sampled
+}
+
+// cgroupFilePath finds the cgroup file path from /proc/[pid]/cgroup, it
+// returns v2 file if the process is in unified hierarchy or otherwise v1 file
+// of the controller
+func cgroupFilePath(pid int32, controller, v2, v1 string) (string, bool) {
+	b, err := os.ReadFile(fmt.Sprintf("/proc/%d/cgroup", pid))
+	if err != nil {
+		return "", false
+	}
+	for _, l := range strings.Split(string(b), "\n") {
+		// hierarchy-ID:controller-list:cgroup-path
+		f := strings.SplitN(l, ":", 3)
+		if len(f) != 3 {
+			continue
+		}
+		switch {
+		case f[1] == "": // cgroup v2
+			return path.Join(cgroupBasePath, f[2], v2), true
+		case strings.Contains(","+f[1]+",", ","+controller+","):
+			return path.Join(cgroupBasePath, controller, f[2], v1), false
+		}
+	}
+	return "", false
+}
+
+// Signal sends signal to the program if it is still running
+func (p *process) Signal(sig os.Signal) error {
+	s, ok := sig.(syscall.Signal)
+	if !ok {
+		return fmt.Errorf("signal: unsupported signal %v", sig)
+	}
+	pid := atomic.LoadInt32(p.pid)
+	if pid == 0 {
+		return fmt.Errorf("signal: process not started")
+	}
+	select {
+	case <-p.done:
+		return fmt.Errorf("signal: process exited")
+	default:
+	}
+	return syscall.Kill(int(pid), s)
+}
@@ -0,0 +1,76 @@
+package linuxcontainer
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// fspick / fsconfig flags and commands (linux 5.2+)
+const (
+	fspickCloexec          = 0x1
+	fspickEmptyPath        = 0x8
+	fsconfigSetString      = 1
+	fsconfigCmdReconfigure = 7
+)
+
+// fileCountLimitable returns whether the work dir is tmpfs with nr_inodes
+// (tmpfs without it reports no inode count)
+func fileCountLimitable(wd *os.File) bool {
+	var st unix.Statfs_t
+	if err := unix.Fstatfs(int(wd.Fd()), &st); err != nil {
+		return false
+	}
+	return st.Type == unix.TMPFS_MAGIC && st.Files > 0
+}
+
+// limitFileCount limits the inodes of the work dir tmpfs to the used count
+// plus n by its nr_inodes, one more is allowed so that the limit exceeded is
+// sampled, it returns the func to restore the nr_inodes configured
+func limitFileCount(wd *os.File, n uint64) (func() error, error) {
+	var st unix.Statfs_t
+	if err := unix.Fstatfs(int(wd.Fd()), &st); err != nil {
+		return nil, fmt.Errorf("file limit: %v", err)
+	}
+	max := st.Files - st.Ffree + n + 1
+	if max >= st.Files {
+		return func() error { return nil }, nil
+	}
+	if err := setNrInodes(wd, max); err != nil {
+		return nil, fmt.Errorf("file limit: %v", err)
+	}
+	return func() error {
+		return setNrInodes(wd, st.Files)
+	}, nil
+}
+
+// setNrInodes reconfigures the nr_inodes of the tmpfs mounted at the dir, it
+// is done by fspick on the opened dir since the mount is in the container
+// mount namespace
+func setNrInodes(dir *os.File, n uint64) error {
+	empty := []byte{0}
+	fd, _, errno := unix.Syscall(unix.SYS_FSPICK, dir.Fd(), uintptr(unsafe.Pointer(&empty[0])), fspickCloexec|fspickEmptyPath)
+	if errno != 0 {
+		return fmt.Errorf("fspick: %v", errno)
+	}
+	defer unix.Close(int(fd))
+
+	key, err := unix.BytePtrFromString("nr_inodes")
+	if err != nil {
+		return err
+	}
+	value, err := unix.BytePtrFromString(strconv.FormatUint(n, 10))
+	if err != nil {
+		return err
+	}
+	if _, _, errno := unix.Syscall6(unix.SYS_FSCONFIG, fd, fsconfigSetString, uintptr(unsafe.Pointer(key)), uintptr(unsafe.Pointer(value)), 0, 0); errno != 0 {
+		return fmt.Errorf("fsconfig nr_inodes: %v", errno)
+	}
+	if _, _, errno := unix.Syscall6(unix.SYS_FSCONFIG, fd, fsconfigCmdReconfigure, 0, 0, 0, 0); errno != 0 {
+		return fmt.Errorf("fsconfig reconfigure: %v", errno)
+	}
+	return nil
+}
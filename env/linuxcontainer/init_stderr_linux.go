@@ -0,0 +1,162 @@
+package linuxcontainer
+
+import (
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/criyle/go-judge/envexec"
+	"github.com/criyle/go-sandbox/container"
+)
+
+// initStderrSize is the bytes of the container init stderr kept for diagnosis
+const initStderrSize = 4 << 10
+
+// initStderrWait is the time to wait the stderr of the failed container init
+// to be drained
+const initStderrWait = 100 * time.Millisecond
+
+var _ EnvironmentBuilder = &InitStderrBuilder{}
+
+// InitStderrBuilder builds the container with the stderr of its init copied
+// to Stderr, the last output is kept as the diagnosis of the failures to build
+// the container or to set up the exec in the container
+type InitStderrBuilder struct {
+	Builder container.Builder
+	Stderr  io.Writer // optional
+}
+
+// Build creates the container with its own stderr pipe
+func (b *InitStderrBuilder) Build() (container.Environment, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	s := newInitStderr(r, b.Stderr)
+	cb := b.Builder
+	cb.Stderr = w
+	m, err := cb.Build()
+	// the container init holds the write end once started
+	w.Close()
+	if err != nil {
+		return nil, &initError{err: err, stderr: s.wait(initStderrWait)}
+	}
+	return &initEnvironment{Environment: m, stderr: s}, nil
+}
+
+// initEnvironment is the container with its init stderr captured
+type initEnvironment struct {
+	container.Environment
+	stderr *initStderr
+}
+
+// initError is the error with the stderr of the container init, the stderr
+// is not included in the error message since it may expose the host
+type initError struct {
+	err    error
+	stderr string
+}
+
+var _ envexec.Diagnoser = &initError{}
+
+func (e *initError) Error() string {
+	return e.err.Error()
+}
+
+func (e *initError) Unwrap() error {
+	return e.err
+}
+
+// Diagnosis returns the stderr of the container init
+func (e *initError) Diagnosis() string {
+	return e.stderr
+}
+
+// initStderr reads the stderr of the container init until it exits and keeps
+// the last initStderrSize bytes
+type initStderr struct {
+	mu      sync.Mutex
+	buf     []byte
+	written int64 // total bytes read
+	done    chan struct{}
+}
+
+func newInitStderr(r *os.File, w io.Writer) *initStderr {
+	s := &initStderr{done: make(chan struct{})}
+	go func() {
+		defer close(s.done)
+		defer r.Close()
+		b := make([]byte, 1024)
+		for {
+			n, err := r.Read(b)
+			if n > 0 {
+				if w != nil {
+					w.Write(b[:n])
+				}
+				s.write(b[:n])
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return s
+}
+
+func (s *initStderr) write(b []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf = append(s.buf, b...)
+	if len(s.buf) > initStderrSize {
+		s.buf = append(s.buf[:0], s.buf[len(s.buf)-initStderrSize:]...)
+	}
+	s.written += int64(len(b))
+}
+
+// initStderrMark marks the init stderr read before a run
+type initStderrMark struct {
+	s   *initStderr
+	off int64
+}
+
+// mark returns the mark of the output read so far, nil if not captured
+func (s *initStderr) mark() *initStderrMark {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &initStderrMark{s: s, off: s.written}
+}
+
+// since returns the kept output read after the mark
+func (m *initStderrMark) since() string {
+	return m.s.since(m.off)
+}
+
+// since returns the kept output read after the offset
+func (s *initStderr) since(off int64) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := s.written - off
+	if n <= 0 {
+		return ""
+	}
+	if n > int64(len(s.buf)) {
+		n = int64(len(s.buf))
+	}
+	return string(s.buf[int64(len(s.buf))-n:])
+}
+
+// wait waits the exited container init to close its stderr upto d and
+// returns the kept output
+func (s *initStderr) wait(d time.Duration) string {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-s.done:
+	case <-t.C:
+	}
+	return s.since(0)
+}
@@ -0,0 +1,71 @@
+package linuxcontainer
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+
+	"github.com/criyle/go-judge/envexec"
+)
+
+// memoryHigh throttles the process cgroup over the soft memory limit by
+// memory.high and counts the times exceeded by memory.events high (v2 only),
+// the high is written for every run since the pooled cgroup keeps the high of
+// the previous run
+type memoryHigh struct {
+	path string // memory.events of the process cgroup
+	base uint64
+	hits uint64
+	err  error
+}
+
+// init sets the memory.high of the process cgroup and reads the base
+func (h *memoryHigh) init(pid int32, soft envexec.Size) {
+	p, v2 := cgroupFilePath(pid, "memory", "memory.high", "")
+	if !v2 {
+		h.err = fmt.Errorf("memory high: cgroup v2 not enabled")
+		return
+	}
+	high := "max"
+	if soft > 0 {
+		high = strconv.FormatUint(soft.Byte(), 10)
+	}
+	if err := os.WriteFile(p, []byte(high), 0644); err != nil {
+		h.err = fmt.Errorf("memory high: %v", err)
+		return
+	}
+	h.path = path.Join(path.Dir(p), "memory.events")
+	if h.base, h.err = readEvent(h.path, "high"); h.err != nil {
+		h.err = fmt.Errorf("memory high: %v", h.err)
+	}
+}
+
+// finish reads the times exceeded during the run before the cgroup is reset
+func (h *memoryHigh) finish() {
+	if h.err != nil {
+		return
+	}
+	if h.path == "" {
+		h.err = fmt.Errorf("memory high: cgroup not enabled")
+		return
+	}
+	n, err := readEvent(h.path, "high")
+	if err != nil {
+		h.err = fmt.Errorf("memory high: %v", err)
+		return
+	}
+	if n > h.base {
+		h.hits = n - h.base
+	}
+}
+
+// MemorySoftExceeded returns whether the memory.high set by the soft limit
+// was exceeded during the run, false if not available
+func (p *process) MemorySoftExceeded() (bool, bool) {
+	if p.memoryHigh == nil {
+		return false, false
+	}
+	<-p.done
+	return p.memoryHigh.hits > 0, p.memoryHigh.err == nil
+}
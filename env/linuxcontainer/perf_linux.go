@@ -0,0 +1,101 @@
+package linuxcontainer
+
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// instructionCounter counts the user space instructions retired by the
+// process and its children (perf hardware counter inherited by forks), the
+// counter is enabled once the process calls execve so that the environment
+// set up is not counted
+type instructionCounter struct {
+	mu     sync.Mutex
+	fd     int
+	opened bool
+	closed bool
+	count  uint64 // final count after closed
+	err    error
+}
+
+// open opens the counter of the process before it calls execve
+func (c *instructionCounter) open(pid int) error {
+	fd, err := perfEventOpen(pid, unix.PerfBitDisabled|unix.PerfBitEnableOnExec)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.fd, c.opened = fd, true
+	return nil
+}
+
+func perfEventOpen(pid int, bits uint64) (int, error) {
+	attr := unix.PerfEventAttr{
+		Type:   unix.PERF_TYPE_HARDWARE,
+		Config: unix.PERF_COUNT_HW_INSTRUCTIONS,
+		Bits:   bits | unix.PerfBitInherit | unix.PerfBitExcludeKernel | unix.PerfBitExcludeHv,
+	}
+	attr.Size = uint32(unsafe.Sizeof(attr))
+	fd, err := unix.PerfEventOpen(&attr, pid, -1, -1, unix.PERF_FLAG_FD_CLOEXEC)
+	if err != nil {
+		return -1, fmt.Errorf("perf_event_open: %v", err)
+	}
+	return fd, nil
+}
+
+// read returns the current count, or the final count after closed
+func (c *instructionCounter) read() (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.opened {
+		return 0, fmt.Errorf("instruction counter: process not started")
+	}
+	if c.closed {
+		return c.count, c.err
+	}
+	return readCounter(c.fd)
+}
+
+// close reads the final count (counts of the exited children are included)
+// and releases the counter
+func (c *instructionCounter) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.opened || c.closed {
+		return nil
+	}
+	c.count, c.err = readCounter(c.fd)
+	c.closed = true
+	unix.Close(c.fd)
+	return c.err
+}
+
+func readCounter(fd int) (uint64, error) {
+	var b [8]byte
+	n, err := unix.Read(fd, b[:])
+	if err != nil {
+		return 0, fmt.Errorf("perf counter: %v", err)
+	}
+	if n != len(b) {
+		return 0, fmt.Errorf("perf counter: short read %d", n)
+	}
+	// the counter is in native byte order
+	return *(*uint64)(unsafe.Pointer(&b[0])), nil
+}
+
+// InstructionCounterAvailable checks whether the hardware instruction counter
+// could be opened (e.g. not available inside most virtual machines or
+// restricted by kernel.perf_event_paranoid)
+func InstructionCounterAvailable() error {
+	fd, err := perfEventOpen(0, 0)
+	if err != nil {
+		return fmt.Errorf("hardware instruction counter is not available: %v", err)
+	}
+	defer unix.Close(fd)
+	_, err = readCounter(fd)
+	return err
+}
@@ -0,0 +1,81 @@
+package linuxcontainer
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// pidsEvents counts the fork / clone failures by the pids cgroup limit
+// (pids.events max), the counter is cumulative for the pooled cgroup thus the
+// base is read once the process added to the cgroup
+type pidsEvents struct {
+	path string
+	base uint64
+	hits uint64
+	err  error
+}
+
+// init resolves the events file of the process cgroup and reads the base
+func (e *pidsEvents) init(pid int32) {
+	e.path, _ = cgroupFilePath(pid, "pids", "pids.events", "pids.events")
+	if e.path == "" {
+		e.err = fmt.Errorf("pids events: pids cgroup not found")
+		return
+	}
+	e.base, e.err = readPidsMax(e.path)
+}
+
+// finish reads the failures during the run before the cgroup is reset
+func (e *pidsEvents) finish() {
+	if e.err != nil {
+		return
+	}
+	if e.path == "" {
+		e.err = fmt.Errorf("pids events: cgroup not enabled")
+		return
+	}
+	n, err := readPidsMax(e.path)
+	if err != nil {
+		e.err = err
+		return
+	}
+	if n > e.base {
+		e.hits = n - e.base
+	}
+}
+
+func readPidsMax(p string) (uint64, error) {
+	n, err := readEvent(p, "max")
+	if err != nil {
+		return 0, fmt.Errorf("pids events: %v", err)
+	}
+	return n, nil
+}
+
+// readEvent reads the counter of the cgroup events file (e.g. pids.events)
+func readEvent(p, name string) (uint64, error) {
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return 0, err
+	}
+	for _, l := range strings.Split(string(b), "\n") {
+		// max 1
+		f := strings.Fields(l)
+		if len(f) == 2 && f[0] == name {
+			return strconv.ParseUint(f[1], 10, 64)
+		}
+	}
+	return 0, fmt.Errorf("%s not found", name)
+}
+
+// ProcLimitHits returns the number of process / thread creations failed by
+// the pids cgroup limit during the run
+func (p *process) ProcLimitHits() (uint64, error) {
+	if p.pidsEvents == nil {
+		return 0, fmt.Errorf("pids events: cgroup not enabled")
+	}
+	<-p.done
+	return p.pidsEvents.hits, p.pidsEvents.err
+}
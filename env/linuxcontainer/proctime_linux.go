@@ -0,0 +1,47 @@
+package linuxcontainer
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// clockTicks is the unit of the times in /proc/[pid]/stat (USER_HZ)
+const clockTicks = 100
+
+// procTime reads the user cpu time of the process itself (all of its threads
+// and the waited children) like the rusage reported at exit, instead of the
+// cgroup aggregate of all processes
+type procTime struct {
+	pid *int32
+}
+
+func (t *procTime) read() (time.Duration, error) {
+	pid := atomic.LoadInt32(t.pid)
+	if pid == 0 {
+		return 0, fmt.Errorf("process time: process not started")
+	}
+	b, err := os.ReadFile("/proc/" + strconv.Itoa(int(pid)) + "/stat")
+	if err != nil {
+		return 0, err
+	}
+	// the command name could contain spaces and parentheses
+	s := string(b)
+	f := strings.Fields(s[strings.LastIndexByte(s, ')')+1:])
+	// state is the 3rd field, utime and cutime are 14th and 16th
+	if len(f) < 14 {
+		return 0, fmt.Errorf("process time: invalid stat %q", s)
+	}
+	var ticks uint64
+	for _, i := range []int{11, 13} {
+		n, err := strconv.ParseUint(f[i], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("process time: %v", err)
+		}
+		ticks += n
+	}
+	return time.Duration(ticks) * time.Second / clockTicks, nil
+}
@@ -0,0 +1,138 @@
+package linuxcontainer
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime"
+	"strconv"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// reaperArg is the argument to start the executable as the reaper
+const reaperArg = "reaper"
+
+// sigUnblock is SIG_UNBLOCK of rt_sigprocmask
+const sigUnblock = 1
+
+// reaperSignals are forwarded to the command by the reaper
+var reaperSignals = []os.Signal{
+	unix.SIGTERM, unix.SIGINT, unix.SIGHUP, unix.SIGQUIT, unix.SIGUSR1, unix.SIGUSR2,
+}
+
+// Reaper runs the reaper if the executable is started as the reaper of a
+// command, it never returns in that case and is a noop otherwise (call it in
+// init beside container.Init)
+//
+// The reaper is the parent of the command as a child subreaper, it reaps the
+// orphans (e.g. double forked processes) until the command exits and exits
+// with the same status (or signal) as the command
+//
+// The go runtime of the reaper (threads and a few MB of memory) is counted
+// in the limits of the command, the static cmd/creaper speaks the same args
+// without runtime
+func Reaper() {
+	if len(os.Args) < 5 || os.Args[1] != reaperArg {
+		return
+	}
+	os.Exit(runReaper(os.Args[2], os.Args[3], os.Args[4:]))
+}
+
+// reaperArgs returns the args to start the command args by the reaper with
+// the first n files inherited and executed by the file at execFd (-1 for
+// args[0])
+func reaperArgs(args []string, n, execFd int) []string {
+	rt := make([]string, 0, len(args)+4)
+	rt = append(rt, "reaper", reaperArg, strconv.Itoa(n), strconv.Itoa(execFd))
+	return append(rt, args...)
+}
+
+func runReaper(files, exec string, args []string) int {
+	runtime.GOMAXPROCS(1)
+
+	n, err := strconv.Atoi(files)
+	if err != nil {
+		return reaperFail(fmt.Errorf("invalid files %q", files))
+	}
+	execFd, err := strconv.Atoi(exec)
+	if err != nil {
+		return reaperFail(fmt.Errorf("invalid exec fd %q", exec))
+	}
+	if err := unix.Prctl(unix.PR_SET_CHILD_SUBREAPER, 1, 0, 0, 0); err != nil {
+		return reaperFail(fmt.Errorf("prctl: %v", err))
+	}
+
+	// syscall.ForkExec has no fexecve, the exec file is executed by its proc
+	// path and closed on exec as it is not inherited by the command
+	path := args[0]
+	if execFd >= 0 {
+		if _, err := unix.FcntlInt(uintptr(execFd), unix.F_SETFD, unix.FD_CLOEXEC); err != nil {
+			return reaperFail(fmt.Errorf("exec fd: %v", err))
+		}
+		path = "/proc/self/fd/" + exec
+	}
+
+	// files not opened are closed in the command as well
+	fds := make([]uintptr, n)
+	for i := range fds {
+		fds[i] = uintptr(i)
+		if _, err := unix.FcntlInt(uintptr(i), unix.F_GETFD, 0); err != nil {
+			fds[i] = ^uintptr(0)
+		}
+	}
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, reaperSignals...)
+
+	pid, err := syscall.ForkExec(path, args, &syscall.ProcAttr{
+		Env:   os.Environ(),
+		Files: fds,
+	})
+	if err != nil {
+		return reaperFail(fmt.Errorf("exec %s: %v", args[0], err))
+	}
+	go func() {
+		for s := range sig {
+			unix.Kill(pid, s.(syscall.Signal))
+		}
+	}()
+
+	for {
+		var ws unix.WaitStatus
+		wpid, err := unix.Wait4(-1, &ws, 0, nil)
+		if err == unix.EINTR {
+			continue
+		}
+		if err != nil {
+			return reaperFail(fmt.Errorf("wait4: %v", err))
+		}
+		if wpid != pid || !(ws.Exited() || ws.Signaled()) {
+			continue
+		}
+		if ws.Signaled() {
+			signal.Stop(sig)
+			reaperRaise(ws.Signal())
+			return 128 + int(ws.Signal())
+		}
+		return ws.ExitStatus()
+	}
+}
+
+// reaperRaise terminates the reaper by the signal with its default action,
+// the go runtime handlers (e.g. SIGSEGV) are replaced by SIG_DFL directly
+func reaperRaise(sig unix.Signal) {
+	runtime.LockOSThread()
+	var act [4]uint64 // zeroed struct sigaction (SIG_DFL)
+	unix.RawSyscall6(unix.SYS_RT_SIGACTION, uintptr(sig), uintptr(unsafe.Pointer(&act)), 0, 8, 0, 0)
+	set := uint64(1) << (uint(sig) - 1)
+	unix.RawSyscall6(unix.SYS_RT_SIGPROCMASK, sigUnblock, uintptr(unsafe.Pointer(&set)), 0, 8, 0, 0)
+	unix.Tgkill(unix.Getpid(), unix.Gettid(), sig)
+}
+
+func reaperFail(err error) int {
+	fmt.Fprintln(os.Stderr, "reaper:", err)
+	return 126
+}
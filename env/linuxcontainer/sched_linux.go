@@ -0,0 +1,52 @@
+package linuxcontainer
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"golang.org/x/sys/unix"
+)
+
+// prioToWeight is the kernel scheduler weight of nice -20 to 19
+// (sched_prio_to_weight), nice 0 is 1024 as the cgroup v1 default cpu.shares
+var prioToWeight = [40]int{
+	88761, 71755, 56483, 46273, 36291,
+	29154, 23254, 18705, 14949, 11916,
+	9548, 7620, 6100, 4904, 3906,
+	3121, 2501, 1991, 1586, 1277,
+	1024, 820, 655, 526, 423,
+	335, 272, 215, 172, 137,
+	110, 87, 70, 56, 45,
+	36, 29, 23, 18, 15,
+}
+
+// setNice sets the nice value of the process before it calls execve, its
+// threads and children inherit it
+func setNice(pid, nice int) error {
+	if err := unix.Setpriority(unix.PRIO_PROCESS, pid, nice); err != nil {
+		return fmt.Errorf("setpriority: %v", err)
+	}
+	return nil
+}
+
+// setCPUWeight weights the cpu cgroup of the process by the nice value
+// (cpu.weight.nice on v2, cpu.shares on v1), it is set for every run so that
+// the pooled cgroup does not keep the weight of the previous run
+func setCPUWeight(pid int32, nice int) error {
+	if nice < -20 || nice > 19 {
+		return fmt.Errorf("cpu weight: nice %d is out of range", nice)
+	}
+	p, v2 := cgroupFilePath(pid, "cpu", "cpu.weight.nice", "cpu.shares")
+	if p == "" {
+		return nil
+	}
+	v := prioToWeight[nice+20]
+	if v2 {
+		v = nice
+	}
+	if err := os.WriteFile(p, []byte(strconv.Itoa(v)), 0644); err != nil {
+		return fmt.Errorf("cpu weight: %v", err)
+	}
+	return nil
+}
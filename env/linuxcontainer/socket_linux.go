@@ -0,0 +1,50 @@
+package linuxcontainer
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// SocketCount counts the distinct sockets held by the processes in the
+// cgroup of the process (or the process itself without cgroup) from /proc,
+// sockets shared by forked children are counted once
+func (p *process) SocketCount() (uint64, error) {
+	pid := atomic.LoadInt32(p.pid)
+	if pid == 0 {
+		return 0, fmt.Errorf("socket count: process not started")
+	}
+	pids := []string{strconv.Itoa(int(pid))}
+	if p.cg != nil {
+		p.procsOnce.Do(func() {
+			p.procsPath, _ = cgroupFilePath(pid, "pids", "cgroup.procs", "cgroup.procs")
+		})
+		if p.procsPath != "" {
+			b, err := os.ReadFile(p.procsPath)
+			if err != nil {
+				return 0, err
+			}
+			pids = strings.Fields(string(b))
+		}
+	}
+	sockets := make(map[string]bool)
+	for _, pid := range pids {
+		dir := path.Join("/proc", pid, "fd")
+		d, err := os.Open(dir)
+		if err != nil { // exited meanwhile
+			continue
+		}
+		names, _ := d.Readdirnames(-1)
+		d.Close()
+		for _, n := range names {
+			t, err := os.Readlink(path.Join(dir, n))
+			if err == nil && strings.HasPrefix(t, "socket:") {
+				sockets[t] = true
+			}
+		}
+	}
+	return uint64(len(sockets)), nil
+}
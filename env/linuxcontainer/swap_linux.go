@@ -0,0 +1,134 @@
+package linuxcontainer
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/criyle/go-judge/envexec"
+)
+
+// disableSwap limits the memory cgroup of the process to the physical memory
+// limit so that it is killed as memory limit exceeded instead of swapping
+// (memory.swap.max 0 on v2, memory.memsw.limit_in_bytes on v1). It is set for
+// every run since the pooled cgroup keeps the limit of the previous run
+func disableSwap(pid int32, memory envexec.Size) error {
+	p, v2 := cgroupFilePath(pid, "memory", "memory.swap.max", "memory.memsw.limit_in_bytes")
+	if p == "" {
+		return fmt.Errorf("disable swap: memory cgroup not found")
+	}
+	if v2 {
+		return writeSwapFile(p, "0")
+	}
+	// memsw must not be less than the memory limit, the memory limit is set
+	// again since it fails if the memsw limit of the previous run is lower
+	m := strconv.FormatUint(memory.Byte(), 10)
+	if err := writeSwapFile(p, "-1"); err != nil {
+		return err
+	}
+	if err := writeSwapFile(path.Join(path.Dir(p), "memory.limit_in_bytes"), m); err != nil {
+		return err
+	}
+	return writeSwapFile(p, m)
+}
+
+func writeSwapFile(p, v string) error {
+	if err := os.WriteFile(p, []byte(v), 0644); err != nil {
+		return fmt.Errorf("disable swap: %v", err)
+	}
+	return nil
+}
+
+// swapUsage samples the swap usage of the process cgroup (memory.swap.current
+// on v2, memory.memsw.usage_in_bytes minus memory.usage_in_bytes on v1) and
+// keeps the max sample, the paths are resolved once the process added to
+// cgroup. The v1 peaks are not used since memsw and memory peak at different
+// moments
+type swapUsage struct {
+	mu   sync.Mutex
+	dir  string
+	v2   bool
+	max  envexec.Size
+	read bool
+}
+
+func (s *swapUsage) init(pid int32) {
+	p, v2 := cgroupFilePath(pid, "memory", "memory.swap.current", "memory.memsw.usage_in_bytes")
+	if p == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dir, s.v2 = path.Dir(p), v2
+}
+
+// sample reads the current swap usage and updates the max
+func (s *swapUsage) sample() (envexec.Size, error) {
+	s.mu.Lock()
+	dir, v2 := s.dir, s.v2
+	s.mu.Unlock()
+	if dir == "" {
+		return 0, fmt.Errorf("swap usage: memory cgroup not found")
+	}
+	var (
+		u   envexec.Size
+		err error
+	)
+	if v2 {
+		u, err = readCgroupSize(path.Join(dir, "memory.swap.current"))
+	} else {
+		u, err = swapDiff(dir, "memory.memsw.usage_in_bytes", "memory.usage_in_bytes")
+	}
+	if err != nil {
+		return 0, err
+	}
+	s.update(u)
+	return u, nil
+}
+
+// peak returns the max sampled swap usage after the process exited
+func (s *swapUsage) peak() (envexec.Size, bool) {
+	s.sample()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.max, s.read
+}
+
+func (s *swapUsage) update(u envexec.Size) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if u > s.max {
+		s.max = u
+	}
+	s.read = true
+}
+
+func swapDiff(dir, memsw, memory string) (envexec.Size, error) {
+	a, err := readCgroupSize(path.Join(dir, memsw))
+	if err != nil {
+		return 0, err
+	}
+	b, err := readCgroupSize(path.Join(dir, memory))
+	if err != nil {
+		return 0, err
+	}
+	if a < b {
+		return 0, nil
+	}
+	return a - b, nil
+}
+
+func readCgroupSize(p string) (envexec.Size, error) {
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseUint(strings.TrimSpace(string(b)), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return envexec.Size(n), nil
+}
@@ -0,0 +1,149 @@
+package linuxcontainer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// syscallEvent is the perf tracepoint of entering a syscall
+type syscallEvent struct {
+	name string
+	id   uint64
+}
+
+var (
+	syscallEventsOnce sync.Once
+	syscallEvents     []syscallEvent
+	syscallEventsErr  error
+)
+
+// tracefsDirs are the possible mount points of tracefs
+var tracefsDirs = []string{"/sys/kernel/tracing", "/sys/kernel/debug/tracing"}
+
+// loadSyscallEvents lists the syscalls:sys_enter_* tracepoints once
+func loadSyscallEvents() ([]syscallEvent, error) {
+	syscallEventsOnce.Do(func() {
+		syscallEventsErr = fmt.Errorf("syscall counter: tracefs syscalls events not found")
+		for _, d := range tracefsDirs {
+			dir := filepath.Join(d, "events", "syscalls")
+			names, err := filepath.Glob(filepath.Join(dir, "sys_enter_*"))
+			if err != nil || len(names) == 0 {
+				continue
+			}
+			events := make([]syscallEvent, 0, len(names))
+			for _, n := range names {
+				b, err := os.ReadFile(filepath.Join(n, "id"))
+				if err != nil {
+					continue
+				}
+				id, err := strconv.ParseUint(strings.TrimSpace(string(b)), 10, 64)
+				if err != nil {
+					continue
+				}
+				events = append(events, syscallEvent{
+					name: strings.TrimPrefix(filepath.Base(n), "sys_enter_"),
+					id:   id,
+				})
+			}
+			if len(events) > 0 {
+				syscallEvents, syscallEventsErr = events, nil
+				return
+			}
+		}
+	})
+	return syscallEvents, syscallEventsErr
+}
+
+// syscallCounter counts the syscalls entered by the process and its children
+// by a perf tracepoint counter for each syscall, the counters are enabled
+// once the process calls execve like the instruction counter
+type syscallCounter struct {
+	mu     sync.Mutex
+	fds    map[string]int
+	opened bool
+	closed bool
+	counts map[string]uint64 // final counts after closed
+	err    error
+}
+
+// open opens the counters of the process before it calls execve
+func (c *syscallCounter) open(pid int) error {
+	events, err := loadSyscallEvents()
+	if err != nil {
+		return err
+	}
+	fds := make(map[string]int, len(events))
+	for _, e := range events {
+		attr := unix.PerfEventAttr{
+			Type:   unix.PERF_TYPE_TRACEPOINT,
+			Config: e.id,
+			Bits:   unix.PerfBitDisabled | unix.PerfBitEnableOnExec | unix.PerfBitInherit,
+		}
+		attr.Size = uint32(unsafe.Sizeof(attr))
+		fd, err := unix.PerfEventOpen(&attr, pid, -1, -1, unix.PERF_FLAG_FD_CLOEXEC)
+		if err != nil {
+			closeCounters(fds)
+			return fmt.Errorf("syscall counter: perf_event_open %s: %v", e.name, err)
+		}
+		fds[e.name] = fd
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.fds, c.opened = fds, true
+	return nil
+}
+
+// read returns the non-zero counts by syscall name, or the final counts
+// after closed
+func (c *syscallCounter) read() (map[string]uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.opened {
+		return nil, fmt.Errorf("syscall counter: process not started")
+	}
+	if c.closed {
+		return c.counts, c.err
+	}
+	return readCounters(c.fds)
+}
+
+// close reads the final counts (counts of the exited children are included)
+// and releases the counters
+func (c *syscallCounter) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.opened || c.closed {
+		return nil
+	}
+	c.counts, c.err = readCounters(c.fds)
+	c.closed = true
+	closeCounters(c.fds)
+	return c.err
+}
+
+func readCounters(fds map[string]int) (map[string]uint64, error) {
+	rt := make(map[string]uint64)
+	for name, fd := range fds {
+		n, err := readCounter(fd)
+		if err != nil {
+			return nil, fmt.Errorf("syscall counter: %s: %v", name, err)
+		}
+		if n > 0 {
+			rt[name] = n
+		}
+	}
+	return rt, nil
+}
+
+func closeCounters(fds map[string]int) {
+	for _, fd := range fds {
+		unix.Close(fd)
+	}
+}
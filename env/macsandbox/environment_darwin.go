@@ -26,10 +26,17 @@ type environment struct {
 }
 
 func (e *environment) Execve(c context.Context, param envexec.ExecveParam) (envexec.Process, error) {
+	fileSize := uint64(outputLimit)
+	if s := param.Limit.Output.Byte(); s > 0 && s < fileSize {
+		fileSize = s
+	}
+	if s := param.Limit.FileSize.Byte(); s > 0 && s < fileSize {
+		fileSize = s
+	}
 	rLimits := rlimit.RLimits{
 		CPU:      uint64(param.Limit.Time.Truncate(time.Second)/time.Second) + 1,
 		Data:     param.Limit.Memory.Byte(),
-		FileSize: outputLimit,
+		FileSize: fileSize,
 		Stack:    param.Limit.Stack.Byte(),
 	}
 
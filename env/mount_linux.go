@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"strings"
 
 	"github.com/criyle/go-sandbox/pkg/mount"
+	"golang.org/x/sys/unix"
 	"gopkg.in/yaml.v2"
 )
 
@@ -28,6 +30,31 @@ type Mounts struct {
 	UID        int     `yaml:"uid"`
 	GID        int     `yaml:"gid"`
 	Proc       bool    `yaml:"proc"`
+
+	// Devices overrides the device nodes in /dev (e.g. null, zero, urandom)
+	Devices []string `yaml:"devices"`
+
+	// Propagation overrides the mount propagation (private, slave, unbindable)
+	Propagation string `yaml:"propagation"`
+}
+
+// defaultDevices defines the minimal device set in the container /dev
+var defaultDevices = []string{"null", "zero", "random", "urandom"}
+
+// safeDevices defines device nodes allowed in the container /dev
+var safeDevices = map[string]bool{
+	"null":    true,
+	"zero":    true,
+	"full":    true,
+	"random":  true,
+	"urandom": true,
+}
+
+// propagationFlags maps the mount propagation to its mount flag
+var propagationFlags = map[string]uintptr{
+	"private":    unix.MS_PRIVATE,
+	"slave":      unix.MS_SLAVE,
+	"unbindable": unix.MS_UNBINDABLE,
 }
 
 func readMountConfig(p string) (*Mounts, error) {
@@ -90,14 +117,77 @@ func getDefaultMount(tmpFsConf string) *mount.Builder {
 		WithBind("/etc/fpc.cfg", "etc/fpc.cfg", true).
 		// mono wants /etc/mono
 		WithBind("/etc/mono", "etc/mono", true).
-		// go wants /dev/null
-		WithBind("/dev/null", "dev/null", false).
 		// ghc wants /var/lib/ghc
 		WithBind("/var/lib/ghc", "var/lib/ghc", true).
-		// javaScript wants /dev/urandom
-		WithBind("/dev/urandom", "dev/urandom", false).
 		// work dir
 		WithTmpfs("w", tmpFsConf).
 		// tmp dir
 		WithTmpfs("tmp", tmpFsConf)
 }
+
+// zoneinfoDir is the host timezone database
+const zoneinfoDir = "/usr/share/zoneinfo"
+
+// zoneinfoPath resolves the timezone name (e.g. Asia/Shanghai) to its host
+// zoneinfo file
+func zoneinfoPath(tz string) (string, error) {
+	p := path.Clean(path.Join(zoneinfoDir, tz))
+	if path.IsAbs(tz) || !strings.HasPrefix(p, zoneinfoDir+"/") {
+		return "", fmt.Errorf("timezone %s is not valid", tz)
+	}
+	fi, err := os.Stat(p)
+	if err != nil {
+		return "", err
+	}
+	if !fi.Mode().IsRegular() {
+		return "", fmt.Errorf("timezone %s is not a zoneinfo file", tz)
+	}
+	return p, nil
+}
+
+// withDevices binds the device nodes into the container /dev (go wants
+// /dev/null, javaScript wants /dev/urandom), devices already mounted are
+// skipped
+func withDevices(b *mount.Builder, devices []string) error {
+	mounted := make(map[string]bool)
+	for _, m := range b.Mounts {
+		mounted[m.Target] = true
+	}
+	for _, d := range devices {
+		d = strings.TrimPrefix(strings.TrimSpace(d), "/dev/")
+		if d == "" {
+			continue
+		}
+		if !safeDevices[d] {
+			return fmt.Errorf("device /dev/%s is not allowed", d)
+		}
+		target := path.Join("dev", d)
+		if mounted[target] {
+			continue
+		}
+		mounted[target] = true
+		b.WithBind(path.Join("/dev", d), target, false)
+	}
+	return nil
+}
+
+// withPropagation sets the propagation of the container root recursively
+// (including the work dir) after all mounts, so that mount events inside the
+// sandbox never propagate to the host, empty keeps the inherited propagation.
+// The container init performs the mounts in the container root thus "." is
+// the root tmpfs
+func withPropagation(b *mount.Builder, p string) error {
+	if p == "" {
+		return nil
+	}
+	flag, ok := propagationFlags[p]
+	if !ok {
+		return fmt.Errorf("mount propagation %s is not valid (private, slave, unbindable)", p)
+	}
+	b.Mounts = append(b.Mounts, mount.Mount{
+		Source: "none",
+		Target: ".",
+		Flags:  unix.MS_REC | flag,
+	})
+	return nil
+}
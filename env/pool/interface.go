@@ -1,11 +1,16 @@
 package pool
 
 import (
+	"fmt"
 	"sync"
+	"time"
 
 	"github.com/criyle/go-judge/envexec"
 )
 
+// idleCheckInterval defines how often the idle environments are checked
+const idleCheckInterval = time.Second
+
 // Environment defines envexec.Environment with destroy
 type Environment interface {
 	envexec.Environment
@@ -13,16 +18,67 @@ type Environment interface {
 	Destroy() error
 }
 
+// ResetVerifier is optionally implemented by Environment to verify the
+// environment is clean after reset (e.g. the work dir is empty)
+type ResetVerifier interface {
+	VerifyReset() error
+}
+
+// ResetCheck defines the check of the environment put back to the pool, the
+// environment failed to reset (or verify) is destroyed instead of pooled
+type ResetCheck struct {
+	// Verify verifies the environment by ResetVerifier after reset
+	Verify bool
+
+	// OnFailed is called once the environment is destroyed (optional)
+	OnFailed func(error)
+}
+
 // EnvBuilder defines the abstract builder for container environment
 type EnvBuilder interface {
 	Build() (Environment, error)
 }
 
+// BuilderShutdowner is optionally implemented by EnvBuilder to release its
+// resources once the pool shut down (e.g. the container init launcher)
+type BuilderShutdowner interface {
+	Shutdown()
+}
+
+// GroupsSupporter is optionally implemented by EnvBuilder whose environments
+// set the supplementary groups of the cmd (e.g. gVisor)
+type GroupsSupporter interface {
+	SupportsGroups() bool
+}
+
+// CapabilitiesSupporter is optionally implemented by EnvBuilder whose
+// environments retain the capabilities of the cmd (e.g. gVisor)
+type CapabilitiesSupporter interface {
+	SupportsCapabilities() bool
+}
+
+// IdlePool defines environment pool that destroys idle environments
+type IdlePool interface {
+	envexec.EnvironmentPool
+	Shutdown()
+}
+
+type idleEnv struct {
+	Environment
+	lastUsed time.Time
+}
+
 type pool struct {
 	builder EnvBuilder
 
-	env []Environment
+	env []idleEnv // stack with the least recently used at bottom
 	mu  sync.Mutex
+
+	idleTimeout time.Duration
+	minIdle     int
+	check       ResetCheck
+	stopOnce    sync.Once
+	done        chan struct{}
 }
 
 // NewPool returns a pool for EnvBuilder
@@ -32,6 +88,23 @@ func NewPool(builder EnvBuilder) envexec.EnvironmentPool {
 	}
 }
 
+// NewIdlePool returns a pool for EnvBuilder that destroys environments not
+// reused within idleTimeout while keeping at least minIdle environments, the
+// environments put back are checked by check
+func NewIdlePool(builder EnvBuilder, idleTimeout time.Duration, minIdle int, check ResetCheck) IdlePool {
+	p := &pool{
+		builder:     builder,
+		idleTimeout: idleTimeout,
+		minIdle:     minIdle,
+		check:       check,
+		done:        make(chan struct{}),
+	}
+	if idleTimeout > 0 {
+		go p.loop()
+	}
+	return p
+}
+
 func (p *pool) Get() (envexec.Environment, error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -39,17 +112,105 @@ func (p *pool) Get() (envexec.Environment, error) {
 	if len(p.env) > 0 {
 		rt := p.env[len(p.env)-1]
 		p.env = p.env[:len(p.env)-1]
-		return rt, nil
+		return rt.Environment, nil
 	}
 	return p.builder.Build()
 }
 
+// GetWarm returns the most recently used pooled environment without building
+// a new one
+func (p *pool) GetWarm() (envexec.Environment, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.env) == 0 {
+		return nil, false
+	}
+	rt := p.env[len(p.env)-1]
+	p.env = p.env[:len(p.env)-1]
+	return rt.Environment, true
+}
+
 func (p *pool) Put(env envexec.Environment) {
 	e, _ := env.(Environment)
-	e.Reset()
+	if err := p.reset(e); err != nil {
+		e.Destroy()
+		if p.check.OnFailed != nil {
+			p.check.OnFailed(err)
+		}
+		return
+	}
 
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	p.env = append(p.env, e)
+	p.env = append(p.env, idleEnv{Environment: e, lastUsed: time.Now()})
+}
+
+// reset resets the environment and verifies it if requested
+func (p *pool) reset(e Environment) error {
+	if err := e.Reset(); err != nil {
+		return fmt.Errorf("reset: %v", err)
+	}
+	if v, ok := e.(ResetVerifier); ok && p.check.Verify {
+		if err := v.VerifyReset(); err != nil {
+			return fmt.Errorf("verify reset: %v", err)
+		}
+	}
+	return nil
+}
+
+// Discard destroys the environment instead of putting it back (e.g. the
+// program inside is not reaped)
+func (p *pool) Discard(env envexec.Environment) {
+	if e, ok := env.(Environment); ok {
+		e.Destroy()
+	}
+}
+
+func (p *pool) Shutdown() {
+	p.stopOnce.Do(func() {
+		close(p.done)
+		if s, ok := p.builder.(BuilderShutdowner); ok {
+			s.Shutdown()
+		}
+	})
+}
+
+func (p *pool) loop() {
+	ticker := time.NewTicker(idleCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.done:
+			return
+
+		case now := <-ticker.C:
+			for _, e := range p.removeIdle(now) {
+				e.Destroy()
+			}
+		}
+	}
+}
+
+// removeIdle removes the environments idled over the timeout from the bottom
+// of the stack and keeps at least minIdle environments
+func (p *pool) removeIdle(now time.Time) []Environment {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	n := 0
+	for n < len(p.env)-p.minIdle && now.Sub(p.env[n].lastUsed) > p.idleTimeout {
+		n++
+	}
+	if n == 0 {
+		return nil
+	}
+	rt := make([]Environment, 0, n)
+	for _, e := range p.env[:n] {
+		rt = append(rt, e.Environment)
+	}
+	p.env = append(p.env[:0], p.env[n:]...)
+	return rt
 }
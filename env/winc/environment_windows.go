@@ -9,6 +9,7 @@ import (
 	"os"
 	"path"
 	"syscall"
+	"time"
 	"unicode/utf16"
 	"unsafe"
 
@@ -169,6 +170,7 @@ func (e *Environment) Execve(ctx context.Context, param envexec.ExecveParam) (pr
 	if _, err := windows.ResumeThread(windows.Handle(processInfo.Thread)); err != nil {
 		return nil, err
 	}
+	sTime := time.Now()
 	syscall.CloseHandle(processInfo.Thread)
 
 	done := make(chan struct{})
@@ -213,6 +215,7 @@ func (e *Environment) Execve(ctx context.Context, param envexec.ExecveParam) (pr
 			}
 			switch qty {
 			case JOB_OBJECT_MSG_ACTIVE_PROCESS_ZERO:
+				result.RunningTime = time.Since(sTime)
 				break loop
 
 			case JOB_OBJECT_MSG_END_OF_JOB_TIME, JOB_OBJECT_MSG_END_OF_PROCESS_TIME:
@@ -0,0 +1,66 @@
+package envexec
+
+import (
+	"bytes"
+	"sync"
+)
+
+// BufferPool reuses buffers to collect pipe outputs
+type BufferPool interface {
+	Get() *bytes.Buffer
+	Put(*bytes.Buffer)
+}
+
+type bufferPool struct {
+	pool    sync.Pool
+	maxSize int
+}
+
+// NewBufferPool creates pool for buffers, buffers grown larger than maxSize
+// are not reused to avoid pinning large memory
+func NewBufferPool(maxSize int) BufferPool {
+	return &bufferPool{
+		pool: sync.Pool{
+			New: func() interface{} {
+				return new(bytes.Buffer)
+			},
+		},
+		maxSize: maxSize,
+	}
+}
+
+func (p *bufferPool) Get() *bytes.Buffer {
+	b := p.pool.Get().(*bytes.Buffer)
+	b.Reset()
+	return b
+}
+
+func (p *bufferPool) Put(b *bytes.Buffer) {
+	if b.Cap() > p.maxSize {
+		return
+	}
+	p.pool.Put(b)
+}
+
+func newBuffer(p BufferPool) *bytes.Buffer {
+	if p == nil {
+		return new(bytes.Buffer)
+	}
+	return p.Get()
+}
+
+// bufferBytes returns the first n bytes of the buffer, if the pool presents,
+// the bytes are copied and the buffer is put back to the pool
+func bufferBytes(p BufferPool, b *bytes.Buffer, n int) []byte {
+	c := b.Bytes()
+	if n < len(c) {
+		c = c[:n]
+	}
+	if p == nil {
+		return c
+	}
+	rt := make([]byte, len(c))
+	copy(rt, c)
+	p.Put(b)
+	return rt
+}
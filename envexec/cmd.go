@@ -2,6 +2,7 @@ package envexec
 
 import (
 	"context"
+	"os"
 	"time"
 
 	"github.com/criyle/go-judge/file"
@@ -28,6 +29,11 @@ type Cmd struct {
 	Files []interface{}
 	TTY   bool // use pty as input / output
 
+	// StdinTee collects the input delivered to the program stdin as a copy out
+	// file within the size limit (optional, not for TTY), the stdin is fed
+	// over a pipe
+	StdinTee *PipeCollector
+
 	// resource limits
 	TimeLimit         time.Duration
 	MemoryLimit       Size
@@ -38,9 +44,118 @@ type Cmd struct {
 	CPURateLimit      float64
 	StrictMemoryLimit bool
 
+	// MemorySoftLimit defines a memory threshold that reports MemoryWarning
+	// instead of failing the program, when memory exceeded it (throttled over
+	// it if MemorySoftReporter, 0 disables)
+	MemorySoftLimit Size
+
+	// SyscallRateLimit defines the max syscalls per second sampled by the
+	// waiter from the syscall counter (e.g. sched_yield / nanosleep loop), 0
+	// disables. Exceeded rate is reported as warning and kills the program if
+	// KillOnSyscallRate
+	SyscallRateLimit  uint64
+	KillOnSyscallRate bool
+
+	// WriteLimit defines the max cumulative bytes written sampled by the
+	// waiter, the program is killed once exceeded (0 disables)
+	WriteLimit Size
+
+	// FileLimit defines the max number of files created in the work dir file
+	// system (inodes) sampled by the waiter, the creation over it fails if the
+	// environment is FileCountLimiter, killed once exceeded otherwise (0
+	// disables)
+	FileLimit uint64
+
+	// SocketLimit defines the max number of sockets held by the program and
+	// its children sampled by the waiter, the peak is reported and the
+	// program is killed once exceeded (0 disables)
+	SocketLimit uint64
+
+	// OpenFileLimit defines the max file descriptor number of each process
+	// (RLIMIT_NOFILE), it bounds the sockets opened between samples (0 keeps
+	// the default)
+	OpenFileLimit uint64
+
+	// NprocLimit defines the max number of processes / threads of the
+	// sandbox user (RLIMIT_NPROC) checked by the kernel on fork / clone
+	// besides the cgroup ProcLimit (0 disables)
+	NprocLimit uint64
+
+	// FreshCgroup requests a newly built cgroup instead of the pooled one
+	// (reset after each run) for isolation at cost of cgroup set up time
+	FreshCgroup bool
+
+	// FileSizeLimit defines the max size of a single file written by the
+	// program (RLIMIT_FSIZE), exceeded write gets SIGXFSZ / EFBIG from the
+	// kernel and reported as FileSizeLimitExceeded (0 disables)
+	FileSizeLimit Size
+
+	// EarlyResultQuietPeriod terminates the program once all its pipe
+	// collectors closed (the program closed the outputs without exiting) and
+	// the quiet period passed, the run is reported as exited (0 disables)
+	EarlyResultQuietPeriod time.Duration
+
+	// SignalStatus maps the terminating signal to the status if the program
+	// was signalled on its own (not killed by limits or cancel), e.g. SIGSEGV
+	// to SegmentationFault or SIGKILL (by OOM killer) to MemoryLimitExceeded
+	SignalStatus map[int]Status
+
+	// Devices specifies device nodes (e.g. nvidia0) accessible to the program
+	// besides the defaults, the environment must be configured to expose them
+	Devices []string
+
+	// Groups specifies the supplementary group IDs of the program (e.g.
+	// access group readable files), rejected by the environment not setting
+	// them (e.g. the native container)
+	Groups []int
+
+	// Capabilities specifies the capabilities retained by the program (e.g.
+	// CAP_NET_BIND_SERVICE), rejected by the environment dropping them
+	Capabilities []string
+
+	// ReapDeadline defines the max time to wait the program to be reaped after
+	// it is killed, the environment is destroyed instead of reused if
+	// exceeded (0 waits until reaped)
+	ReapDeadline time.Duration
+
+	// MemoryAccounting selects the memory reported and compared with the
+	// memory limit, either the cgroup peak (default) or current at exit
+	MemoryAccounting MemoryAccounting
+
+	// TimeAccounting selects the cpu time reported and compared with the
+	// time limit, either the cgroup aggregate (default) or the process itself
+	TimeAccounting TimeAccounting
+
+	// InstructionLimit defines the max user space instructions retired by
+	// the program sampled by the waiter, the program is killed once exceeded
+	// and reported as InstructionLimitExceeded by the final count (0 disables)
+	InstructionLimit uint64
+
+	// Nice is the scheduling nice value of the program (-20 highest to 19
+	// lowest priority, 0 default), it also weights the cpu cgroup (if enabled)
+	// so that lower priority runs yield cpu when the host is saturated
+	Nice int
+
+	// SampleOpenFiles samples the open files of the program by the waiter
+	// and right before killed for diagnosis, the last sample is reported
+	SampleOpenFiles bool
+
+	// CountSyscalls counts the syscalls entered by the program and its
+	// children by name for diagnosis (e.g. crafting seccomp profiles)
+	CountSyscalls bool
+
+	// ReportCgroup reports the cgroup version and controllers in effect for
+	// the program by CgroupInfo for diagnosis
+	ReportCgroup bool
+
 	// file contents to copyin before exec
 	CopyIn map[string]file.File
 
+	// CleanWorkDir removes the contents of the work dir before copy in so
+	// that the files left by the previous run of a reused environment are
+	// not visible, otherwise existing files are only overwritten by copy in
+	CleanWorkDir bool
+
 	// file names to copyout after exec
 	CopyOut    []string
 	CopyOutMax Size // file size limit
@@ -48,16 +163,210 @@ type Cmd struct {
 	// CopyOutDir specifies a dir to dump all /w contnet
 	CopyOutDir string
 
+	// CopyOutDirs collects regular files inside dirs as copy out files
+	CopyOutDirs []CopyOutDirSpec
+
+	// CopyOutFileLimit defines the max number of copy out files including
+	// the files inside copy out dirs, exceeded fails as file error before
+	// any file read (0 unlimited)
+	CopyOutFileLimit int
+
+	// CopyOutPartial omits the copy out files exceeded CopyOutMax (reported
+	// by OmittedFiles with their sizes) instead of failing the program, the
+	// other files are still collected
+	CopyOutPartial bool
+
+	// CopyOutTotalMax defines the max total size of the collected copy out
+	// files, exceeded fails as file error (0 unlimited), the pipe collectors
+	// are accounted by their own limits unless CopyOutIncludePipes
+	CopyOutTotalMax     Size
+	CopyOutIncludePipes bool
+
+	// CopyOutCollision decides the collected file whose name collides with
+	// another one (e.g. copy out dir file with copy out), compared case
+	// insensitively if CopyOutCaseInsensitive
+	CopyOutCollision       CollisionPolicy
+	CopyOutCaseInsensitive bool
+
+	// PreserveDir specifies a dir to dump all /w content if the program is
+	// not accepted (for inspection), not dumped if over PreserveMax (0
+	// unlimited)
+	PreserveDir string
+	PreserveMax Size
+
+	// PostExec runs in the same environment after the cmd exited regardless
+	// of its status (e.g. teardown), after copy out files collected, it is
+	// bounded by PostExecTimeLimit (default 1s)
+	PostExec          []string
+	PostExecTimeLimit time.Duration
+
 	// Waiter is called after cmd starts and it should return
 	// once time limit exceeded.
 	// return true to as TLE and false as normal exits (context finished)
 	Waiter func(context.Context, Process) bool
+
+	// KillSignal is sent to the program once the waiter reports time limit
+	// exceeded (optional), then it is killed after KillGracePeriod
+	// (default 1s) if not exited
+	KillSignal      os.Signal
+	KillGracePeriod time.Duration
+
+	// BufferPool reuses buffers to collect pipe outputs (optional)
+	BufferPool BufferPool
+
+	// StageObserver receives the run stages of the cmd (optional), it may be
+	// called from other goroutines
+	StageObserver func(Stage)
 }
 
-// PipeCollector can be used in Cmd.Files paramenter
+// CopyOutDirSpec defines a dir inside the container to collect its regular
+// files, collected files are named as Prefix + file name
+type CopyOutDirSpec struct {
+	Src    string
+	Prefix string
+}
+
+// PipeCollector can be used in Cmd.Files paramenter, each collector has its
+// own size limit and policy when the limit exceeded (collectors with the same
+// name share the pipe and the limit of the first one)
 type PipeCollector struct {
 	Name      string
 	SizeLimit int64
+	Policy    CollectorPolicy
+
+	// Backpressure stops reading the pipe at SizeLimit, the pipe is closed
+	// once the program writes past the limit so that its writes fail with
+	// EPIPE (SIGPIPE by default) instead of being drained and discarded, the
+	// collector holds at most SizeLimit bytes (ignored with TTY)
+	Backpressure bool
+}
+
+// CollectorPolicy defines the action when the pipe collector exceeded its size
+// limit, the collected output is truncated to the limit in all cases
+type CollectorPolicy int
+
+// Defines collector policies
+const (
+	CollectorPolicyFail     CollectorPolicy = iota // output limit exceeded after the program exited
+	CollectorPolicyTruncate                        // not fails the program
+	CollectorPolicyKill                            // kills the program as output limit exceeded
+)
+
+var collectorPolicyToString = []string{
+	"fail",
+	"truncate",
+	"kill",
+}
+
+func (p CollectorPolicy) String() string {
+	pi := int(p)
+	if pi < 0 || pi >= len(collectorPolicyToString) {
+		return collectorPolicyToString[0]
+	}
+	return collectorPolicyToString[pi]
+}
+
+// CollisionPolicy defines the action when the copy out file names collide,
+// files are resolved in order of copy out, copy out dirs and pipe collectors
+type CollisionPolicy int
+
+// Defines collision policies
+const (
+	CollisionPolicyOverwrite CollisionPolicy = iota // the last one is kept
+	CollisionPolicyError                            // fails as file error
+	CollisionPolicyKeepFirst                        // the first one is kept
+	CollisionPolicyRename                           // renamed with suffix (e.g. out_1.txt)
+)
+
+var collisionPolicyToString = []string{
+	"overwrite",
+	"error",
+	"keep-first",
+	"rename",
+}
+
+func (p CollisionPolicy) String() string {
+	pi := int(p)
+	if pi < 0 || pi >= len(collisionPolicyToString) {
+		return collisionPolicyToString[0]
+	}
+	return collisionPolicyToString[pi]
+}
+
+// MemoryAccounting defines how the memory usage of the program is accounted
+type MemoryAccounting int
+
+// Defines memory accountings
+const (
+	MemoryAccountingPeak    MemoryAccounting = iota // peak memory during the run
+	MemoryAccountingCurrent                         // current memory sampled last before exit
+)
+
+var memoryAccountingToString = []string{
+	"peak",
+	"current",
+}
+
+func (a MemoryAccounting) String() string {
+	ai := int(a)
+	if ai < 0 || ai >= len(memoryAccountingToString) {
+		return memoryAccountingToString[0]
+	}
+	return memoryAccountingToString[ai]
+}
+
+// TimeAccounting defines how the cpu time of the program is accounted
+type TimeAccounting int
+
+// Defines time accountings
+const (
+	TimeAccountingCgroup  TimeAccounting = iota // cgroup cpu usage of all processes and threads
+	TimeAccountingProcess                       // user cpu time of the process (rusage with its threads and waited children)
+)
+
+var timeAccountingToString = []string{
+	"cgroup",
+	"process",
+}
+
+func (a TimeAccounting) String() string {
+	ai := int(a)
+	if ai < 0 || ai >= len(timeAccountingToString) {
+		return timeAccountingToString[0]
+	}
+	return timeAccountingToString[ai]
+}
+
+// CgroupInfo describes the cgroup the program was placed in, Version is 0 and
+// Controllers is empty if the program ran without cgroup (rlimit / rusage)
+type CgroupInfo struct {
+	Version     int      // cgroup version (1 or 2)
+	Controllers []string // controllers set up for the program (e.g. memory, pids)
+}
+
+// ProcLimitHit defines which process count limit failed the process / thread
+// creation of the program
+type ProcLimitHit int
+
+// Defines process count limits
+const (
+	ProcLimitHitNone  ProcLimitHit = iota // no creation failed by the limits
+	ProcLimitHitPids                      // cgroup pids limit (ProcLimit)
+	ProcLimitHitNproc                     // RLIMIT_NPROC (NprocLimit)
+)
+
+var procLimitHitToString = []string{
+	"",
+	"pids",
+	"nproc",
+}
+
+func (h ProcLimitHit) String() string {
+	hi := int(h)
+	if hi < 0 || hi >= len(procLimitHitToString) {
+		return procLimitHitToString[0]
+	}
+	return procLimitHitToString[hi]
 }
 
 // Result defines the running result for single Cmd
@@ -68,10 +377,105 @@ type Result struct {
 
 	Error string // error
 
-	Time    time.Duration
-	RunTime time.Duration
-	Memory  Size // byte
+	Time    time.Duration // cpu time
+	RunTime time.Duration // wall clock time from exec to exit
+	Memory  Size          // byte
+
+	// MemoryPeak is the raw peak memory if Memory is an estimate excluding
+	// page cache or the current memory at exit (0 otherwise)
+	MemoryPeak Size
+
+	// SwapUsage is the max sampled swap usage of the cgroup if swap is allowed and
+	// accounted (0 otherwise)
+	SwapUsage Size
+
+	// Diagnosis is the diagnosis of the environment if it failed to set up
+	// the exec (e.g. the stderr of the container init)
+	Diagnosis string
+
+	// EnvironmentTime is the time spent to get the environment from pool
+	EnvironmentTime time.Duration
+
+	// CgroupTime is the time spent to get and set up cgroup (if presents)
+	CgroupTime time.Duration
+
+	// ExitReason reports why the program exited
+	ExitReason ExitReason
+
+	// UserTime / SystemTime split the cpu time (if presents)
+	UserTime   time.Duration
+	SystemTime time.Duration
+
+	// ProcPeak is the peak number of processes / threads sampled during
+	// the run (0 if not available)
+	ProcPeak uint64
+
+	// SocketPeak is the peak number of sockets sampled during the run (only
+	// with SocketLimit)
+	SocketPeak uint64
+
+	// ProcLimitHit reports which process count limit failed the process /
+	// thread creation, pids is counted by the cgroup (pids.events) and nproc
+	// is estimated as the sampled peak reached NprocLimit
+	ProcLimitHit ProcLimitHit
+
+	// Instructions is the number of user space instructions retired by the
+	// program (only with InstructionLimit)
+	Instructions uint64
+
+	// CopyOutBytes and PipeBytes are the total size of the collected copy
+	// out files and pipe collector outputs
+	CopyOutBytes Size
+	PipeBytes    Size
+
+	// MemoryWarning is true if memory exceeded MemorySoftLimit without
+	// MemoryLimitExceeded
+	MemoryWarning bool
+
+	// Warning reports abnormal behavior that not fails the program
+	Warning string
+
+	// Preserved is true if the work dir dumped into PreserveDir
+	Preserved bool
+
+	// EarlyResult is true if the program was terminated after it closed the
+	// outputs with EarlyResultQuietPeriod
+	EarlyResult bool
+
+	// ReapTimeout is true if the program was not reaped within ReapDeadline
+	// after killed (hang on exit), its environment is destroyed
+	ReapTimeout bool
+
+	// ClockBudgetExceeded is true if the program was killed since the clock
+	// budget of the group exceeded
+	ClockBudgetExceeded bool
+
+	// OpenFiles is the last sampled open file descriptors with targets of
+	// the program (e.g. "3 -> /w/a.txt") with SampleOpenFiles, nil if not
+	// sampled before it exited
+	OpenFiles []string
+
+	// SyscallCounts is the number of syscalls entered by the program by
+	// syscall name with CountSyscalls (zero counts are omitted)
+	SyscallCounts map[string]uint64
+
+	// CgroupInfo is the cgroup in effect for the program with ReportCgroup,
+	// nil if not reported by the environment
+	CgroupInfo *CgroupInfo
+
+	// PostExec is the result of the post exec cmd (if presents), it does not
+	// change the status of the cmd
+	PostExec *PostExecResult
 
 	// Files stores copy out files
 	Files map[string]file.File
+
+	// OmittedFiles are the copy out files exceeded the size limit with their
+	// sizes (CopyOutPartial), non-empty if the collection is partial
+	OmittedFiles map[string]Size
+
+	// TruncatedFiles are the pipe collectors exceeded the size limit with the
+	// bytes read from the pipe (collected and discarded), the program wrote
+	// at least that many bytes
+	TruncatedFiles map[string]Size
 }
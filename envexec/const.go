@@ -1,5 +1,9 @@
 package envexec
 
+import "time"
+
 const (
 	defaultExtraMemoryLimit = Size(16 << 10) // 16k more memory
+	defaultKillGracePeriod  = time.Second    // wait before kill after kill signal sent
+	copyOutParallelism      = 8              // max files read in parallel for copy out
 )
@@ -0,0 +1,83 @@
+package envexec
+
+import (
+	"context"
+	"syscall"
+
+	"github.com/criyle/go-sandbox/runner"
+)
+
+// ExitReason defines why the program exited
+type ExitReason int
+
+// Defines exit reasons
+const (
+	// not exited (e.g. failed to start)
+	ExitReasonInvalid ExitReason = iota
+
+	// exited on its own (with any exit status)
+	ExitReasonNormal
+
+	// killed by its own time / memory limit
+	ExitReasonTimeLimit
+	ExitReasonMemoryLimit
+
+	// killed by other limits (e.g. output, write, file or syscall rate)
+	ExitReasonOtherLimit
+
+	// killed by SIGPIPE since the peer closed the pipe (e.g. interactor
+	// finished)
+	ExitReasonKilledByPeer
+
+	// killed since the request was cancelled
+	ExitReasonCancelled
+
+	// killed by other signals (e.g. SIGSEGV, seccomp)
+	ExitReasonSignalled
+)
+
+var exitReasonToString = []string{
+	"",
+	"Normal",
+	"TimeLimit",
+	"MemoryLimit",
+	"OtherLimit",
+	"KilledByPeer",
+	"Cancelled",
+	"Signalled",
+}
+
+func (r ExitReason) String() string {
+	ri := int(r)
+	if ri < 0 || ri >= len(exitReasonToString) {
+		return exitReasonToString[0]
+	}
+	return exitReasonToString[ri]
+}
+
+// exitReason decides why the program exited by the final status and the
+// runner result, kill by cancel is reported as time limit exceeded by runner
+func exitReason(pc context.Context, rt runner.Result, s Status, timedOut bool) ExitReason {
+	switch {
+	case rt.Status == runner.StatusRunnerError:
+		return ExitReasonInvalid
+	case pc.Err() != nil && !timedOut && rt.Status != runner.StatusNormal &&
+		rt.Status != runner.StatusNonzeroExitStatus:
+		return ExitReasonCancelled
+	case s == StatusTimeLimitExceeded:
+		return ExitReasonTimeLimit
+	case s == StatusMemoryLimitExceeded:
+		return ExitReasonMemoryLimit
+	case s == StatusOutputLimitExceeded || s == StatusFileSizeLimitExceeded ||
+		s == StatusWriteLimitExceeded || s == StatusFileLimitExceeded ||
+		s == StatusSyscallRateExceeded || s == StatusInstructionLimitExceeded ||
+		s == StatusSocketLimitExceeded:
+		return ExitReasonOtherLimit
+	case rt.Status == runner.StatusSignalled && rt.ExitStatus == int(syscall.SIGPIPE):
+		return ExitReasonKilledByPeer
+	case rt.Status == runner.StatusNormal || rt.Status == runner.StatusNonzeroExitStatus:
+		return ExitReasonNormal
+	default:
+		return ExitReasonSignalled
+	}
+}
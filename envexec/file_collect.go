@@ -1,10 +1,14 @@
 package envexec
 
 import (
-	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/criyle/go-judge/file"
@@ -12,64 +16,58 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
-// copyOutAndCollect reads file and pipes in parallel from container
-func copyOutAndCollect(m Environment, c *Cmd, ptc []pipeCollector) (map[string]file.File, error) {
+// copyOutUsage is the total size of the collected copy out files and pipes
+type copyOutUsage struct {
+	files, pipes Size
+}
+
+// copyOutAndCollect reads file and pipes in parallel from container, the
+// copy out files exceeded CopyOutMax are returned as omitted with their sizes
+// if CopyOutPartial
+func copyOutAndCollect(m Environment, c *Cmd, ptc []pipeCollector) (map[string]file.File, map[string]Size, copyOutUsage, error) {
 	var (
 		g errgroup.Group
 		l sync.Mutex
 	)
-	rt := make(map[string]file.File)
-	put := func(f file.File) {
+	var fs []collectedFile
+	put := func(f collectedFile) {
+		l.Lock()
+		defer l.Unlock()
+		fs = append(fs, f)
+	}
+	var omitted map[string]Size
+	omit := func(name string, size Size) {
 		l.Lock()
 		defer l.Unlock()
-		rt[f.Name()] = f
+		if omitted == nil {
+			omitted = make(map[string]Size)
+		}
+		omitted[name] = size
 	}
 
-	// copy out
-	for _, n := range c.CopyOut {
-		n := n
+	// copy out and copy out dirs
+	if len(c.CopyOut) > 0 || len(c.CopyOutDirs) > 0 {
 		g.Go(func() error {
-			cf, err := m.Open(n, os.O_RDONLY, 0777)
-			if err != nil {
-				return err
-			}
-			defer cf.Close()
-
-			stat, err := cf.Stat()
+			jobs, err := copyOutJobs(m, c)
 			if err != nil {
 				return err
 			}
-			// check regular file
-			if stat.Mode()&os.ModeType != 0 {
-				return fmt.Errorf("File(%s) is not a regular file %d", n, stat.Mode()&os.ModeType)
-			}
-			// check size limit
-			s := stat.Size()
-			if c.CopyOutMax > 0 && s > int64(c.CopyOutMax) {
-				return fmt.Errorf("File(%s) have size (%d) exceeded the limit (%d)", n, s, c.CopyOutMax)
-			}
-			var buf bytes.Buffer
-			buf.Grow(int(s))
-
-			// Ensure not copy over file size
-			_, err = buf.ReadFrom(io.LimitReader(cf, s))
-			if err != nil {
-				return err
-			}
-			put(file.NewMemFile(n, buf.Bytes()))
-			return nil
+			return runCopyOutJobs(m, c, jobs, put, omit)
 		})
 	}
 
 	// collect pipe
-	for _, p := range ptc {
-		p := p
+	for i, p := range ptc {
+		i, p := i, p
 		g.Go(func() error {
 			<-p.buff.Done
-			if int64(p.buff.Buffer.Len()) > p.buff.Max {
+			// keep the partial output (up to the limit) even if the limit exceeded
+			exceeded := p.exceeded()
+			b := bufferBytes(c.BufferPool, p.buff.Buffer, int(p.buff.Max))
+			put(collectedFile{order: [3]int{2, i}, name: p.name, content: b})
+			if exceeded && p.policy != CollectorPolicyTruncate {
 				return runner.StatusOutputLimitExceeded
 			}
-			put(file.NewMemFile(p.name, p.buff.Buffer.Bytes()))
 			return nil
 		})
 	}
@@ -77,10 +75,239 @@ func copyOutAndCollect(m Environment, c *Cmd, ptc []pipeCollector) (map[string]f
 	// copy out dir
 	if c.CopyOutDir != "" {
 		g.Go(func() error {
-			return copyDir(m.WorkDir(), c.CopyOutDir)
+			return copyDir(m.WorkDir(), c.CopyOutDir, 0)
 		})
 	}
 
 	err := g.Wait()
-	return rt, err
+	u := collectedUsage(fs)
+	if err == nil {
+		err = checkCopyOutTotal(c, u)
+	}
+	rt, cerr := collectFiles(c, fs)
+	if err == nil {
+		err = cerr
+	}
+	return rt, omitted, u, err
+}
+
+// collectedUsage sums the collected files by copy out files and pipes
+func collectedUsage(fs []collectedFile) copyOutUsage {
+	var u copyOutUsage
+	for _, f := range fs {
+		if f.order[0] == 2 {
+			u.pipes += Size(len(f.content))
+		} else {
+			u.files += Size(len(f.content))
+		}
+	}
+	return u
+}
+
+// checkCopyOutTotal checks the usage against the total copy out limit
+func checkCopyOutTotal(c *Cmd, u copyOutUsage) error {
+	if c.CopyOutTotalMax <= 0 {
+		return nil
+	}
+	n := u.files
+	if c.CopyOutIncludePipes {
+		n += u.pipes
+	}
+	if n > c.CopyOutTotalMax {
+		return fmt.Errorf("copy out: total size (%d) exceeded the limit (%d)", n, c.CopyOutTotalMax)
+	}
+	return nil
+}
+
+// copyOutJob is the file to read from the container
+type copyOutJob struct {
+	order [3]int
+	src   string
+	name  string
+}
+
+// copyOutJobs lists the copy out files including the files inside copy out
+// dirs, it fails if the number of files exceeded the limit of the cmd
+func copyOutJobs(m Environment, c *Cmd) ([]copyOutJob, error) {
+	jobs := make([]copyOutJob, 0, len(c.CopyOut))
+	for i, n := range c.CopyOut {
+		jobs = append(jobs, copyOutJob{order: [3]int{0, i}, src: n, name: n})
+	}
+	for i, d := range c.CopyOutDirs {
+		names, err := copyOutDirNames(m, d.Src)
+		if err != nil {
+			return nil, err
+		}
+		for j, n := range names {
+			jobs = append(jobs, copyOutJob{order: [3]int{1, i, j}, src: path.Join(d.Src, n), name: d.Prefix + n})
+		}
+		if c.CopyOutFileLimit > 0 && len(jobs) > c.CopyOutFileLimit {
+			break
+		}
+	}
+	if c.CopyOutFileLimit > 0 && len(jobs) > c.CopyOutFileLimit {
+		return nil, fmt.Errorf("copy out: number of files exceeded the limit (%d)", c.CopyOutFileLimit)
+	}
+	return jobs, nil
+}
+
+// runCopyOutJobs reads the copy out files by a bounded number of workers
+func runCopyOutJobs(m Environment, c *Cmd, jobs []copyOutJob, put func(collectedFile), omit func(string, Size)) error {
+	ch := make(chan copyOutJob)
+	var g errgroup.Group
+	for i := 0; i < copyOutParallelism && i < len(jobs); i++ {
+		g.Go(func() error {
+			var rt error
+			for j := range ch {
+				b, err := copyOutFile(m, j.src, c.CopyOutMax)
+				var se *fileSizeError
+				if c.CopyOutPartial && errors.As(err, &se) {
+					omit(j.name, se.size)
+					continue
+				}
+				if err != nil {
+					if rt == nil {
+						rt = err
+					}
+					continue
+				}
+				put(collectedFile{order: j.order, name: j.name, content: b})
+			}
+			return rt
+		})
+	}
+	for _, j := range jobs {
+		ch <- j
+	}
+	close(ch)
+	return g.Wait()
+}
+
+// collectedFile is the copy out file ordered by its source
+type collectedFile struct {
+	order   [3]int // source group, index and file index inside dir
+	name    string
+	content []byte
+}
+
+func (f collectedFile) less(o collectedFile) bool {
+	for i := range f.order {
+		if f.order[i] != o.order[i] {
+			return f.order[i] < o.order[i]
+		}
+	}
+	return false
+}
+
+// collectFiles names the collected files in order by the collision policy of
+// the cmd
+func collectFiles(c *Cmd, fs []collectedFile) (map[string]file.File, error) {
+	sort.Slice(fs, func(i, j int) bool { return fs[i].less(fs[j]) })
+	key := func(n string) string {
+		if c.CopyOutCaseInsensitive {
+			return strings.ToLower(n)
+		}
+		return n
+	}
+	rt := make(map[string]file.File, len(fs))
+	names := make(map[string]string, len(fs)) // key to collected name
+	for _, f := range fs {
+		n := f.name
+		if prev, ok := names[key(n)]; ok {
+			switch c.CopyOutCollision {
+			case CollisionPolicyError:
+				return rt, fmt.Errorf("copy out: file name %s collides with %s", n, prev)
+			case CollisionPolicyKeepFirst:
+				continue
+			case CollisionPolicyRename:
+				n = renameCollision(n, func(s string) bool {
+					_, ok := names[key(s)]
+					return ok
+				})
+			default:
+				delete(rt, prev)
+			}
+		}
+		names[key(n)] = n
+		rt[n] = file.NewMemFile(n, f.content)
+	}
+	return rt, nil
+}
+
+// renameCollision appends the first free suffix to the name before its
+// extension (e.g. out.txt to out_1.txt)
+func renameCollision(n string, exists func(string) bool) string {
+	ext := path.Ext(n)
+	base := strings.TrimSuffix(n, ext)
+	if base == "" || strings.HasSuffix(base, "/") {
+		base, ext = n, ""
+	}
+	for i := 1; ; i++ {
+		s := base + "_" + strconv.Itoa(i) + ext
+		if !exists(s) {
+			return s
+		}
+	}
+}
+
+// copyOutFile reads the regular file from the container within the size limit
+func copyOutFile(m Environment, n string, max Size) ([]byte, error) {
+	cf, err := m.Open(n, os.O_RDONLY, 0777)
+	if err != nil {
+		return nil, err
+	}
+	defer cf.Close()
+
+	stat, err := cf.Stat()
+	if err != nil {
+		return nil, err
+	}
+	// check regular file
+	if stat.Mode()&os.ModeType != 0 {
+		return nil, fmt.Errorf("File(%s) is not a regular file %d", n, stat.Mode()&os.ModeType)
+	}
+	// check size limit
+	s := stat.Size()
+	if max > 0 && s > int64(max) {
+		return nil, &fileSizeError{name: n, size: Size(s), max: max}
+	}
+	// read into exact size to avoid buffer growth
+	// and ensure not copy over file size
+	buf := make([]byte, s)
+	rn, err := io.ReadFull(cf, buf)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	return buf[:rn], nil
+}
+
+// fileSizeError is the copy out file exceeded the size limit
+type fileSizeError struct {
+	name      string
+	size, max Size
+}
+
+func (e *fileSizeError) Error() string {
+	return fmt.Sprintf("File(%s) have size (%d) exceeded the limit (%d)", e.name, e.size, e.max)
+}
+
+// copyOutDirNames lists the regular files inside the dir of the container
+func copyOutDirNames(m Environment, dir string) ([]string, error) {
+	d, err := m.Open(dir, os.O_RDONLY, 0777)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	entries, err := d.ReadDir(-1)
+	if err != nil {
+		return nil, fmt.Errorf("Dir(%s) cannot be read: %v", dir, err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.Type().IsRegular() {
+			names = append(names, e.Name())
+		}
+	}
+	return names, nil
 }
@@ -13,6 +13,12 @@ func copyIn(m Environment, copyIn map[string]file.File) error {
 	for n, f := range copyIn {
 		n, f := n, f
 		g.Go(func() error {
+			// falls back to copy if not linked
+			if l, ok := f.(file.Linker); ok {
+				if p, ok := l.LinkPath(); ok && linkFile(m, n, p) {
+					return nil
+				}
+			}
 			cf, err := m.Open(n, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0777)
 			if err != nil {
 				return err
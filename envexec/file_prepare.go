@@ -1,11 +1,11 @@
 package envexec
 
 import (
-	"bytes"
 	"fmt"
 	"io"
 	"os"
 	"sync"
+	"sync/atomic"
 
 	"github.com/creack/pty"
 	"github.com/criyle/go-judge/file"
@@ -13,8 +13,50 @@ import (
 )
 
 type pipeCollector struct {
-	buff *pipe.Buffer
-	name string
+	buff   *pipe.Buffer
+	name   string
+	policy CollectorPolicy
+	closed *int32 // set if the pipe closed by writes past the limit (backpressure)
+	read   *int64 // bytes read from the pipe including the discarded, nil for tty
+}
+
+// exceeded returns whether the output exceeded the limit once buff done
+func (p pipeCollector) exceeded() bool {
+	if p.closed != nil {
+		return atomic.LoadInt32(p.closed) == 1
+	}
+	return int64(p.buff.Buffer.Len()) > p.buff.Max
+}
+
+// closedExceeded returns whether the program failed by writing to the pipe
+// closed at the limit and the limit exceeded should fail the program
+func closedExceeded(ptc []pipeCollector) bool {
+	for _, p := range ptc {
+		if p.closed != nil && p.policy != CollectorPolicyTruncate && p.exceeded() {
+			return true
+		}
+	}
+	return false
+}
+
+// truncatedPipes returns the collectors exceeded the limit with the bytes
+// read from their pipes (the program wrote at least), after buff done
+func truncatedPipes(ptc []pipeCollector) map[string]Size {
+	var rt map[string]Size
+	for _, p := range ptc {
+		if !p.exceeded() {
+			continue
+		}
+		if rt == nil {
+			rt = make(map[string]Size)
+		}
+		n := int64(p.buff.Buffer.Len())
+		if p.read != nil {
+			n = atomic.LoadInt64(p.read)
+		}
+		rt[p.name] = Size(n)
+	}
+	return rt
 }
 
 // prepare Files for tty input / output
@@ -72,10 +114,10 @@ func prepareCmdFdTTY(c *Cmd, count int) (fd, ftc []*os.File, ptc []pipeCollector
 			output = &pipe.Buffer{
 				W:      fTty,
 				Max:    t.SizeLimit,
-				Buffer: new(bytes.Buffer),
+				Buffer: newBuffer(c.BufferPool),
 				Done:   done,
 			}
-			ptc = append(ptc, pipeCollector{output, t.Name})
+			ptc = append(ptc, pipeCollector{buff: output, name: t.Name, policy: t.Policy})
 
 			wg.Add(1)
 			go func() {
@@ -133,14 +175,25 @@ func prepareCmdFd(c *Cmd, count int) (fd, ftc []*os.File, ptc []pipeCollector, e
 				break
 			}
 
-			b, err := pipe.NewBuffer(t.SizeLimit)
+			var (
+				b      *pipe.Buffer
+				closed *int32
+				read   = new(int64)
+				err    error
+			)
+			if t.Backpressure {
+				closed = new(int32)
+				b, err = newBackpressurePipeBuffer(c.BufferPool, t.SizeLimit, closed, read)
+			} else {
+				b, err = newPipeBuffer(c.BufferPool, t.SizeLimit, read)
+			}
 			if err != nil {
 				err = fmt.Errorf("failed to create pipe %v", err)
 				goto openError
 			}
 			fd[j] = b.W
 			pb[t.Name] = b
-			ptc = append(ptc, pipeCollector{b, t.Name})
+			ptc = append(ptc, pipeCollector{buff: b, name: t.Name, policy: t.Policy, closed: closed, read: read})
 			ftc = append(ftc, b.W)
 
 		default:
@@ -155,6 +208,74 @@ openError:
 	return nil, nil, nil, err
 }
 
+// newPipeBuffer creates pipe.Buffer with buffer from the pool, the output
+// past max is drained and discarded so that the program is neither blocked
+// nor signalled, read counts the bytes read from the pipe
+func newPipeBuffer(p BufferPool, max int64, read *int64) (*pipe.Buffer, error) {
+	buffer := newBuffer(p)
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	done := make(chan struct{})
+	go func() {
+		defer r.Close()
+		n, _ := io.CopyN(buffer, r, max+1)
+		atomic.StoreInt64(read, n)
+		close(done)
+		io.Copy(countDiscard{read}, r)
+	}()
+	return &pipe.Buffer{
+		W:      w,
+		Max:    max,
+		Buffer: buffer,
+		Done:   done,
+	}, nil
+}
+
+// newBackpressurePipeBuffer creates pipe.Buffer reads at most max bytes, the
+// read end is closed once the program writes past max (closed is set) or
+// closes the write end
+func newBackpressurePipeBuffer(p BufferPool, max int64, closed *int32, read *int64) (*pipe.Buffer, error) {
+	buffer := newBuffer(p)
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer r.Close()
+		n, _ := io.CopyN(buffer, r, max)
+		atomic.StoreInt64(read, n)
+		if n < max {
+			return
+		}
+		// the byte past the limit is not collected
+		var b [1]byte
+		if n, _ := r.Read(b[:]); n > 0 {
+			atomic.AddInt64(read, int64(n))
+			atomic.StoreInt32(closed, 1)
+		}
+	}()
+	return &pipe.Buffer{
+		W:      w,
+		Max:    max,
+		Buffer: buffer,
+		Done:   done,
+	}, nil
+}
+
+// countDiscard discards the writes and counts the bytes written
+type countDiscard struct {
+	n *int64
+}
+
+func (c countDiscard) Write(b []byte) (int, error) {
+	atomic.AddInt64(c.n, int64(len(b)))
+	return len(b), nil
+}
+
 // prepareFd returns fds, pipeToCollect fileToClose, error
 func prepareFds(r *Group) ([][]*os.File, [][]pipeCollector, []*os.File, error) {
 	// prepare fd count
@@ -3,23 +3,48 @@ package envexec
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"syscall"
+
+	"golang.org/x/sys/unix"
 )
 
-func copyDir(src *os.File, dst string) error {
-	// make sure dir exists
-	os.MkdirAll(dst, 0777)
-	newDir, err := os.Open(dst)
+// copyDir copies the files of the dir into dst, it fails before copying if
+// the total size exceeded max (0 unlimited)
+func copyDir(src *os.File, dst string, max Size) error {
+	dir, err := reopenDir(src)
 	if err != nil {
 		return err
 	}
-	defer newDir.Close()
+	defer dir.Close()
 
-	dir := src
 	names, err := dir.Readdirnames(-1)
 	if err != nil {
 		return err
 	}
+	if max > 0 {
+		var total Size
+		for _, n := range names {
+			var st unix.Stat_t
+			if err := unix.Fstatat(int(dir.Fd()), n, &st, unix.AT_SYMLINK_NOFOLLOW); err == nil && st.Mode&unix.S_IFMT == unix.S_IFREG {
+				total += Size(st.Size)
+			}
+		}
+		if total > max {
+			return fmt.Errorf("copy dir: total size (%d) exceeded the limit (%d)", total, max)
+		}
+	}
+
+	// make sure dir exists
+	os.MkdirAll(dst, 0777)
+	newDir, err := os.Open(dst)
+	if err != nil {
+		return err
+	}
+	defer newDir.Close()
+
 	for _, n := range names {
 		if err := copyFileDir(int(dir.Fd()), int(newDir.Fd()), n); err != nil {
 			return err
@@ -28,6 +53,16 @@ func copyDir(src *os.File, dst string) error {
 	return nil
 }
 
+// reopenDir opens the dir again so that its entries are read from the start
+// regardless of the offset of the shared fd
+func reopenDir(dir *os.File) (*os.File, error) {
+	fd, err := unix.Openat(int(dir.Fd()), ".", unix.O_CLOEXEC|unix.O_RDONLY|unix.O_DIRECTORY, 0)
+	if err != nil {
+		return nil, err
+	}
+	return os.NewFile(uintptr(fd), dir.Name()), nil
+}
+
 func copyFileDir(srcDirFd, dstDirFd int, name string) error {
 	// open the source file
 	fd, err := syscall.Openat(srcDirFd, name, syscall.O_CLOEXEC|syscall.O_RDONLY, 0777)
@@ -57,3 +92,103 @@ func copyFileDir(srcDirFd, dstDirFd int, name string) error {
 	}
 	return nil
 }
+
+// fileCount returns the number of used inodes of the file system of the dir
+func fileCount(dir *os.File) (uint64, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Fstatfs(int(dir.Fd()), &st); err != nil {
+		return 0, err
+	}
+	return st.Files - st.Ffree, nil
+}
+
+// removeDirContents removes the entries of the dir (recursively) except the
+// kept names
+func removeDirContents(dir *os.File, keep map[string]bool) error {
+	names, err := dir.Readdirnames(-1)
+	if err != nil {
+		return err
+	}
+	for _, n := range names {
+		if keep[n] {
+			continue
+		}
+		if err := removeAt(int(dir.Fd()), n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func removeAt(dirFd int, name string) error {
+	err := unix.Unlinkat(dirFd, name, 0)
+	if err == nil || err == unix.ENOENT {
+		return nil
+	}
+	if err != unix.EISDIR {
+		return err
+	}
+	fd, err := syscall.Openat(dirFd, name, syscall.O_CLOEXEC|syscall.O_RDONLY|syscall.O_DIRECTORY|syscall.O_NOFOLLOW, 0)
+	if err != nil {
+		return err
+	}
+	d := os.NewFile(uintptr(fd), name)
+	names, err := d.Readdirnames(-1)
+	if err == nil {
+		for _, n := range names {
+			if err = removeAt(fd, n); err != nil {
+				break
+			}
+		}
+	}
+	d.Close()
+	if err != nil {
+		return err
+	}
+	return unix.Unlinkat(dirFd, name, unix.AT_REMOVEDIR)
+}
+
+// linkFile links the host file into the work dir instead of copying, the
+// read-only file owned by the server is hard linked into host work dir,
+// otherwise it is cloned (reflink, copy on write) if the file system supports,
+// false if neither succeeded
+func linkFile(m Environment, name, src string) bool {
+	sf, err := os.Open(src)
+	if err != nil {
+		return false
+	}
+	defer sf.Close()
+
+	if h, ok := m.(HostWorkDirer); ok && hardLinkable(sf) {
+		if dir, ok := h.HostWorkDir(); ok {
+			dst := filepath.Join(dir, name)
+			// links the opened inode instead of the path checked
+			if strings.HasPrefix(dst, filepath.Clean(dir)+"/") && os.MkdirAll(filepath.Dir(dst), 0777) == nil {
+				os.Remove(dst)
+				fdPath := "/proc/self/fd/" + strconv.Itoa(int(sf.Fd()))
+				if unix.Linkat(unix.AT_FDCWD, fdPath, unix.AT_FDCWD, dst, unix.AT_SYMLINK_FOLLOW) == nil {
+					return true
+				}
+			}
+		}
+	}
+
+	df, err := m.Open(name, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0777)
+	if err != nil {
+		return false
+	}
+	defer df.Close()
+	return unix.IoctlFileClone(int(df.Fd()), int(sf.Fd())) == nil
+}
+
+// hardLinkable returns whether the file is a regular file owned by the server
+// without write or set id permission, thus the program runs as other user
+// could not modify the original through the link
+func hardLinkable(f *os.File) bool {
+	var st unix.Stat_t
+	if err := unix.Fstat(int(f.Fd()), &st); err != nil {
+		return false
+	}
+	return st.Mode&unix.S_IFMT == unix.S_IFREG && st.Mode&0222 == 0 &&
+		st.Mode&(unix.S_ISUID|unix.S_ISGID) == 0 && int(st.Uid) == os.Geteuid()
+}
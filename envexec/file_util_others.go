@@ -8,27 +8,36 @@ import (
 	"path"
 )
 
-func copyDir(src *os.File, dst string) error {
-	// make sure dir exists
-	os.MkdirAll(dst, 0777)
-	newDir, err := os.Open(dst)
+// copyDir copies the files of the dir into dst, it fails before copying if
+// the total size exceeded max (0 unlimited)
+func copyDir(src *os.File, dst string, max Size) error {
+	entries, err := os.ReadDir(src.Name())
 	if err != nil {
 		return err
 	}
-	defer newDir.Close()
-
-	dir, err := os.Open(src.Name())
-	if err != nil {
-		return err
+	if max > 0 {
+		var total Size
+		for _, e := range entries {
+			if fi, err := e.Info(); err == nil && fi.Mode().IsRegular() {
+				total += Size(fi.Size())
+			}
+		}
+		if total > max {
+			return fmt.Errorf("copy dir: total size (%d) exceeded the limit (%d)", total, max)
+		}
 	}
-	defer dir.Close()
 
-	names, err := dir.Readdirnames(-1)
+	// make sure dir exists
+	os.MkdirAll(dst, 0777)
+	newDir, err := os.Open(dst)
 	if err != nil {
 		return err
 	}
-	for _, n := range names {
-		if copyDirFile(src.Name(), dst, n); err != nil {
+	defer newDir.Close()
+
+	for _, e := range entries {
+		n := e.Name()
+		if err := copyDirFile(src.Name(), dst, n); err != nil {
 			return err
 		}
 	}
@@ -63,3 +72,34 @@ func copyDirFile(src, dst, name string) error {
 	}
 	return nil
 }
+
+func fileCount(dir *os.File) (uint64, error) {
+	return 0, fmt.Errorf("file count is not supported")
+}
+
+func removeDirContents(dir *os.File, keep map[string]bool) error {
+	d, err := os.Open(dir.Name())
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	names, err := d.Readdirnames(-1)
+	if err != nil {
+		return err
+	}
+	for _, n := range names {
+		if keep[n] {
+			continue
+		}
+		if err := os.RemoveAll(path.Join(dir.Name(), n)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// linkFile is not supported, the file is copied
+func linkFile(m Environment, name, src string) bool {
+	return false
+}
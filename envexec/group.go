@@ -3,6 +3,8 @@ package envexec
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 )
@@ -19,6 +21,16 @@ type Group struct {
 	// Pipes defines the potential mapping between Cmd.
 	// ensure nil is used as placeholder in correspond cmd
 	Pipes []*Pipe
+
+	// ResultCallback is called with the cmd index and its result as soon as
+	// the cmd finishes (optional), it may be called from multiple goroutines
+	ResultCallback func(int, Result)
+
+	// ClockBudget defines the wall time shared by all cmds since they start
+	// (e.g. total interaction time), exceeded kills the cmds still running
+	// and reports them as ClockBudgetExceeded with TimeLimitExceeded, the
+	// limits of each cmd still apply (0 disables)
+	ClockBudget time.Duration
 }
 
 // PipeIndex defines the index of cmd and the fd of the that cmd
@@ -43,30 +55,55 @@ func (r *Group) Run(ctx context.Context) ([]Result, error) {
 	}
 
 	// prepare environments
+	result := make([]Result, len(r.Cmd))
 	ms := make([]Environment, 0, len(r.Cmd))
-	for range r.Cmd {
+	envTime := make([]time.Duration, 0, len(r.Cmd))
+	for _, c := range r.Cmd {
+		envStart := time.Now()
 		m, err := r.EnvironmentPool.Get()
 		if err != nil {
-			return nil, fmt.Errorf("failed to get environment %v", err)
+			return nil, fmt.Errorf("failed to get environment %w", err)
 		}
-		defer r.EnvironmentPool.Put(m)
+		defer func(i int) { putEnvironment(r.EnvironmentPool, m, discardResult(result[i])) }(len(ms))
 		ms = append(ms, m)
+		envTime = append(envTime, time.Since(envStart))
+		c.observeStage(StageEnvironmentAcquired)
+	}
+
+	// the shared deadline cancels all cmds once the budget exceeded
+	var budgetExceeded int32
+	if r.ClockBudget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		timer := time.AfterFunc(r.ClockBudget, func() {
+			atomic.StoreInt32(&budgetExceeded, 1)
+			cancel()
+		})
+		defer timer.Stop()
 	}
 
 	// wait all cmd to finish
 	var g errgroup.Group
-	result := make([]Result, len(r.Cmd))
 	for i, c := range r.Cmd {
 		i, c := i, c
 		g.Go(func() error {
-			r, err := runSingle(ctx, ms[i], c, fds[i], pipeToCollect[i])
-			result[i] = r
+			rt, err := runSingle(ctx, ms[i], c, fds[i], pipeToCollect[i])
+			rt.EnvironmentTime = envTime[i]
 			if err != nil {
-				result[i].Status = StatusInternalError
-				result[i].Error = err.Error()
-				return err
+				rt.Status = StatusInternalError
+				rt.Error = err.Error()
+			}
+			// killed by the cancel of the budget (not exited before it)
+			if atomic.LoadInt32(&budgetExceeded) == 1 && rt.ExitReason == ExitReasonCancelled {
+				rt.Status = StatusTimeLimitExceeded
+				rt.ClockBudgetExceeded = true
+			}
+			result[i] = rt
+			if r.ResultCallback != nil {
+				r.ResultCallback(i, rt)
 			}
-			return nil
+			return err
 		})
 	}
 	err = g.Wait()
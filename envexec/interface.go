@@ -23,6 +23,32 @@ type ExecveParam struct {
 	// TTY specifies whether to use TTY
 	TTY bool
 
+	// Devices specifies device nodes (e.g. nvidia0) accessible besides the
+	// defaults, the environment must be configured to expose them
+	Devices []string
+
+	// Groups specifies the supplementary group IDs of the process, the
+	// environment not setting them should fail the execve
+	Groups []int
+
+	// Capabilities specifies the capabilities retained by the process, the
+	// environment dropping them should fail the execve
+	Capabilities []string
+
+	// CountInstructions requests the process to count the instructions
+	// retired (e.g. hardware perf counter), the process should implement
+	// InstructionCounter
+	CountInstructions bool
+
+	// CountSyscalls requests the process to count the syscalls entered by
+	// name (e.g. perf tracepoints), the process should implement
+	// SyscallCounter
+	CountSyscalls bool
+
+	// ReportCgroup requests the process to report the cgroup it is placed
+	// in, the process should implement CgroupReporter
+	ReportCgroup bool
+
 	// Process Limitations
 	Limit Limit
 }
@@ -34,8 +60,32 @@ type Limit struct {
 	Proc         uint64        // Process count limit
 	Stack        Size          // Stack limit
 	Output       Size          // Output limit
+	FileSize     Size          // Single file size limit (e.g. RLIMIT_FSIZE)
+	FreshCgroup  bool          // Use newly built cgroup instead of pooled one
 	Rate         float64       // CPU Rate limit
 	StrictMemory bool          // Use stricter memory limit (e.g. rlimit)
+
+	// MemoryAccounting selects peak or current memory to report
+	MemoryAccounting MemoryAccounting
+
+	// TimeAccounting selects cgroup or process cpu time to report
+	TimeAccounting TimeAccounting
+
+	// Nice is the scheduling nice value (-20 to 19, 0 default)
+	Nice int
+
+	// OpenFile is the max file descriptor number (e.g. RLIMIT_NOFILE)
+	OpenFile uint64
+
+	// Nproc is the max number of processes of the user (e.g. RLIMIT_NPROC)
+	Nproc uint64
+
+	// MemorySoft is the soft memory limit throttled over (e.g. memory.high)
+	MemorySoft Size
+
+	// FileCount is the max number of files created in the work dir over the
+	// count before the run, enforced by the FileCountLimiter environment
+	FileCount uint64
 }
 
 // Usage defines the peak process resource usage
@@ -51,6 +101,99 @@ type Process interface {
 	Usage() Usage          // Usage retrieves the process usage during the run time
 }
 
+// CgroupTimer is optionally implemented by Process to report the time spent
+// to get and set up the cgroup before the process starts
+type CgroupTimer interface {
+	CgroupTime() time.Duration
+}
+
+// CPUStater is optionally implemented by Process to report the user and
+// system cpu time after the process exited
+type CPUStater interface {
+	CPUStat() (user, system time.Duration, err error)
+}
+
+// MemoryPeaker is optionally implemented by Process to report the raw peak
+// memory if the reported memory is an estimate (e.g. excludes page cache)
+type MemoryPeaker interface {
+	MemoryPeak() (Size, bool)
+}
+
+// MemorySoftReporter is optionally implemented by Process to report whether
+// the memory exceeded Limit.MemorySoft by the cgroup (e.g. memory.events
+// high), false if not available
+type MemorySoftReporter interface {
+	MemorySoftExceeded() (bool, bool)
+}
+
+// Diagnoser is optionally implemented by the errors of EnvironmentPool and
+// Environment or by Process of the runner error to report the diagnosis of
+// the failure (e.g. the stderr of the container init), it may expose details
+// of the host thus only reported for debug
+type Diagnoser interface {
+	Diagnosis() string
+}
+
+// SwapReporter is optionally implemented by Process to report the max sampled
+// swap usage after the process exited, false if swap is disabled or not accounted
+type SwapReporter interface {
+	SwapUsage() (Size, bool)
+}
+
+// ProcCounter is optionally implemented by Process to report the current
+// number of processes / threads (e.g. cgroup pids.current)
+type ProcCounter interface {
+	ProcCount() (uint64, error)
+}
+
+// ProcLimitCounter is optionally implemented by Process to report the number
+// of process / thread creations failed by the process count limit after the
+// process exited (e.g. cgroup pids.events max)
+type ProcLimitCounter interface {
+	ProcLimitHits() (uint64, error)
+}
+
+// WriteCounter is optionally implemented by Process to report the cumulative
+// bytes written (e.g. cgroup io.stat wbytes)
+type WriteCounter interface {
+	WriteBytes() (uint64, error)
+}
+
+// InstructionCounter is optionally implemented by Process to report the
+// number of user space instructions retired by the program and its children
+type InstructionCounter interface {
+	Instructions() (uint64, error)
+}
+
+// SyscallCounter is optionally implemented by Process to report the number
+// of syscalls entered by the program and its children by syscall name
+type SyscallCounter interface {
+	SyscallCounts() (map[string]uint64, error)
+}
+
+// CgroupReporter is optionally implemented by Process to report the cgroup
+// version and the controllers set up for the program
+type CgroupReporter interface {
+	CgroupInfo() (CgroupInfo, error)
+}
+
+// OpenFileLister is optionally implemented by Process to list the targets of
+// the open file descriptors of the program (e.g. /proc/[pid]/fd)
+type OpenFileLister interface {
+	OpenFiles() ([]string, error)
+}
+
+// SocketCounter is optionally implemented by Process to report the number of
+// distinct sockets held by the program and its children
+type SocketCounter interface {
+	SocketCount() (uint64, error)
+}
+
+// Signaler is optionally implemented by Process to send signal to the program
+type Signaler interface {
+	Signal(os.Signal) error
+}
+
 // Environment defines the interface to access container execution environment
 type Environment interface {
 	Execve(context.Context, ExecveParam) (Process, error)
@@ -59,8 +202,51 @@ type Environment interface {
 	Open(path string, flags int, perm os.FileMode) (*os.File, error)
 }
 
+// HostWorkDirer is optionally implemented by Environment to report the host
+// path of its work dir if the program runs as other user than the server
+// (false otherwise), read-only files owned by the server are safe to be hard
+// linked into it
+type HostWorkDirer interface {
+	HostWorkDir() (string, bool)
+}
+
+// FileCountLimiter is optionally implemented by Environment if it enforces
+// Limit.FileCount by its file system (e.g. tmpfs nr_inodes), the file count
+// sampled is only reported then, the program is killed otherwise
+type FileCountLimiter interface {
+	FileCountLimited() bool
+}
+
 // EnvironmentPool implements pool of environments
 type EnvironmentPool interface {
 	Get() (Environment, error)
 	Put(Environment)
 }
+
+// WarmEnvironmentPool is optionally implemented by EnvironmentPool to get a
+// pooled environment without building a new one (false if none is pooled)
+type WarmEnvironmentPool interface {
+	GetWarm() (Environment, bool)
+}
+
+// EnvironmentDiscarder is optionally implemented by EnvironmentPool to
+// destroy the environment instead of reusing it (e.g. program not reaped)
+type EnvironmentDiscarder interface {
+	Discard(Environment)
+}
+
+// discardResult returns whether the environment of the result is broken thus
+// not reused (program not reaped or internal error)
+func discardResult(r Result) bool {
+	return r.ReapTimeout || r.Status == StatusInternalError
+}
+
+// putEnvironment puts the environment back to the pool unless it should be
+// discarded
+func putEnvironment(p EnvironmentPool, m Environment, discard bool) {
+	if d, ok := p.(EnvironmentDiscarder); ok && discard {
+		d.Discard(m)
+		return
+	}
+	p.Put(m)
+}
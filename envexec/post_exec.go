@@ -0,0 +1,64 @@
+package envexec
+
+import (
+	"context"
+	"time"
+
+	"github.com/criyle/go-sandbox/runner"
+)
+
+// defaultPostExecTimeLimit bounds the post exec cmd if not specified
+const defaultPostExecTimeLimit = time.Second
+
+// PostExecResult defines the result of the post exec cmd
+type PostExecResult struct {
+	Status     Status
+	ExitStatus int
+	Error      string
+	Time       time.Duration
+}
+
+// runPostExec runs the post exec cmd in the environment after the cmd exited,
+// it is bounded by PostExecTimeLimit in both cpu and clock time and the memory
+// / proc limits of the cmd
+func runPostExec(pc context.Context, m Environment, c *Cmd) *PostExecResult {
+	timeLimit := c.PostExecTimeLimit
+	if timeLimit <= 0 {
+		timeLimit = defaultPostExecTimeLimit
+	}
+	extraMemoryLimit := c.ExtraMemoryLimit
+	if extraMemoryLimit == 0 {
+		extraMemoryLimit = defaultExtraMemoryLimit
+	}
+	ctx, cancel := context.WithTimeout(pc, timeLimit)
+	defer cancel()
+
+	p, err := m.Execve(ctx, ExecveParam{
+		Args: c.PostExec,
+		Env:  c.Env,
+		Limit: Limit{
+			Time:   timeLimit,
+			Memory: c.MemoryLimit + extraMemoryLimit,
+			Proc:   c.ProcLimit,
+		},
+	})
+	if err != nil {
+		return &PostExecResult{
+			Status: StatusInternalError,
+			Error:  err.Error(),
+		}
+	}
+	<-p.Done()
+	rt := p.Result()
+	res := &PostExecResult{
+		Status:     convertStatus(rt.Status),
+		ExitStatus: rt.ExitStatus,
+		Error:      rt.Error,
+		Time:       rt.Time,
+	}
+	// killed by the clock time limit or exceeded the cpu time limit
+	if (rt.Status != runner.StatusNormal && ctx.Err() == context.DeadlineExceeded) || res.Time > timeLimit {
+		res.Status = StatusTimeLimitExceeded
+	}
+	return res
+}
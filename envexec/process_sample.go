@@ -0,0 +1,304 @@
+package envexec
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// sampledProcess wraps the process to sample its syscall rate, process
+// count, write bytes, instructions, sockets and work dir file count each time
+// the waiter checks the usage
+type sampledProcess struct {
+	Process
+	syscallCounter SyscallCounter // nil if syscall rate not checked
+	procCounter    ProcCounter    // nil if process count not available
+	writeCounter   WriteCounter   // nil if write bytes not checked
+	fileCounter    *fileCounter   // nil if file count not checked
+	syscallLimit   uint64
+	writeLimit     uint64
+	fileLimit      uint64
+	syscallCancel  context.CancelFunc // kills the process if syscall rate exceeded
+	cancel         context.CancelFunc // kills the process
+
+	instructionCounter InstructionCounter // nil if instructions not checked
+	instructionLimit   uint64
+
+	openFileLister OpenFileLister // nil if open files not sampled
+
+	socketCounter SocketCounter // nil if sockets not checked
+	socketLimit   uint64
+
+	mu        sync.Mutex
+	last      time.Time
+	lastCount uint64
+	maxRate   uint64
+	exceeded  bool
+	procPeak  uint64
+
+	writeExceeded bool
+	fileExceeded  bool
+
+	instructionExceeded bool
+
+	openFiles []string
+
+	socketPeak     uint64
+	socketExceeded bool
+}
+
+// fileCounter samples the file count of the work dir over the count before
+// the run
+type fileCounter struct {
+	wd      *os.File
+	base    uint64 // file count before the run
+	limited bool   // enforced by the environment, not killed
+}
+
+// newFileCounter takes the file count before the run, nil if not checked
+func newFileCounter(c *Cmd, m Environment) *fileCounter {
+	if c.FileLimit == 0 {
+		return nil
+	}
+	wd := m.WorkDir()
+	n, err := fileCount(wd)
+	if err != nil {
+		return nil
+	}
+	fc := &fileCounter{wd: wd, base: n}
+	if l, ok := m.(FileCountLimiter); ok {
+		fc.limited = l.FileCountLimited()
+	}
+	return fc
+}
+
+func newSampledProcess(p Process, c *Cmd, fc *fileCounter, cancel context.CancelFunc) Process {
+	sp := &sampledProcess{
+		Process:      p,
+		syscallLimit: c.SyscallRateLimit,
+		writeLimit:   uint64(c.WriteLimit),
+		fileLimit:    c.FileLimit,
+		fileCounter:  fc,
+		cancel:       cancel,
+
+		instructionLimit: c.InstructionLimit,
+		socketLimit:      c.SocketLimit,
+	}
+	if c.KillOnSyscallRate {
+		sp.syscallCancel = cancel
+	}
+	if pc, ok := p.(ProcCounter); ok {
+		sp.procCounter = pc
+	}
+	if sc, ok := p.(SyscallCounter); ok && sp.syscallLimit > 0 {
+		sp.syscallCounter = sc
+	}
+	if wc, ok := p.(WriteCounter); ok && sp.writeLimit > 0 {
+		sp.writeCounter = wc
+	}
+	if ic, ok := p.(InstructionCounter); ok && sp.instructionLimit > 0 {
+		sp.instructionCounter = ic
+	}
+	if ol, ok := p.(OpenFileLister); ok && c.SampleOpenFiles {
+		sp.openFileLister = ol
+	}
+	if sc, ok := p.(SocketCounter); ok && sp.socketLimit > 0 {
+		sp.socketCounter = sc
+	}
+	if sp.procCounter == nil && sp.syscallCounter == nil && sp.writeCounter == nil && sp.fileCounter == nil &&
+		sp.instructionCounter == nil && sp.openFileLister == nil && sp.socketCounter == nil {
+		return p
+	}
+	return sp
+}
+
+func (p *sampledProcess) Usage() Usage {
+	u := p.Process.Usage()
+	if p.procCounter != nil {
+		if n, err := p.procCounter.ProcCount(); err == nil {
+			p.mu.Lock()
+			if n > p.procPeak {
+				p.procPeak = n
+			}
+			p.mu.Unlock()
+		}
+	}
+	if p.syscallCounter != nil {
+		if m, err := p.syscallCounter.SyscallCounts(); err == nil {
+			var c uint64
+			for _, n := range m {
+				c += n
+			}
+			p.checkSyscallRate(c, time.Now())
+		}
+	}
+	if p.writeCounter != nil {
+		if n, err := p.writeCounter.WriteBytes(); err == nil {
+			p.checkWriteBytes(n)
+		}
+	}
+	p.sampleFileCount()
+	if p.instructionCounter != nil {
+		if n, err := p.instructionCounter.Instructions(); err == nil {
+			p.checkInstructions(n)
+		}
+	}
+	if p.socketCounter != nil {
+		if n, err := p.socketCounter.SocketCount(); err == nil {
+			p.checkSocketCount(n)
+		}
+	}
+	p.sampleOpenFiles()
+	return u
+}
+
+// sampleOpenFiles keeps the open files if the program is still running
+func (p *sampledProcess) sampleOpenFiles() {
+	if p.openFileLister == nil {
+		return
+	}
+	fs, err := p.openFileLister.OpenFiles()
+	if err != nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.openFiles = fs
+}
+
+// lastOpenFiles returns the last sampled open files
+func (p *sampledProcess) lastOpenFiles() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.openFiles
+}
+
+func (p *sampledProcess) checkInstructions(n uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if n > p.instructionLimit && !p.instructionExceeded {
+		p.instructionExceeded = true
+		p.cancel()
+	}
+}
+
+// sampleFileCount checks the file count of the work dir, the files are kept
+// after the program exited (thus sampled once more)
+func (p *sampledProcess) sampleFileCount() {
+	if p.fileCounter == nil {
+		return
+	}
+	if n, err := fileCount(p.fileCounter.wd); err == nil {
+		p.checkFileCount(n)
+	}
+}
+
+func (p *sampledProcess) checkFileCount(n uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	fc := p.fileCounter
+	if n > fc.base && n-fc.base > p.fileLimit && !p.fileExceeded {
+		p.fileExceeded = true
+		if !fc.limited {
+			p.cancel()
+		}
+	}
+}
+
+func (p *sampledProcess) checkSocketCount(n uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if n > p.socketPeak {
+		p.socketPeak = n
+	}
+	if n > p.socketLimit && !p.socketExceeded {
+		p.socketExceeded = true
+		p.cancel()
+	}
+}
+
+func (p *sampledProcess) checkWriteBytes(n uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if n > p.writeLimit && !p.writeExceeded {
+		p.writeExceeded = true
+		p.cancel()
+	}
+}
+
+func (p *sampledProcess) checkSyscallRate(c uint64, now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if d := now.Sub(p.last); !p.last.IsZero() && d > 0 && c >= p.lastCount {
+		rate := uint64(float64(c-p.lastCount) / d.Seconds())
+		if rate > p.maxRate {
+			p.maxRate = rate
+		}
+		if rate > p.syscallLimit && !p.exceeded {
+			p.exceeded = true
+			if p.syscallCancel != nil {
+				p.syscallCancel()
+			}
+		}
+	}
+	p.last, p.lastCount = now, c
+}
+
+// switchRateResult returns whether the rate exceeded and the warning message
+func (p *sampledProcess) syscallRateResult() (bool, string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.exceeded {
+		return false, ""
+	}
+	return true, fmt.Sprintf("syscall rate (%d/s) exceeded the limit (%d/s)", p.maxRate, p.syscallLimit)
+}
+
+// writeLimitExceeded returns whether the write bytes exceeded the limit
+func (p *sampledProcess) writeLimitExceeded() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.writeExceeded
+}
+
+// fileLimitExceeded returns whether the created files exceeded the limit
+func (p *sampledProcess) fileLimitExceeded() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.fileExceeded
+}
+
+// socketLimitExceeded returns whether the sockets exceeded the limit
+func (p *sampledProcess) socketLimitExceeded() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.socketExceeded
+}
+
+// peakSocket returns the max sampled socket count
+func (p *sampledProcess) peakSocket() uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.socketPeak
+}
+
+// peakProc returns the max sampled process count
+func (p *sampledProcess) peakProc() uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.procPeak
+}
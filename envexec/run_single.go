@@ -2,7 +2,11 @@ package envexec
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
+	"sync/atomic"
+	"time"
 
 	"github.com/criyle/go-sandbox/runner"
 )
@@ -12,6 +16,14 @@ func runSingle(pc context.Context, m Environment, c *Cmd, fds []*os.File, ptc []
 	fdToClose := fds
 	defer func() { closeFiles(fdToClose) }()
 
+	if c.CleanWorkDir {
+		if err := removeDirContents(m.WorkDir(), nil); err != nil {
+			result.Status = StatusFileError
+			result.Error = fmt.Sprintf("clean work dir: %v", err)
+			return result, nil
+		}
+	}
+
 	// copyin
 	if len(c.CopyIn) > 0 {
 		if err := copyIn(m, c.CopyIn); err != nil {
@@ -21,6 +33,19 @@ func runSingle(pc context.Context, m Environment, c *Cmd, fds []*os.File, ptc []
 		}
 	}
 
+	var tee *stdinTee
+	if c.StdinTee != nil && !c.TTY && len(fds) > 0 && fds[0] != nil {
+		t, r, err := newStdinTee(*c.StdinTee, fds[0])
+		if err != nil {
+			result.Status = StatusFileError
+			result.Error = err.Error()
+			return result, nil
+		}
+		// the original stdin is owned by the tee
+		fds[0] = r
+		tee = t
+	}
+
 	extraMemoryLimit := c.ExtraMemoryLimit
 	if extraMemoryLimit == 0 {
 		extraMemoryLimit = defaultExtraMemoryLimit
@@ -38,17 +63,36 @@ func runSingle(pc context.Context, m Environment, c *Cmd, fds []*os.File, ptc []
 
 	// set running parameters
 	execParam := ExecveParam{
-		Args:  c.Args,
-		Env:   c.Env,
-		Files: getFdArray(fds),
-		TTY:   c.TTY,
+		Args:    c.Args,
+		Env:     c.Env,
+		Files:   getFdArray(fds),
+		TTY:     c.TTY,
+		Devices: c.Devices,
+		Groups:  c.Groups,
+
+		Capabilities: c.Capabilities,
+
+		CountInstructions: c.InstructionLimit > 0,
+		CountSyscalls:     c.CountSyscalls || c.SyscallRateLimit > 0,
+		ReportCgroup:      c.ReportCgroup,
 		Limit: Limit{
 			Time:         c.TimeLimit,
 			Memory:       memoryLimit,
 			Proc:         c.ProcLimit,
 			Stack:        stackLimit,
+			Output:       c.OutputLimit,
+			MemorySoft:   c.MemorySoftLimit,
 			Rate:         c.CPURateLimit,
 			StrictMemory: c.StrictMemoryLimit,
+			FileSize:     c.FileSizeLimit,
+			FreshCgroup:  c.FreshCgroup,
+
+			MemoryAccounting: c.MemoryAccounting,
+			TimeAccounting:   c.TimeAccounting,
+			Nice:             c.Nice,
+			OpenFile:         c.OpenFileLimit,
+			Nproc:            c.NprocLimit,
+			FileCount:        c.FileLimit,
 		},
 	}
 
@@ -56,33 +100,154 @@ func runSingle(pc context.Context, m Environment, c *Cmd, fds []*os.File, ptc []
 	ctx, cancel := context.WithCancel(pc)
 	waiterCtx, waiterCancel := context.WithCancel(ctx)
 
+	// the file count before the run is taken before the program started
+	fc := newFileCounter(c, m)
 	process, err := m.Execve(ctx, execParam)
+	startTime := time.Now()
+	var (
+		cgTime   time.Duration
+		signaler Signaler
+		cpuStat  CPUStater
+		memPeak  MemoryPeaker
+		swap     SwapReporter
+		memSoft  MemorySoftReporter
+		insCount InstructionCounter
+		procHits ProcLimitCounter
+		sysCount SyscallCounter
+		cgReport CgroupReporter
+		diag     Diagnoser
+	)
+	if err == nil {
+		c.observeStage(StageExecStarted)
+		if t, ok := process.(CgroupTimer); ok {
+			cgTime = t.CgroupTime()
+		}
+		signaler, _ = process.(Signaler)
+		cpuStat, _ = process.(CPUStater)
+		memPeak, _ = process.(MemoryPeaker)
+		swap, _ = process.(SwapReporter)
+		memSoft, _ = process.(MemorySoftReporter)
+		insCount, _ = process.(InstructionCounter)
+		procHits, _ = process.(ProcLimitCounter)
+		sysCount, _ = process.(SyscallCounter)
+		cgReport, _ = process.(CgroupReporter)
+		diag, _ = process.(Diagnoser)
+		process = newSampledProcess(process, c, fc, cancel)
+	}
 
 	// close files
 	closeFiles(fds)
 	fdToClose = nil
 
+	// kills the program once the collector with kill policy exceeded
+	var outputKilled int32
+	if err == nil {
+		for _, p := range ptc {
+			if p.policy != CollectorPolicyKill {
+				continue
+			}
+			go func(p pipeCollector) {
+				select {
+				case <-p.buff.Done:
+					if p.exceeded() {
+						atomic.StoreInt32(&outputKilled, 1)
+						cancel()
+					}
+				case <-ctx.Done():
+				}
+			}(p)
+		}
+	}
+
+	// terminates the program once outputs closed and quiet period passed
+	var earlyResult int32
+	if err == nil && c.EarlyResultQuietPeriod > 0 && !c.TTY && len(ptc) > 0 {
+		go func() {
+			for _, p := range ptc {
+				select {
+				case <-p.buff.Done:
+				case <-ctx.Done():
+					return
+				}
+			}
+			// collector done by exceeding the limit is not closed by program
+			for _, p := range ptc {
+				if p.exceeded() {
+					return
+				}
+			}
+			timer := time.NewTimer(c.EarlyResultQuietPeriod)
+			defer timer.Stop()
+
+			select {
+			case <-timer.C:
+			case <-process.Done():
+				return
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case <-process.Done(): // exited by itself
+			default:
+				atomic.StoreInt32(&earlyResult, 1)
+				cancel()
+			}
+		}()
+	}
+
 	// starts waiter to periodically check cpu usage
+	var timedOut int32
 	go func() {
-		c.Waiter(waiterCtx, process)
+		if c.Waiter(waiterCtx, process) && c.KillSignal != nil && signaler != nil {
+			atomic.StoreInt32(&timedOut, 1)
+			killSignal(process, signaler, c.KillSignal, c.KillGracePeriod)
+		}
+		// samples the open files right before killed
+		if p, ok := process.(*sampledProcess); ok {
+			p.sampleOpenFiles()
+		}
 		cancel()
 	}()
 
 	var rt runner.Result
 	if err == nil {
-		<-process.Done()
+		if !waitReaped(ctx, process, c.ReapDeadline) {
+			// the environment is destroyed instead of reused
+			waiterCancel()
+			result = Result{
+				Status:      StatusReapTimeout,
+				Error:       fmt.Sprintf("program is not reaped within %v after killed", c.ReapDeadline),
+				CgroupTime:  cgTime,
+				ReapTimeout: true,
+			}
+			return result, nil
+		}
 		rt = process.Result()
+		c.observeStage(StageExited)
+		// measured here if the environment does not report the wall clock time
+		if rt.RunningTime == 0 {
+			rt.RunningTime = time.Since(startTime)
+		}
+		// killed after the outputs closed is reported as exited normally
+		if atomic.LoadInt32(&earlyResult) == 1 {
+			rt.Status = runner.StatusNormal
+			rt.ExitStatus = 0
+		}
 	} else {
 		rt = runner.Result{
 			Status: runner.StatusRunnerError,
 			Error:  err.Error(),
 		}
+		errors.As(err, &diag)
 	}
 
 	waiterCancel()
 
 	// collect result
-	files, err := copyOutAndCollect(m, c, ptc)
+	files, omitted, usage, err := copyOutAndCollect(m, c, ptc)
+	if tee != nil {
+		files[tee.name] = tee.file()
+	}
 	result = Result{
 		Status:     convertStatus(rt.Status),
 		ExitStatus: rt.ExitStatus,
@@ -90,8 +255,69 @@ func runSingle(pc context.Context, m Environment, c *Cmd, fds []*os.File, ptc []
 		Time:       rt.Time,
 		RunTime:    rt.RunningTime,
 		Memory:     rt.Memory,
+		CgroupTime: cgTime,
 		Files:      files,
+
+		OmittedFiles: omitted,
+		CopyOutBytes: usage.files,
+		PipeBytes:    usage.pipes,
+
+		TruncatedFiles: truncatedPipes(ptc),
+	}
+	if cpuStat != nil {
+		if u, s, err := cpuStat.CPUStat(); err == nil {
+			result.UserTime, result.SystemTime = u, s
+		}
+	}
+	result.EarlyResult = atomic.LoadInt32(&earlyResult) == 1
+	if insCount != nil && c.InstructionLimit > 0 {
+		if n, err := insCount.Instructions(); err == nil {
+			result.Instructions = n
+		}
+	}
+	if sysCount != nil && c.CountSyscalls {
+		if m, err := sysCount.SyscallCounts(); err == nil {
+			result.SyscallCounts = m
+		}
+	}
+	if cgReport != nil && c.ReportCgroup {
+		if i, err := cgReport.CgroupInfo(); err == nil {
+			result.CgroupInfo = &i
+		}
 	}
+	if memPeak != nil {
+		if m, ok := memPeak.MemoryPeak(); ok {
+			result.MemoryPeak = m
+		}
+	}
+	if diag != nil && rt.Status == runner.StatusRunnerError {
+		result.Diagnosis = diag.Diagnosis()
+	}
+	if swap != nil {
+		if s, ok := swap.SwapUsage(); ok {
+			result.SwapUsage = s
+		}
+	}
+	// SIGXFSZ is reported as output limit exceeded by the runner, the
+	// RLIMIT_FSIZE is of the file size limit only if it is below the output
+	// limit
+	if rt.Status == runner.StatusOutputLimitExceeded && fileSizeLimited(c) {
+		result.Status = StatusFileSizeLimitExceeded
+	}
+	var syscallRateKilled, writeLimitKilled, fileLimitKilled, socketLimitKilled bool
+	if p, ok := process.(*sampledProcess); ok {
+		var exceeded bool
+		exceeded, result.Warning = p.syscallRateResult()
+		syscallRateKilled = exceeded && c.KillOnSyscallRate
+		writeLimitKilled = p.writeLimitExceeded()
+		p.sampleFileCount()
+		fileLimitKilled = p.fileLimitExceeded()
+		socketLimitKilled = p.socketLimitExceeded()
+		result.ProcPeak = p.peakProc()
+		result.SocketPeak = p.peakSocket()
+		result.OpenFiles = p.lastOpenFiles()
+	}
+	result.ProcLimitHit = procLimitHit(c, procHits, result.ProcPeak)
 	// collect error (only if the process exits normally)
 	if rt.Status == runner.StatusNormal && err != nil && result.Error == "" {
 		switch err := err.(type) {
@@ -108,7 +334,135 @@ func runSingle(pc context.Context, m Environment, c *Cmd, fds []*os.File, ptc []
 	if result.Memory > c.MemoryLimit {
 		result.Status = StatusMemoryLimitExceeded
 	}
+	// program exits by kill signal after time limit exceeded
+	if atomic.LoadInt32(&timedOut) == 1 && (result.Status == StatusAccepted ||
+		result.Status == StatusSignalled || result.Status == StatusNonzeroExitStatus) {
+		result.Status = StatusTimeLimitExceeded
+	}
+	// the program throttled by the soft limit may not have its peak over it,
+	// the failed program is reported by its status only
+	if c.MemorySoftLimit > 0 && result.Status != StatusMemoryLimitExceeded {
+		exceeded, ok := false, false
+		if memSoft != nil {
+			exceeded, ok = memSoft.MemorySoftExceeded()
+		}
+		result.MemoryWarning = exceeded || (!ok && result.Memory > c.MemorySoftLimit)
+	}
+	if syscallRateKilled && (result.Status == StatusSignalled || result.Status == StatusTimeLimitExceeded) &&
+		result.Time <= c.TimeLimit && result.Memory <= c.MemoryLimit {
+		result.Status = StatusSyscallRateExceeded
+	}
+	if writeLimitKilled && (result.Status == StatusSignalled || result.Status == StatusTimeLimitExceeded) &&
+		result.Time <= c.TimeLimit && result.Memory <= c.MemoryLimit {
+		result.Status = StatusWriteLimitExceeded
+	}
+	// the program not killed by the file limit (enforced by the environment)
+	// may exit after the creation failed
+	if fileLimitKilled && (result.Status == StatusSignalled || result.Status == StatusTimeLimitExceeded ||
+		result.Status == StatusAccepted || result.Status == StatusNonzeroExitStatus) &&
+		result.Time <= c.TimeLimit && result.Memory <= c.MemoryLimit {
+		result.Status = StatusFileLimitExceeded
+	}
+	if socketLimitKilled && (result.Status == StatusSignalled || result.Status == StatusTimeLimitExceeded) &&
+		result.Time <= c.TimeLimit && result.Memory <= c.MemoryLimit {
+		result.Status = StatusSocketLimitExceeded
+	}
+	// the final count decides instead of being killed by the sample
+	if c.InstructionLimit > 0 && result.Instructions > c.InstructionLimit && (result.Status == StatusAccepted ||
+		result.Status == StatusSignalled || result.Status == StatusTimeLimitExceeded ||
+		result.Status == StatusNonzeroExitStatus) &&
+		result.Time <= c.TimeLimit && result.Memory <= c.MemoryLimit {
+		result.Status = StatusInstructionLimitExceeded
+	}
+	// program killed by SIGPIPE (or exited on EPIPE) writing past the limit
+	if (atomic.LoadInt32(&outputKilled) == 1 || closedExceeded(ptc)) && (result.Status == StatusSignalled ||
+		result.Status == StatusTimeLimitExceeded || result.Status == StatusNonzeroExitStatus) &&
+		result.Time <= c.TimeLimit && result.Memory <= c.MemoryLimit {
+		result.Status = StatusOutputLimitExceeded
+	}
+	// program terminated by signal on its own within limits
+	if sig, ok := terminatedSignal(rt); ok && len(c.SignalStatus) > 0 &&
+		result.Status == convertStatus(rt.Status) && pc.Err() == nil && atomic.LoadInt32(&timedOut) == 0 &&
+		result.Time <= c.TimeLimit && result.Memory <= c.MemoryLimit {
+		if s, ok := c.SignalStatus[sig]; ok {
+			result.Status = s
+		}
+	}
+	result.ExitReason = exitReason(pc, rt, result.Status, atomic.LoadInt32(&timedOut) == 1)
+	if c.PreserveDir != "" && result.Status != StatusAccepted {
+		if err := copyDir(m.WorkDir(), c.PreserveDir, c.PreserveMax); err == nil {
+			result.Preserved = true
+		} else {
+			os.RemoveAll(c.PreserveDir)
+		}
+	}
 	// make sure waiter exit
 	<-ctx.Done()
+	if len(c.PostExec) > 0 {
+		result.PostExec = runPostExec(pc, m, c)
+	}
 	return result, nil
 }
+
+// procLimitHit decides which process count limit the program hit, the cgroup
+// counts the failures while RLIMIT_NPROC does not thus it is estimated by the
+// sampled peak
+func procLimitHit(c *Cmd, pc ProcLimitCounter, peak uint64) ProcLimitHit {
+	if pc != nil && c.ProcLimit > 0 {
+		if n, err := pc.ProcLimitHits(); err == nil && n > 0 {
+			return ProcLimitHitPids
+		}
+	}
+	if c.NprocLimit > 0 && peak >= c.NprocLimit {
+		return ProcLimitHitNproc
+	}
+	return ProcLimitHitNone
+}
+
+// waitReaped waits the program to exit, once it is killed (context done) it
+// should be reaped within the deadline (0 waits until reaped), returns false
+// if the deadline exceeded (e.g. stuck in uninterruptible sleep)
+func waitReaped(ctx context.Context, p Process, deadline time.Duration) bool {
+	select {
+	case <-p.Done():
+		return true
+	case <-ctx.Done():
+	}
+	if deadline <= 0 {
+		<-p.Done()
+		return true
+	}
+	timer := time.NewTimer(deadline)
+	defer timer.Stop()
+
+	select {
+	case <-p.Done():
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// fileSizeLimited returns whether the RLIMIT_FSIZE of the cmd is set by its
+// file size limit rather than the output limit
+func fileSizeLimited(c *Cmd) bool {
+	return c.FileSizeLimit > 0 && (c.OutputLimit == 0 || c.FileSizeLimit < c.OutputLimit)
+}
+
+// killSignal sends signal to the program and waits it to exit within the grace
+// period before it got killed
+func killSignal(p Process, s Signaler, sig os.Signal, grace time.Duration) {
+	if err := s.Signal(sig); err != nil {
+		return
+	}
+	if grace == 0 {
+		grace = defaultKillGracePeriod
+	}
+	timer := time.NewTimer(grace)
+	defer timer.Stop()
+
+	select {
+	case <-p.Done():
+	case <-timer.C:
+	}
+}
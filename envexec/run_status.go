@@ -30,6 +30,41 @@ const (
 
 	// internal error including: cgroup init failed, container failed, etc
 	StatusInternalError
+
+	// killed by syscall rate exceeded
+	StatusSyscallRateExceeded
+
+	// killed by cumulative write bytes exceeded
+	StatusWriteLimitExceeded
+
+	// killed by number of created files exceeded
+	StatusFileLimitExceeded
+
+	// single file size exceeded (SIGXFSZ)
+	StatusFileSizeLimitExceeded
+
+	// signalled with SIGSEGV / SIGFPE / SIGABRT (by SignalStatus mapping)
+	StatusSegmentationFault
+	StatusFloatingPointException
+	StatusAborted
+
+	// instructions retired exceeded
+	StatusInstructionLimitExceeded
+
+	// output format is not valid (e.g. testlib checker _pe)
+	StatusPresentationError
+
+	// killed by number of sockets held exceeded
+	StatusSocketLimitExceeded
+
+	// not run since a previous cmd failed (e.g. stop on failure)
+	StatusSkipped
+
+	// not run since the environment was not acquired in time
+	StatusQueueTimeout
+
+	// not reaped within the reap deadline after killed (hang on exit)
+	StatusReapTimeout
 )
 
 var statusToString = []string{
@@ -47,6 +82,19 @@ var statusToString = []string{
 	"Judgement Failed",
 	"Invalid Interaction",
 	"Internal Error",
+	"Syscall Rate Exceeded",
+	"Write Limit Exceeded",
+	"File Limit Exceeded",
+	"File Size Limit Exceeded",
+	"Segmentation Fault",
+	"Floating Point Exception",
+	"Aborted",
+	"Instruction Limit Exceeded",
+	"Presentation Error",
+	"Socket Limit Exceeded",
+	"Skipped",
+	"Queue Timeout",
+	"Reap Timeout",
 	"CGroup Error",
 	"Container Error",
 }
@@ -58,3 +106,13 @@ func (s Status) String() string {
 	}
 	return statusToString[si]
 }
+
+// ParseStatus returns the status with the given string representation
+func ParseStatus(str string) (Status, bool) {
+	for i := StatusInvalid; i <= StatusReapTimeout; i++ {
+		if i.String() == str {
+			return i, true
+		}
+	}
+	return StatusInvalid, false
+}
@@ -0,0 +1,123 @@
+package envexec
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Sequence defines the running instruction to run the cmds one after another
+// in the same environment (e.g. a binary against multiple inputs), the work
+// dir is cleaned between runs except the kept files (e.g. the binary copied
+// in by the first cmd)
+type Sequence struct {
+	// EnvironmentPool defines pool used for runner environment
+	EnvironmentPool EnvironmentPool
+
+	// Cmd defines Cmd running in order in the same environment
+	Cmd []*Cmd
+
+	// Keep names the files in the work dir kept between runs
+	Keep []string
+
+	// ResultCallback is called with the cmd index and its result as soon as
+	// the cmd finishes (optional)
+	ResultCallback func(int, Result)
+
+	// StopOnFailure skips the rest cmds once a cmd is not accepted
+	StopOnFailure bool
+}
+
+// Run starts the cmds in order and returns exec results, the cmds not run
+// since the environment was discarded (reap timeout) or the context is done
+// are internal error, the cmds after failure with StopOnFailure are skipped
+func (s *Sequence) Run(ctx context.Context) ([]Result, error) {
+	results := make([]Result, len(s.Cmd))
+	if len(s.Cmd) == 0 {
+		return results, nil
+	}
+
+	// prepare environment
+	envStart := time.Now()
+	m, err := s.EnvironmentPool.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get environment %w", err)
+	}
+	var discard, broken bool
+	defer func() { putEnvironment(s.EnvironmentPool, m, discard || broken) }()
+	envTime := time.Since(envStart)
+
+	keep := make(map[string]bool, len(s.Keep))
+	for _, n := range s.Keep {
+		keep[n] = true
+	}
+	var failed bool
+	for i, c := range s.Cmd {
+		if failed {
+			results[i] = Result{
+				Status: StatusSkipped,
+				Error:  "not run: previous cmd failed",
+			}
+			s.callback(i, results[i])
+			continue
+		}
+		if reason := s.skipped(ctx, discard); reason != "" {
+			results[i] = Result{
+				Status: StatusInternalError,
+				Error:  "not run: " + reason,
+			}
+			s.callback(i, results[i])
+			continue
+		}
+		if i > 0 {
+			if err := removeDirContents(m.WorkDir(), keep); err != nil {
+				return nil, fmt.Errorf("failed to clean work dir %v", err)
+			}
+		}
+		c.observeStage(StageEnvironmentAcquired)
+		result, err := s.runOne(ctx, m, c)
+		if i == 0 {
+			result.EnvironmentTime = envTime
+		}
+		if err != nil {
+			return nil, err
+		}
+		results[i] = result
+		discard = result.ReapTimeout
+		broken = broken || discardResult(result)
+		failed = s.StopOnFailure && result.Status != StatusAccepted
+		s.callback(i, result)
+	}
+	return results, nil
+}
+
+func (s *Sequence) runOne(ctx context.Context, m Environment, c *Cmd) (Result, error) {
+	fd, fileToClose, pipeToCollect, err := prepareCmdFd(c, len(c.Files))
+	if err != nil {
+		closeFiles(fileToClose)
+		return Result{}, err
+	}
+	result, err := runSingle(ctx, m, c, fd, pipeToCollect)
+	if err != nil {
+		result.Status = StatusInternalError
+		result.Error = err.Error()
+	}
+	return result, nil
+}
+
+// skipped returns why the rest cmds are not run (empty if to run)
+func (s *Sequence) skipped(ctx context.Context, discard bool) string {
+	switch {
+	case discard:
+		return "environment discarded after reap timeout"
+	case ctx.Err() != nil:
+		return ctx.Err().Error()
+	}
+	return ""
+}
+
+func (s *Sequence) callback(i int, r Result) {
+	if s.ResultCallback != nil {
+		s.ResultCallback(i, r)
+	}
+}
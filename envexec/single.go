@@ -3,6 +3,7 @@ package envexec
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 // Single defines the running instruction to run single
@@ -26,13 +27,17 @@ func (s *Single) Run(ctx context.Context) (result Result, err error) {
 	}
 
 	// prepare environment
+	envStart := time.Now()
 	m, err := s.EnvironmentPool.Get()
 	if err != nil {
-		return result, fmt.Errorf("failed to get environment %v", err)
+		return result, fmt.Errorf("failed to get environment %w", err)
 	}
-	defer s.EnvironmentPool.Put(m)
+	defer func() { putEnvironment(s.EnvironmentPool, m, discardResult(result)) }()
+	envTime := time.Since(envStart)
+	s.Cmd.observeStage(StageEnvironmentAcquired)
 
 	result, err = runSingle(ctx, m, s.Cmd, fd, pipeToCollect)
+	result.EnvironmentTime = envTime
 	fileToClose = nil // already closed by runOne
 	if err != nil {
 		result.Status = StatusInternalError
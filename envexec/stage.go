@@ -0,0 +1,18 @@
+package envexec
+
+// Stage defines the run stage of the cmd reported to Cmd.StageObserver
+type Stage int
+
+// Defines run stages
+const (
+	StageEnvironmentAcquired Stage = iota + 1 // got environment from pool
+	StageExecStarted                          // program started
+	StageExited                               // program exited
+)
+
+// observeStage reports the stage if the observer presents
+func (c *Cmd) observeStage(s Stage) {
+	if c.StageObserver != nil {
+		c.StageObserver(s)
+	}
+}
@@ -0,0 +1,58 @@
+package envexec
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/criyle/go-judge/file"
+)
+
+// stdinTee feeds the stdin into the program over a pipe and collects at most
+// max bytes of it, the rest is discarded thus the program is never blocked by
+// the collected buffer
+type stdinTee struct {
+	name string
+	max  int64
+
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+// newStdinTee returns the pipe read end to replace the stdin of the program,
+// the stdin is closed once it reaches EOF or the program closed the pipe
+func newStdinTee(p PipeCollector, stdin *os.File) (*stdinTee, *os.File, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, nil, err
+	}
+	t := &stdinTee{name: p.Name, max: p.SizeLimit}
+	go func() {
+		defer stdin.Close()
+		defer w.Close()
+		io.Copy(w, io.TeeReader(stdin, t))
+	}()
+	return t, r, nil
+}
+
+func (t *stdinTee) Write(b []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if remain := t.max - int64(t.buf.Len()); remain > 0 {
+		if int64(len(b)) > remain {
+			t.buf.Write(b[:remain])
+		} else {
+			t.buf.Write(b)
+		}
+	}
+	return len(b), nil
+}
+
+// file returns the input collected so far
+func (t *stdinTee) file() file.File {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return file.NewMemFile(t.name, append([]byte(nil), t.buf.Bytes()...))
+}
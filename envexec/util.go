@@ -29,6 +29,19 @@ func convertStatus(s runner.Status) Status {
 	}
 }
 
+// terminatedSignal returns the signal terminated the program, the runner
+// reports the signal as exit status with SIGKILL / SIGXCPU as TLE, SIGXFSZ as
+// OLE and SIGSYS as disallowed syscall
+func terminatedSignal(rt runner.Result) (int, bool) {
+	switch rt.Status {
+	case runner.StatusSignalled, runner.StatusTimeLimitExceeded,
+		runner.StatusOutputLimitExceeded, runner.StatusDisallowedSyscall:
+		return rt.ExitStatus, rt.ExitStatus > 0
+	default:
+		return 0, false
+	}
+}
+
 func getFdArray(fd []*os.File) []uintptr {
 	r := make([]uintptr, 0, len(fd))
 	for _, f := range fd {
@@ -16,6 +16,17 @@ type ReaderOpener interface {
 	Reader() (io.ReadCloser, error)
 }
 
+// ReadSeekerOpener creates seekable reader for range reading (optional)
+type ReadSeekerOpener interface {
+	ReadSeeker() (io.ReadSeekCloser, error)
+}
+
+// Linker reports the local file preferred to be linked into the work dir as
+// a read-only view instead of copied (optional)
+type Linker interface {
+	LinkPath() (string, bool)
+}
+
 // File defines file name with its content
 // file could on file system or memory
 type File interface {
@@ -6,11 +6,16 @@ import (
 	"os"
 )
 
-var _ File = &localFile{}
+var (
+	_ File             = &localFile{}
+	_ ReadSeekerOpener = &localFile{}
+	_ Linker           = &localFile{}
+)
 
 // localFile stores a path to represent a local file
 type localFile struct {
 	name, path string
+	link       bool
 }
 
 // NewLocalFile creates a wrapper to file system by path
@@ -21,6 +26,21 @@ func NewLocalFile(name, path string) File {
 	}
 }
 
+// NewLinkFile creates a wrapper to file system by path, it is linked into the
+// work dir instead of copied if possible (e.g. shared dataset)
+func NewLinkFile(name, path string) File {
+	return &localFile{
+		name: name,
+		path: path,
+		link: true,
+	}
+}
+
+// LinkPath returns the path if the file is preferred to be linked
+func (f *localFile) LinkPath() (string, bool) {
+	return f.path, f.link
+}
+
 func (f *localFile) Name() string {
 	return f.name
 }
@@ -40,3 +60,7 @@ func (f *localFile) String() string {
 func (f *localFile) Reader() (io.ReadCloser, error) {
 	return os.Open(f.path)
 }
+
+func (f *localFile) ReadSeeker() (io.ReadSeekCloser, error) {
+	return os.Open(f.path)
+}
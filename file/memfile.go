@@ -6,7 +6,10 @@ import (
 	"io"
 )
 
-var _ File = &memFile{}
+var (
+	_ File             = &memFile{}
+	_ ReadSeekerOpener = &memFile{}
+)
 
 // memFile represent a file like byte array
 type memFile struct {
@@ -37,3 +40,15 @@ func (m *memFile) String() string {
 func (m *memFile) Reader() (io.ReadCloser, error) {
 	return io.NopCloser(bytes.NewReader(m.content)), nil
 }
+
+func (m *memFile) ReadSeeker() (io.ReadSeekCloser, error) {
+	return nopSeekCloser{bytes.NewReader(m.content)}, nil
+}
+
+type nopSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (nopSeekCloser) Close() error {
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path"
 	"sync"
+	"time"
 
 	"github.com/criyle/go-judge/file"
 )
@@ -79,6 +80,15 @@ func (s *fileLocalStore) Remove(id string) bool {
 	return true
 }
 
+// ModTime returns the mtime of the file
+func (s *fileLocalStore) ModTime(id string) (time.Time, bool) {
+	fi, err := os.Stat(path.Join(s.dir, id))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return fi.ModTime(), true
+}
+
 func (s *fileLocalStore) List() []string {
 	var names []string
 	fi, err := os.ReadDir(s.dir)
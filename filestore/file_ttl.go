@@ -0,0 +1,136 @@
+package filestore
+
+import (
+	"sync"
+	"time"
+
+	"github.com/criyle/go-judge/file"
+)
+
+// ttlCheckInterval defines how often the expired files are removed
+const ttlCheckInterval = time.Second
+
+var _ TTLFileStore = &fileTTLStore{}
+
+type fileTTLStore struct {
+	FileStore
+	ttl    time.Duration        // default ttl for file added, 0 never expires
+	expire map[string]time.Time // id to expire time mapping if exists
+	mu     sync.Mutex
+
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// modTimer is optionally implemented by FileStore to report the time the
+// file was added (e.g. the mtime of the local file)
+type modTimer interface {
+	ModTime(string) (time.Time, bool)
+}
+
+// NewTTLStore wraps the file store to remove files after their time-to-live,
+// ttl is the default time-to-live for files added by Add (0 never expires).
+// The expire times are kept in memory, files of the dir store left by restart
+// expire by the default ttl since their mtime
+func NewTTLStore(fs FileStore, ttl time.Duration) TTLFileStore {
+	s := &fileTTLStore{
+		FileStore: fs,
+		ttl:       ttl,
+		expire:    make(map[string]time.Time),
+		done:      make(chan struct{}),
+	}
+	if mt, ok := fs.(modTimer); ok && ttl > 0 {
+		for _, id := range fs.List() {
+			if t, ok := mt.ModTime(id); ok {
+				s.expire[id] = t.Add(ttl)
+			}
+		}
+	}
+	go s.loop()
+	return s
+}
+
+func (s *fileTTLStore) Add(name string, content []byte) (string, error) {
+	return s.AddWithTTL(name, content, s.ttl)
+}
+
+func (s *fileTTLStore) AddWithTTL(name string, content []byte, ttl time.Duration) (string, error) {
+	id, err := s.FileStore.Add(name, content)
+	if err != nil {
+		return "", err
+	}
+	if ttl > 0 {
+		s.mu.Lock()
+		s.expire[id] = time.Now().Add(ttl)
+		s.mu.Unlock()
+	}
+	return id, nil
+}
+
+func (s *fileTTLStore) Get(id string) file.File {
+	if s.expired(id, time.Now()) {
+		s.Remove(id)
+		return nil
+	}
+	return s.FileStore.Get(id)
+}
+
+func (s *fileTTLStore) Remove(id string) bool {
+	s.mu.Lock()
+	delete(s.expire, id)
+	s.mu.Unlock()
+
+	return s.FileStore.Remove(id)
+}
+
+func (s *fileTTLStore) List() []string {
+	now := time.Now()
+	ids := s.FileStore.List()
+	rt := ids[:0]
+	for _, id := range ids {
+		if !s.expired(id, now) {
+			rt = append(rt, id)
+		}
+	}
+	return rt
+}
+
+func (s *fileTTLStore) Shutdown() {
+	s.stopOnce.Do(func() {
+		close(s.done)
+	})
+}
+
+func (s *fileTTLStore) expired(id string, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.expire[id]
+	return ok && now.After(t)
+}
+
+func (s *fileTTLStore) loop() {
+	ticker := time.NewTicker(ttlCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+
+		case now := <-ticker.C:
+			var ids []string
+			s.mu.Lock()
+			for id, t := range s.expire {
+				if now.After(t) {
+					ids = append(ids, id)
+				}
+			}
+			s.mu.Unlock()
+
+			for _, id := range ids {
+				s.Remove(id)
+			}
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"crypto/rand"
 	"encoding/base32"
+	"time"
 
 	"github.com/criyle/go-judge/file"
 )
@@ -18,6 +19,13 @@ type FileStore interface {
 	List() []string                     // List return all file ids
 }
 
+// TTLFileStore defines file store that removes files after their time-to-live
+type TTLFileStore interface {
+	FileStore
+	AddWithTTL(string, []byte, time.Duration) (string, error) // AddWithTTL creates a file that expires after ttl (0 never expires)
+	Shutdown()                                                // Shutdown stops removing expired files
+}
+
 func generateID() (string, error) {
 	b := make([]byte, randIDLength)
 	if _, err := rand.Read(b); err != nil {
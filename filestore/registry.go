@@ -0,0 +1,69 @@
+package filestore
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+)
+
+// Factory creates a FileStore by its config string (e.g. the directory)
+type Factory func(config string) (FileStore, error)
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = make(map[string]Factory)
+)
+
+func init() {
+	Register("memory", func(string) (FileStore, error) {
+		return NewFileMemoryStore(), nil
+	})
+	Register("local", func(dir string) (FileStore, error) {
+		if dir == "" {
+			return nil, fmt.Errorf("local file store requires a directory")
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, err
+		}
+		return NewFileLocalStore(dir), nil
+	})
+}
+
+// Register makes a FileStore implementation available by name, it is
+// intended to be called from the init function of the implementing package
+// and panics if the name is registered twice or the factory is nil
+func Register(name string, f Factory) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+	if f == nil {
+		panic("filestore: Register factory is nil")
+	}
+	if _, dup := factories[name]; dup {
+		panic("filestore: Register called twice for " + name)
+	}
+	factories[name] = f
+}
+
+// New creates the FileStore registered by name with its config string
+func New(name, config string) (FileStore, error) {
+	factoriesMu.RLock()
+	f, ok := factories[name]
+	factoriesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("filestore: unknown file store %q (registered: %v)", name, Types())
+	}
+	return f(config)
+}
+
+// Types returns the sorted names of the registered file stores
+func Types() []string {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+	rt := make([]string, 0, len(factories))
+	for n := range factories {
+		rt = append(rt, n)
+	}
+	sort.Strings(rt)
+	return rt
+}
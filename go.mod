@@ -28,6 +28,7 @@ require (
 	github.com/prometheus/procfs v0.6.0 // indirect
 	github.com/sirupsen/logrus v1.8.0 // indirect
 	github.com/ugorji/go v1.2.4 // indirect
+	github.com/ugorji/go/codec v1.2.4
 	github.com/zsais/go-gin-prometheus v0.1.0
 	go.uber.org/multierr v1.6.0 // indirect
 	go.uber.org/zap v1.16.0
@@ -37,7 +38,7 @@ require (
 	golang.org/x/sync v0.0.0-20210220032951-036812b2e83c
 	golang.org/x/sys v0.0.0-20210223212115-eede4237b368
 	golang.org/x/term v0.0.0-20210220032956-6a3ed077a48d // indirect
-	google.golang.org/genproto v0.0.0-20210223151946-22b48be4551b // indirect
+	google.golang.org/genproto v0.0.0-20210223151946-22b48be4551b
 	google.golang.org/grpc v1.35.0
 	google.golang.org/grpc/examples v0.0.0-20210218181225-26c143bd5f59 // indirect
 	google.golang.org/protobuf v1.25.0
@@ -26,23 +26,281 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+type Request_PipeCollector_PolicyType int32
+
+const (
+	Request_PipeCollector_PolicyFail     Request_PipeCollector_PolicyType = 0
+	Request_PipeCollector_PolicyTruncate Request_PipeCollector_PolicyType = 1
+	Request_PipeCollector_PolicyKill     Request_PipeCollector_PolicyType = 2
+)
+
+// Enum value maps for Request_PipeCollector_PolicyType.
+var (
+	Request_PipeCollector_PolicyType_name = map[int32]string{
+		0: "PolicyFail",
+		1: "PolicyTruncate",
+		2: "PolicyKill",
+	}
+	Request_PipeCollector_PolicyType_value = map[string]int32{
+		"PolicyFail":     0,
+		"PolicyTruncate": 1,
+		"PolicyKill":     2,
+	}
+)
+
+func (x Request_PipeCollector_PolicyType) Enum() *Request_PipeCollector_PolicyType {
+	p := new(Request_PipeCollector_PolicyType)
+	*p = x
+	return p
+}
+
+func (x Request_PipeCollector_PolicyType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Request_PipeCollector_PolicyType) Descriptor() protoreflect.EnumDescriptor {
+	return file_judge_proto_enumTypes[0].Descriptor()
+}
+
+func (Request_PipeCollector_PolicyType) Type() protoreflect.EnumType {
+	return &file_judge_proto_enumTypes[0]
+}
+
+func (x Request_PipeCollector_PolicyType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Request_PipeCollector_PolicyType.Descriptor instead.
+func (Request_PipeCollector_PolicyType) EnumDescriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 5, 0}
+}
+
+type Request_CmdType_MemoryAccountingType int32
+
+const (
+	Request_CmdType_MemoryPeak    Request_CmdType_MemoryAccountingType = 0
+	Request_CmdType_MemoryCurrent Request_CmdType_MemoryAccountingType = 1
+)
+
+// Enum value maps for Request_CmdType_MemoryAccountingType.
+var (
+	Request_CmdType_MemoryAccountingType_name = map[int32]string{
+		0: "MemoryPeak",
+		1: "MemoryCurrent",
+	}
+	Request_CmdType_MemoryAccountingType_value = map[string]int32{
+		"MemoryPeak":    0,
+		"MemoryCurrent": 1,
+	}
+)
+
+func (x Request_CmdType_MemoryAccountingType) Enum() *Request_CmdType_MemoryAccountingType {
+	p := new(Request_CmdType_MemoryAccountingType)
+	*p = x
+	return p
+}
+
+func (x Request_CmdType_MemoryAccountingType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Request_CmdType_MemoryAccountingType) Descriptor() protoreflect.EnumDescriptor {
+	return file_judge_proto_enumTypes[1].Descriptor()
+}
+
+func (Request_CmdType_MemoryAccountingType) Type() protoreflect.EnumType {
+	return &file_judge_proto_enumTypes[1]
+}
+
+func (x Request_CmdType_MemoryAccountingType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Request_CmdType_MemoryAccountingType.Descriptor instead.
+func (Request_CmdType_MemoryAccountingType) EnumDescriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 10, 0}
+}
+
+type Request_CmdType_StdoutBufferingType int32
+
+const (
+	Request_CmdType_BufferingDefault Request_CmdType_StdoutBufferingType = 0
+	Request_CmdType_BufferingLine    Request_CmdType_StdoutBufferingType = 1
+	Request_CmdType_BufferingNone    Request_CmdType_StdoutBufferingType = 2
+)
+
+// Enum value maps for Request_CmdType_StdoutBufferingType.
+var (
+	Request_CmdType_StdoutBufferingType_name = map[int32]string{
+		0: "BufferingDefault",
+		1: "BufferingLine",
+		2: "BufferingNone",
+	}
+	Request_CmdType_StdoutBufferingType_value = map[string]int32{
+		"BufferingDefault": 0,
+		"BufferingLine":    1,
+		"BufferingNone":    2,
+	}
+)
+
+func (x Request_CmdType_StdoutBufferingType) Enum() *Request_CmdType_StdoutBufferingType {
+	p := new(Request_CmdType_StdoutBufferingType)
+	*p = x
+	return p
+}
+
+func (x Request_CmdType_StdoutBufferingType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Request_CmdType_StdoutBufferingType) Descriptor() protoreflect.EnumDescriptor {
+	return file_judge_proto_enumTypes[2].Descriptor()
+}
+
+func (Request_CmdType_StdoutBufferingType) Type() protoreflect.EnumType {
+	return &file_judge_proto_enumTypes[2]
+}
+
+func (x Request_CmdType_StdoutBufferingType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Request_CmdType_StdoutBufferingType.Descriptor instead.
+func (Request_CmdType_StdoutBufferingType) EnumDescriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 10, 1}
+}
+
+type Request_CmdType_TimeAccountingType int32
+
+const (
+	Request_CmdType_TimeCgroup  Request_CmdType_TimeAccountingType = 0
+	Request_CmdType_TimeProcess Request_CmdType_TimeAccountingType = 1
+)
+
+// Enum value maps for Request_CmdType_TimeAccountingType.
+var (
+	Request_CmdType_TimeAccountingType_name = map[int32]string{
+		0: "TimeCgroup",
+		1: "TimeProcess",
+	}
+	Request_CmdType_TimeAccountingType_value = map[string]int32{
+		"TimeCgroup":  0,
+		"TimeProcess": 1,
+	}
+)
+
+func (x Request_CmdType_TimeAccountingType) Enum() *Request_CmdType_TimeAccountingType {
+	p := new(Request_CmdType_TimeAccountingType)
+	*p = x
+	return p
+}
+
+func (x Request_CmdType_TimeAccountingType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Request_CmdType_TimeAccountingType) Descriptor() protoreflect.EnumDescriptor {
+	return file_judge_proto_enumTypes[3].Descriptor()
+}
+
+func (Request_CmdType_TimeAccountingType) Type() protoreflect.EnumType {
+	return &file_judge_proto_enumTypes[3]
+}
+
+func (x Request_CmdType_TimeAccountingType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Request_CmdType_TimeAccountingType.Descriptor instead.
+func (Request_CmdType_TimeAccountingType) EnumDescriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 10, 2}
+}
+
+type Request_Compare_PolicyType int32
+
+const (
+	Request_Compare_Exact            Request_Compare_PolicyType = 0
+	Request_Compare_TrimTrailing     Request_Compare_PolicyType = 1 // trailing whitespace of lines and empty lines
+	Request_Compare_IgnoreWhitespace Request_Compare_PolicyType = 2 // all whitespace
+	Request_Compare_Token            Request_Compare_PolicyType = 3 // whitespace separated tokens
+	Request_Compare_Float            Request_Compare_PolicyType = 4 // tokens, numbers within epsilon
+)
+
+// Enum value maps for Request_Compare_PolicyType.
+var (
+	Request_Compare_PolicyType_name = map[int32]string{
+		0: "Exact",
+		1: "TrimTrailing",
+		2: "IgnoreWhitespace",
+		3: "Token",
+		4: "Float",
+	}
+	Request_Compare_PolicyType_value = map[string]int32{
+		"Exact":            0,
+		"TrimTrailing":     1,
+		"IgnoreWhitespace": 2,
+		"Token":            3,
+		"Float":            4,
+	}
+)
+
+func (x Request_Compare_PolicyType) Enum() *Request_Compare_PolicyType {
+	p := new(Request_Compare_PolicyType)
+	*p = x
+	return p
+}
+
+func (x Request_Compare_PolicyType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Request_Compare_PolicyType) Descriptor() protoreflect.EnumDescriptor {
+	return file_judge_proto_enumTypes[4].Descriptor()
+}
+
+func (Request_Compare_PolicyType) Type() protoreflect.EnumType {
+	return &file_judge_proto_enumTypes[4]
+}
+
+func (x Request_Compare_PolicyType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Request_Compare_PolicyType.Descriptor instead.
+func (Request_Compare_PolicyType) EnumDescriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 15, 0}
+}
+
 type Response_Result_StatusType int32
 
 const (
-	Response_Result_Invalid             Response_Result_StatusType = 0
-	Response_Result_Accepted            Response_Result_StatusType = 1
-	Response_Result_WrongAnswer         Response_Result_StatusType = 2 // Not used
-	Response_Result_PartiallyCorrect    Response_Result_StatusType = 3 // Not used
-	Response_Result_MemoryLimitExceeded Response_Result_StatusType = 4
-	Response_Result_TimeLimitExceeded   Response_Result_StatusType = 5
-	Response_Result_OutputLimitExceeded Response_Result_StatusType = 6
-	Response_Result_FileError           Response_Result_StatusType = 7
-	Response_Result_NonZeroExitStatus   Response_Result_StatusType = 8
-	Response_Result_Signalled           Response_Result_StatusType = 9
-	Response_Result_DangerousSyscall    Response_Result_StatusType = 10
-	Response_Result_JudgementFailed     Response_Result_StatusType = 11 // Not used
-	Response_Result_InvalidInteraction  Response_Result_StatusType = 12 // Not used
-	Response_Result_InternalError       Response_Result_StatusType = 13
+	Response_Result_Invalid                  Response_Result_StatusType = 0
+	Response_Result_Accepted                 Response_Result_StatusType = 1
+	Response_Result_WrongAnswer              Response_Result_StatusType = 2 // Not used
+	Response_Result_PartiallyCorrect         Response_Result_StatusType = 3 // Not used
+	Response_Result_MemoryLimitExceeded      Response_Result_StatusType = 4
+	Response_Result_TimeLimitExceeded        Response_Result_StatusType = 5
+	Response_Result_OutputLimitExceeded      Response_Result_StatusType = 6
+	Response_Result_FileError                Response_Result_StatusType = 7
+	Response_Result_NonZeroExitStatus        Response_Result_StatusType = 8
+	Response_Result_Signalled                Response_Result_StatusType = 9
+	Response_Result_DangerousSyscall         Response_Result_StatusType = 10
+	Response_Result_JudgementFailed          Response_Result_StatusType = 11 // Not used
+	Response_Result_InvalidInteraction       Response_Result_StatusType = 12 // Not used
+	Response_Result_InternalError            Response_Result_StatusType = 13
+	Response_Result_SyscallRateExceeded      Response_Result_StatusType = 14
+	Response_Result_WriteLimitExceeded       Response_Result_StatusType = 15
+	Response_Result_FileLimitExceeded        Response_Result_StatusType = 16
+	Response_Result_FileSizeLimitExceeded    Response_Result_StatusType = 17
+	Response_Result_SegmentationFault        Response_Result_StatusType = 18
+	Response_Result_FloatingPointException   Response_Result_StatusType = 19
+	Response_Result_Aborted                  Response_Result_StatusType = 20
+	Response_Result_InstructionLimitExceeded Response_Result_StatusType = 21
+	Response_Result_PresentationError        Response_Result_StatusType = 22
+	Response_Result_SocketLimitExceeded      Response_Result_StatusType = 23
+	Response_Result_Skipped                  Response_Result_StatusType = 24
+	Response_Result_QueueTimeout             Response_Result_StatusType = 25
+	Response_Result_ReapTimeout              Response_Result_StatusType = 26
 )
 
 // Enum value maps for Response_Result_StatusType.
@@ -62,22 +320,48 @@ var (
 		11: "JudgementFailed",
 		12: "InvalidInteraction",
 		13: "InternalError",
+		14: "SyscallRateExceeded",
+		15: "WriteLimitExceeded",
+		16: "FileLimitExceeded",
+		17: "FileSizeLimitExceeded",
+		18: "SegmentationFault",
+		19: "FloatingPointException",
+		20: "Aborted",
+		21: "InstructionLimitExceeded",
+		22: "PresentationError",
+		23: "SocketLimitExceeded",
+		24: "Skipped",
+		25: "QueueTimeout",
+		26: "ReapTimeout",
 	}
 	Response_Result_StatusType_value = map[string]int32{
-		"Invalid":             0,
-		"Accepted":            1,
-		"WrongAnswer":         2,
-		"PartiallyCorrect":    3,
-		"MemoryLimitExceeded": 4,
-		"TimeLimitExceeded":   5,
-		"OutputLimitExceeded": 6,
-		"FileError":           7,
-		"NonZeroExitStatus":   8,
-		"Signalled":           9,
-		"DangerousSyscall":    10,
-		"JudgementFailed":     11,
-		"InvalidInteraction":  12,
-		"InternalError":       13,
+		"Invalid":                  0,
+		"Accepted":                 1,
+		"WrongAnswer":              2,
+		"PartiallyCorrect":         3,
+		"MemoryLimitExceeded":      4,
+		"TimeLimitExceeded":        5,
+		"OutputLimitExceeded":      6,
+		"FileError":                7,
+		"NonZeroExitStatus":        8,
+		"Signalled":                9,
+		"DangerousSyscall":         10,
+		"JudgementFailed":          11,
+		"InvalidInteraction":       12,
+		"InternalError":            13,
+		"SyscallRateExceeded":      14,
+		"WriteLimitExceeded":       15,
+		"FileLimitExceeded":        16,
+		"FileSizeLimitExceeded":    17,
+		"SegmentationFault":        18,
+		"FloatingPointException":   19,
+		"Aborted":                  20,
+		"InstructionLimitExceeded": 21,
+		"PresentationError":        22,
+		"SocketLimitExceeded":      23,
+		"Skipped":                  24,
+		"QueueTimeout":             25,
+		"ReapTimeout":              26,
 	}
 )
 
@@ -92,11 +376,11 @@ func (x Response_Result_StatusType) String() string {
 }
 
 func (Response_Result_StatusType) Descriptor() protoreflect.EnumDescriptor {
-	return file_judge_proto_enumTypes[0].Descriptor()
+	return file_judge_proto_enumTypes[5].Descriptor()
 }
 
 func (Response_Result_StatusType) Type() protoreflect.EnumType {
-	return &file_judge_proto_enumTypes[0]
+	return &file_judge_proto_enumTypes[5]
 }
 
 func (x Response_Result_StatusType) Number() protoreflect.EnumNumber {
@@ -105,7 +389,209 @@ func (x Response_Result_StatusType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Response_Result_StatusType.Descriptor instead.
 func (Response_Result_StatusType) EnumDescriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{4, 0, 0}
+	return file_judge_proto_rawDescGZIP(), []int{5, 0, 0}
+}
+
+type Response_Result_ExitReasonType int32
+
+const (
+	Response_Result_ExitReasonInvalid      Response_Result_ExitReasonType = 0
+	Response_Result_ExitReasonNormal       Response_Result_ExitReasonType = 1
+	Response_Result_ExitReasonTimeLimit    Response_Result_ExitReasonType = 2
+	Response_Result_ExitReasonMemoryLimit  Response_Result_ExitReasonType = 3
+	Response_Result_ExitReasonOtherLimit   Response_Result_ExitReasonType = 4
+	Response_Result_ExitReasonKilledByPeer Response_Result_ExitReasonType = 5
+	Response_Result_ExitReasonCancelled    Response_Result_ExitReasonType = 6
+	Response_Result_ExitReasonSignalled    Response_Result_ExitReasonType = 7
+)
+
+// Enum value maps for Response_Result_ExitReasonType.
+var (
+	Response_Result_ExitReasonType_name = map[int32]string{
+		0: "ExitReasonInvalid",
+		1: "ExitReasonNormal",
+		2: "ExitReasonTimeLimit",
+		3: "ExitReasonMemoryLimit",
+		4: "ExitReasonOtherLimit",
+		5: "ExitReasonKilledByPeer",
+		6: "ExitReasonCancelled",
+		7: "ExitReasonSignalled",
+	}
+	Response_Result_ExitReasonType_value = map[string]int32{
+		"ExitReasonInvalid":      0,
+		"ExitReasonNormal":       1,
+		"ExitReasonTimeLimit":    2,
+		"ExitReasonMemoryLimit":  3,
+		"ExitReasonOtherLimit":   4,
+		"ExitReasonKilledByPeer": 5,
+		"ExitReasonCancelled":    6,
+		"ExitReasonSignalled":    7,
+	}
+)
+
+func (x Response_Result_ExitReasonType) Enum() *Response_Result_ExitReasonType {
+	p := new(Response_Result_ExitReasonType)
+	*p = x
+	return p
+}
+
+func (x Response_Result_ExitReasonType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Response_Result_ExitReasonType) Descriptor() protoreflect.EnumDescriptor {
+	return file_judge_proto_enumTypes[6].Descriptor()
+}
+
+func (Response_Result_ExitReasonType) Type() protoreflect.EnumType {
+	return &file_judge_proto_enumTypes[6]
+}
+
+func (x Response_Result_ExitReasonType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Response_Result_ExitReasonType.Descriptor instead.
+func (Response_Result_ExitReasonType) EnumDescriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{5, 0, 1}
+}
+
+type Response_Result_ProcLimitHitType int32
+
+const (
+	Response_Result_ProcLimitHitNone  Response_Result_ProcLimitHitType = 0
+	Response_Result_ProcLimitHitPids  Response_Result_ProcLimitHitType = 1 // cgroup pids limit (procLimit)
+	Response_Result_ProcLimitHitNproc Response_Result_ProcLimitHitType = 2 // RLIMIT_NPROC (nprocLimit), estimated
+)
+
+// Enum value maps for Response_Result_ProcLimitHitType.
+var (
+	Response_Result_ProcLimitHitType_name = map[int32]string{
+		0: "ProcLimitHitNone",
+		1: "ProcLimitHitPids",
+		2: "ProcLimitHitNproc",
+	}
+	Response_Result_ProcLimitHitType_value = map[string]int32{
+		"ProcLimitHitNone":  0,
+		"ProcLimitHitPids":  1,
+		"ProcLimitHitNproc": 2,
+	}
+)
+
+func (x Response_Result_ProcLimitHitType) Enum() *Response_Result_ProcLimitHitType {
+	p := new(Response_Result_ProcLimitHitType)
+	*p = x
+	return p
+}
+
+func (x Response_Result_ProcLimitHitType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Response_Result_ProcLimitHitType) Descriptor() protoreflect.EnumDescriptor {
+	return file_judge_proto_enumTypes[7].Descriptor()
+}
+
+func (Response_Result_ProcLimitHitType) Type() protoreflect.EnumType {
+	return &file_judge_proto_enumTypes[7]
+}
+
+func (x Response_Result_ProcLimitHitType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Response_Result_ProcLimitHitType.Descriptor instead.
+func (Response_Result_ProcLimitHitType) EnumDescriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{5, 0, 2}
+}
+
+type VersionInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BuildVersion string `protobuf:"bytes,1,opt,name=buildVersion,proto3" json:"buildVersion,omitempty"`
+	GoVersion    string `protobuf:"bytes,2,opt,name=goVersion,proto3" json:"goVersion,omitempty"`
+	Platform     string `protobuf:"bytes,3,opt,name=platform,proto3" json:"platform,omitempty"`
+	Os           string `protobuf:"bytes,4,opt,name=os,proto3" json:"os,omitempty"`
+	// number of cmds executed concurrently
+	Parallelism int32 `protobuf:"varint,5,opt,name=parallelism,proto3" json:"parallelism,omitempty"`
+	// optional features enabled by the server config (e.g. compileCache)
+	Features []string `protobuf:"bytes,6,rep,name=features,proto3" json:"features,omitempty"`
+}
+
+func (x *VersionInfo) Reset() {
+	*x = VersionInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_judge_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VersionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionInfo) ProtoMessage() {}
+
+func (x *VersionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VersionInfo.ProtoReflect.Descriptor instead.
+func (*VersionInfo) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *VersionInfo) GetBuildVersion() string {
+	if x != nil {
+		return x.BuildVersion
+	}
+	return ""
+}
+
+func (x *VersionInfo) GetGoVersion() string {
+	if x != nil {
+		return x.GoVersion
+	}
+	return ""
+}
+
+func (x *VersionInfo) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *VersionInfo) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *VersionInfo) GetParallelism() int32 {
+	if x != nil {
+		return x.Parallelism
+	}
+	return 0
+}
+
+func (x *VersionInfo) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
 }
 
 type FileID struct {
@@ -119,7 +605,7 @@ type FileID struct {
 func (x *FileID) Reset() {
 	*x = FileID{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[0]
+		mi := &file_judge_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -132,7 +618,7 @@ func (x *FileID) String() string {
 func (*FileID) ProtoMessage() {}
 
 func (x *FileID) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[0]
+	mi := &file_judge_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -145,7 +631,7 @@ func (x *FileID) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FileID.ProtoReflect.Descriptor instead.
 func (*FileID) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{0}
+	return file_judge_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *FileID) GetFileID() string {
@@ -162,12 +648,15 @@ type FileContent struct {
 
 	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Content []byte `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	// ttl specifies the time-to-live (ns) of the file for FileAdd (0 uses the
+	// server default), invalid argument if over int64 or ttl not supported
+	Ttl uint64 `protobuf:"varint,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
 }
 
 func (x *FileContent) Reset() {
 	*x = FileContent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[1]
+		mi := &file_judge_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -180,7 +669,7 @@ func (x *FileContent) String() string {
 func (*FileContent) ProtoMessage() {}
 
 func (x *FileContent) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[1]
+	mi := &file_judge_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -193,7 +682,7 @@ func (x *FileContent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FileContent.ProtoReflect.Descriptor instead.
 func (*FileContent) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{1}
+	return file_judge_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *FileContent) GetName() string {
@@ -210,10 +699,17 @@ func (x *FileContent) GetContent() []byte {
 	return nil
 }
 
-type FileListType struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *FileContent) GetTtl() uint64 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
+type FileListType struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
 	FileIDs []string `protobuf:"bytes,1,rep,name=fileIDs,proto3" json:"fileIDs,omitempty"`
 }
@@ -221,7 +717,7 @@ type FileListType struct {
 func (x *FileListType) Reset() {
 	*x = FileListType{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[2]
+		mi := &file_judge_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -234,7 +730,7 @@ func (x *FileListType) String() string {
 func (*FileListType) ProtoMessage() {}
 
 func (x *FileListType) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[2]
+	mi := &file_judge_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -247,7 +743,7 @@ func (x *FileListType) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FileListType.ProtoReflect.Descriptor instead.
 func (*FileListType) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{2}
+	return file_judge_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *FileListType) GetFileIDs() []string {
@@ -265,12 +761,45 @@ type Request struct {
 	RequestID   string             `protobuf:"bytes,1,opt,name=requestID,proto3" json:"requestID,omitempty"`
 	Cmd         []*Request_CmdType `protobuf:"bytes,2,rep,name=cmd,proto3" json:"cmd,omitempty"`
 	PipeMapping []*Request_PipeMap `protobuf:"bytes,3,rep,name=pipeMapping,proto3" json:"pipeMapping,omitempty"`
+	// partialResult only valid in streaming RPC
+	PartialResult bool `protobuf:"varint,4,opt,name=partialResult,proto3" json:"partialResult,omitempty"`
+	// tags label the request for metrics
+	Tags map[string]string `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// debug includes the resolved invocation in results (requires auth)
+	Debug bool `protobuf:"varint,6,opt,name=debug,proto3" json:"debug,omitempty"`
+	// returnFields selects result fields to return (empty returns all), copy
+	// out files are not collected if neither files nor fileIds selected
+	ReturnFields []string `protobuf:"bytes,7,rep,name=returnFields,proto3" json:"returnFields,omitempty"`
+	// image selects the named mount configuration (empty uses the default)
+	Image string `protobuf:"bytes,8,opt,name=image,proto3" json:"image,omitempty"`
+	// fails as busy (ResourceExhausted with retry-after) if no warm environment
+	// of the image is pooled instead of building one
+	WarmOnly bool `protobuf:"varint,17,opt,name=warmOnly,proto3" json:"warmOnly,omitempty"`
+	// seed injected as JUDGE_SEED env (0 means not set), server generates one
+	// if generateSeed without seed
+	Seed         uint64 `protobuf:"varint,9,opt,name=seed,proto3" json:"seed,omitempty"`
+	GenerateSeed bool   `protobuf:"varint,10,opt,name=generateSeed,proto3" json:"generateSeed,omitempty"`
+	// checker runs after all cmds accepted and decides the verdict
+	Checker *Request_Checker `protobuf:"bytes,11,opt,name=checker,proto3" json:"checker,omitempty"`
+	// preserve work dir of programs not accepted (requires auth)
+	PreserveOnFailure bool `protobuf:"varint,12,opt,name=preserveOnFailure,proto3" json:"preserveOnFailure,omitempty"`
+	// wall time (ns) shared by all cmds (e.g. total interaction time),
+	// exceeded kills the cmds still running as TimeLimitExceeded (0 disables)
+	ClockBudget uint64 `protobuf:"varint,13,opt,name=clockBudget,proto3" json:"clockBudget,omitempty"`
+	// wall time (ns) since accepted (queue included) to acquire the
+	// environments, exceeded fails the cmds as QueueTimeout (0 disables)
+	AcquireTimeout uint64           `protobuf:"varint,18,opt,name=acquireTimeout,proto3" json:"acquireTimeout,omitempty"`
+	Inputs         []*Request_Input `protobuf:"bytes,14,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	Compare        *Request_Compare `protobuf:"bytes,15,opt,name=compare,proto3" json:"compare,omitempty"`
+	// skips the rest inputs once the result of an input is not accepted (with
+	// inputs), the skipped results are Skipped
+	StopOnFailure bool `protobuf:"varint,16,opt,name=stopOnFailure,proto3" json:"stopOnFailure,omitempty"`
 }
 
 func (x *Request) Reset() {
 	*x = Request{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[3]
+		mi := &file_judge_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -283,7 +812,7 @@ func (x *Request) String() string {
 func (*Request) ProtoMessage() {}
 
 func (x *Request) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[3]
+	mi := &file_judge_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -296,7 +825,7 @@ func (x *Request) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Request.ProtoReflect.Descriptor instead.
 func (*Request) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{3}
+	return file_judge_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Request) GetRequestID() string {
@@ -320,6 +849,111 @@ func (x *Request) GetPipeMapping() []*Request_PipeMap {
 	return nil
 }
 
+func (x *Request) GetPartialResult() bool {
+	if x != nil {
+		return x.PartialResult
+	}
+	return false
+}
+
+func (x *Request) GetTags() map[string]string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Request) GetDebug() bool {
+	if x != nil {
+		return x.Debug
+	}
+	return false
+}
+
+func (x *Request) GetReturnFields() []string {
+	if x != nil {
+		return x.ReturnFields
+	}
+	return nil
+}
+
+func (x *Request) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *Request) GetWarmOnly() bool {
+	if x != nil {
+		return x.WarmOnly
+	}
+	return false
+}
+
+func (x *Request) GetSeed() uint64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *Request) GetGenerateSeed() bool {
+	if x != nil {
+		return x.GenerateSeed
+	}
+	return false
+}
+
+func (x *Request) GetChecker() *Request_Checker {
+	if x != nil {
+		return x.Checker
+	}
+	return nil
+}
+
+func (x *Request) GetPreserveOnFailure() bool {
+	if x != nil {
+		return x.PreserveOnFailure
+	}
+	return false
+}
+
+func (x *Request) GetClockBudget() uint64 {
+	if x != nil {
+		return x.ClockBudget
+	}
+	return 0
+}
+
+func (x *Request) GetAcquireTimeout() uint64 {
+	if x != nil {
+		return x.AcquireTimeout
+	}
+	return 0
+}
+
+func (x *Request) GetInputs() []*Request_Input {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *Request) GetCompare() *Request_Compare {
+	if x != nil {
+		return x.Compare
+	}
+	return nil
+}
+
+func (x *Request) GetStopOnFailure() bool {
+	if x != nil {
+		return x.StopOnFailure
+	}
+	return false
+}
+
 type Response struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -328,12 +962,27 @@ type Response struct {
 	RequestID string             `protobuf:"bytes,1,opt,name=requestID,proto3" json:"requestID,omitempty"`
 	Results   []*Response_Result `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
 	Error     string             `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// seed injected into the cmds (if seeded)
+	Seed uint64 `protobuf:"varint,4,opt,name=seed,proto3" json:"seed,omitempty"`
+	// checker result and the verdict decided by the checker (if presents)
+	Checker *Response_Result           `protobuf:"bytes,5,opt,name=checker,proto3" json:"checker,omitempty"`
+	Verdict Response_Result_StatusType `protobuf:"varint,6,opt,name=verdict,proto3,enum=pb.Response_Result_StatusType" json:"verdict,omitempty"`
+	// machine readable reason and field path if the request was rejected
+	// before execution (e.g. LIMIT_EXCEEDED, cmd[0].memoryLimit)
+	ErrorCode  string `protobuf:"bytes,7,opt,name=errorCode,proto3" json:"errorCode,omitempty"`
+	ErrorField string `protobuf:"bytes,8,opt,name=errorField,proto3" json:"errorField,omitempty"`
+	// cmds killed since clockBudget exceeded, verdict is TimeLimitExceeded
+	ClockBudgetExceeded bool `protobuf:"varint,9,opt,name=clockBudgetExceeded,proto3" json:"clockBudgetExceeded,omitempty"`
+	// comment of the testlib checker (its stderr)
+	CheckerComment string `protobuf:"bytes,10,opt,name=checkerComment,proto3" json:"checkerComment,omitempty"`
+	// result of the compare (if compare presents)
+	Compare *Response_CompareResult `protobuf:"bytes,11,opt,name=compare,proto3" json:"compare,omitempty"`
 }
 
 func (x *Response) Reset() {
 	*x = Response{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[4]
+		mi := &file_judge_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -346,7 +995,7 @@ func (x *Response) String() string {
 func (*Response) ProtoMessage() {}
 
 func (x *Response) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[4]
+	mi := &file_judge_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -359,7 +1008,7 @@ func (x *Response) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Response.ProtoReflect.Descriptor instead.
 func (*Response) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{4}
+	return file_judge_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Response) GetRequestID() string {
@@ -383,6 +1032,62 @@ func (x *Response) GetError() string {
 	return ""
 }
 
+func (x *Response) GetSeed() uint64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *Response) GetChecker() *Response_Result {
+	if x != nil {
+		return x.Checker
+	}
+	return nil
+}
+
+func (x *Response) GetVerdict() Response_Result_StatusType {
+	if x != nil {
+		return x.Verdict
+	}
+	return Response_Result_Invalid
+}
+
+func (x *Response) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *Response) GetErrorField() string {
+	if x != nil {
+		return x.ErrorField
+	}
+	return ""
+}
+
+func (x *Response) GetClockBudgetExceeded() bool {
+	if x != nil {
+		return x.ClockBudgetExceeded
+	}
+	return false
+}
+
+func (x *Response) GetCheckerComment() string {
+	if x != nil {
+		return x.CheckerComment
+	}
+	return ""
+}
+
+func (x *Response) GetCompare() *Response_CompareResult {
+	if x != nil {
+		return x.Compare
+	}
+	return nil
+}
+
 type StreamRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -392,13 +1097,14 @@ type StreamRequest struct {
 	//	*StreamRequest_ExecRequest
 	//	*StreamRequest_ExecInput
 	//	*StreamRequest_ExecResize
+	//	*StreamRequest_ExecAbort
 	Request isStreamRequest_Request `protobuf_oneof:"request"`
 }
 
 func (x *StreamRequest) Reset() {
 	*x = StreamRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[5]
+		mi := &file_judge_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -411,7 +1117,7 @@ func (x *StreamRequest) String() string {
 func (*StreamRequest) ProtoMessage() {}
 
 func (x *StreamRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[5]
+	mi := &file_judge_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -424,7 +1130,7 @@ func (x *StreamRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamRequest.ProtoReflect.Descriptor instead.
 func (*StreamRequest) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{5}
+	return file_judge_proto_rawDescGZIP(), []int{6}
 }
 
 func (m *StreamRequest) GetRequest() isStreamRequest_Request {
@@ -455,6 +1161,13 @@ func (x *StreamRequest) GetExecResize() *StreamRequest_Resize {
 	return nil
 }
 
+func (x *StreamRequest) GetExecAbort() *StreamRequest_Abort {
+	if x, ok := x.GetRequest().(*StreamRequest_ExecAbort); ok {
+		return x.ExecAbort
+	}
+	return nil
+}
+
 type isStreamRequest_Request interface {
 	isStreamRequest_Request()
 }
@@ -471,12 +1184,18 @@ type StreamRequest_ExecResize struct {
 	ExecResize *StreamRequest_Resize `protobuf:"bytes,3,opt,name=execResize,proto3,oneof"`
 }
 
+type StreamRequest_ExecAbort struct {
+	ExecAbort *StreamRequest_Abort `protobuf:"bytes,4,opt,name=execAbort,proto3,oneof"`
+}
+
 func (*StreamRequest_ExecRequest) isStreamRequest_Request() {}
 
 func (*StreamRequest_ExecInput) isStreamRequest_Request() {}
 
 func (*StreamRequest_ExecResize) isStreamRequest_Request() {}
 
+func (*StreamRequest_ExecAbort) isStreamRequest_Request() {}
+
 type StreamResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -485,13 +1204,14 @@ type StreamResponse struct {
 	// Types that are assignable to Response:
 	//	*StreamResponse_ExecResponse
 	//	*StreamResponse_ExecOutput
+	//	*StreamResponse_ExecResult
 	Response isStreamResponse_Response `protobuf_oneof:"response"`
 }
 
 func (x *StreamResponse) Reset() {
 	*x = StreamResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[6]
+		mi := &file_judge_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -504,7 +1224,7 @@ func (x *StreamResponse) String() string {
 func (*StreamResponse) ProtoMessage() {}
 
 func (x *StreamResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[6]
+	mi := &file_judge_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -517,7 +1237,7 @@ func (x *StreamResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamResponse.ProtoReflect.Descriptor instead.
 func (*StreamResponse) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{6}
+	return file_judge_proto_rawDescGZIP(), []int{7}
 }
 
 func (m *StreamResponse) GetResponse() isStreamResponse_Response {
@@ -541,6 +1261,13 @@ func (x *StreamResponse) GetExecOutput() *StreamResponse_Output {
 	return nil
 }
 
+func (x *StreamResponse) GetExecResult() *StreamResponse_PartialResult {
+	if x, ok := x.GetResponse().(*StreamResponse_ExecResult); ok {
+		return x.ExecResult
+	}
+	return nil
+}
+
 type isStreamResponse_Response interface {
 	isStreamResponse_Response()
 }
@@ -553,22 +1280,30 @@ type StreamResponse_ExecOutput struct {
 	ExecOutput *StreamResponse_Output `protobuf:"bytes,2,opt,name=execOutput,proto3,oneof"`
 }
 
+type StreamResponse_ExecResult struct {
+	ExecResult *StreamResponse_PartialResult `protobuf:"bytes,3,opt,name=execResult,proto3,oneof"`
+}
+
 func (*StreamResponse_ExecResponse) isStreamResponse_Response() {}
 
 func (*StreamResponse_ExecOutput) isStreamResponse_Response() {}
 
+func (*StreamResponse_ExecResult) isStreamResponse_Response() {}
+
 type Request_LocalFile struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Src string `protobuf:"bytes,1,opt,name=src,proto3" json:"src,omitempty"`
+	// link into the work dir as copy in instead of copying if possible
+	Link bool `protobuf:"varint,2,opt,name=link,proto3" json:"link,omitempty"`
 }
 
 func (x *Request_LocalFile) Reset() {
 	*x = Request_LocalFile{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[7]
+		mi := &file_judge_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -581,7 +1316,7 @@ func (x *Request_LocalFile) String() string {
 func (*Request_LocalFile) ProtoMessage() {}
 
 func (x *Request_LocalFile) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[7]
+	mi := &file_judge_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -594,7 +1329,7 @@ func (x *Request_LocalFile) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Request_LocalFile.ProtoReflect.Descriptor instead.
 func (*Request_LocalFile) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{3, 0}
+	return file_judge_proto_rawDescGZIP(), []int{4, 0}
 }
 
 func (x *Request_LocalFile) GetSrc() string {
@@ -604,6 +1339,13 @@ func (x *Request_LocalFile) GetSrc() string {
 	return ""
 }
 
+func (x *Request_LocalFile) GetLink() bool {
+	if x != nil {
+		return x.Link
+	}
+	return false
+}
+
 type Request_MemoryFile struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -615,7 +1357,7 @@ type Request_MemoryFile struct {
 func (x *Request_MemoryFile) Reset() {
 	*x = Request_MemoryFile{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[8]
+		mi := &file_judge_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -628,7 +1370,7 @@ func (x *Request_MemoryFile) String() string {
 func (*Request_MemoryFile) ProtoMessage() {}
 
 func (x *Request_MemoryFile) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[8]
+	mi := &file_judge_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -641,7 +1383,7 @@ func (x *Request_MemoryFile) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Request_MemoryFile.ProtoReflect.Descriptor instead.
 func (*Request_MemoryFile) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{3, 1}
+	return file_judge_proto_rawDescGZIP(), []int{4, 1}
 }
 
 func (x *Request_MemoryFile) GetContent() []byte {
@@ -662,7 +1404,7 @@ type Request_CachedFile struct {
 func (x *Request_CachedFile) Reset() {
 	*x = Request_CachedFile{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[9]
+		mi := &file_judge_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -675,7 +1417,7 @@ func (x *Request_CachedFile) String() string {
 func (*Request_CachedFile) ProtoMessage() {}
 
 func (x *Request_CachedFile) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[9]
+	mi := &file_judge_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -688,7 +1430,7 @@ func (x *Request_CachedFile) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Request_CachedFile.ProtoReflect.Descriptor instead.
 func (*Request_CachedFile) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{3, 2}
+	return file_judge_proto_rawDescGZIP(), []int{4, 2}
 }
 
 func (x *Request_CachedFile) GetFileID() string {
@@ -698,32 +1440,31 @@ func (x *Request_CachedFile) GetFileID() string {
 	return ""
 }
 
-type Request_PipeCollector struct {
+type Request_URLFile struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Max  int64  `protobuf:"varint,2,opt,name=max,proto3" json:"max,omitempty"`
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
 }
 
-func (x *Request_PipeCollector) Reset() {
-	*x = Request_PipeCollector{}
+func (x *Request_URLFile) Reset() {
+	*x = Request_URLFile{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[10]
+		mi := &file_judge_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Request_PipeCollector) String() string {
+func (x *Request_URLFile) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Request_PipeCollector) ProtoMessage() {}
+func (*Request_URLFile) ProtoMessage() {}
 
-func (x *Request_PipeCollector) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[10]
+func (x *Request_URLFile) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -734,50 +1475,41 @@ func (x *Request_PipeCollector) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Request_PipeCollector.ProtoReflect.Descriptor instead.
-func (*Request_PipeCollector) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{3, 3}
+// Deprecated: Use Request_URLFile.ProtoReflect.Descriptor instead.
+func (*Request_URLFile) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 3}
 }
 
-func (x *Request_PipeCollector) GetName() string {
+func (x *Request_URLFile) GetUrl() string {
 	if x != nil {
-		return x.Name
+		return x.Url
 	}
 	return ""
 }
 
-func (x *Request_PipeCollector) GetMax() int64 {
-	if x != nil {
-		return x.Max
-	}
-	return 0
-}
-
-type Request_StreamInput struct {
+type Request_EmptyFile struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 }
 
-func (x *Request_StreamInput) Reset() {
-	*x = Request_StreamInput{}
+func (x *Request_EmptyFile) Reset() {
+	*x = Request_EmptyFile{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[11]
+		mi := &file_judge_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Request_StreamInput) String() string {
+func (x *Request_EmptyFile) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Request_StreamInput) ProtoMessage() {}
+func (*Request_EmptyFile) ProtoMessage() {}
 
-func (x *Request_StreamInput) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[11]
+func (x *Request_EmptyFile) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -788,43 +1520,42 @@ func (x *Request_StreamInput) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Request_StreamInput.ProtoReflect.Descriptor instead.
-func (*Request_StreamInput) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{3, 4}
-}
-
-func (x *Request_StreamInput) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
+// Deprecated: Use Request_EmptyFile.ProtoReflect.Descriptor instead.
+func (*Request_EmptyFile) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 4}
 }
 
-type Request_StreamOutput struct {
+type Request_PipeCollector struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Max  int64  `protobuf:"varint,2,opt,name=max,proto3" json:"max,omitempty"`
+	// action when max exceeded, the output is truncated to max in all cases
+	OnExceed Request_PipeCollector_PolicyType `protobuf:"varint,3,opt,name=onExceed,proto3,enum=pb.Request_PipeCollector_PolicyType" json:"onExceed,omitempty"`
+	// stops reading at max and closes the pipe once the program writes past
+	// max (EPIPE / SIGPIPE) instead of draining the rest of the output
+	Backpressure bool `protobuf:"varint,4,opt,name=backpressure,proto3" json:"backpressure,omitempty"`
 }
 
-func (x *Request_StreamOutput) Reset() {
-	*x = Request_StreamOutput{}
+func (x *Request_PipeCollector) Reset() {
+	*x = Request_PipeCollector{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[12]
+		mi := &file_judge_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Request_StreamOutput) String() string {
+func (x *Request_PipeCollector) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Request_StreamOutput) ProtoMessage() {}
+func (*Request_PipeCollector) ProtoMessage() {}
 
-func (x *Request_StreamOutput) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[12]
+func (x *Request_PipeCollector) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -835,22 +1566,137 @@ func (x *Request_StreamOutput) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Request_StreamOutput.ProtoReflect.Descriptor instead.
-func (*Request_StreamOutput) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{3, 5}
+// Deprecated: Use Request_PipeCollector.ProtoReflect.Descriptor instead.
+func (*Request_PipeCollector) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 5}
 }
 
-func (x *Request_StreamOutput) GetName() string {
+func (x *Request_PipeCollector) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-type Request_File struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *Request_PipeCollector) GetMax() int64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *Request_PipeCollector) GetOnExceed() Request_PipeCollector_PolicyType {
+	if x != nil {
+		return x.OnExceed
+	}
+	return Request_PipeCollector_PolicyFail
+}
+
+func (x *Request_PipeCollector) GetBackpressure() bool {
+	if x != nil {
+		return x.Backpressure
+	}
+	return false
+}
+
+type Request_StreamInput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *Request_StreamInput) Reset() {
+	*x = Request_StreamInput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_judge_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Request_StreamInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Request_StreamInput) ProtoMessage() {}
+
+func (x *Request_StreamInput) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Request_StreamInput.ProtoReflect.Descriptor instead.
+func (*Request_StreamInput) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 6}
+}
+
+func (x *Request_StreamInput) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type Request_StreamOutput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *Request_StreamOutput) Reset() {
+	*x = Request_StreamOutput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_judge_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Request_StreamOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Request_StreamOutput) ProtoMessage() {}
+
+func (x *Request_StreamOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Request_StreamOutput.ProtoReflect.Descriptor instead.
+func (*Request_StreamOutput) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 7}
+}
+
+func (x *Request_StreamOutput) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type Request_File struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
 	// Types that are assignable to File:
 	//	*Request_File_Local
@@ -859,13 +1705,15 @@ type Request_File struct {
 	//	*Request_File_Pipe
 	//	*Request_File_StreamIn
 	//	*Request_File_StreamOut
+	//	*Request_File_Url
+	//	*Request_File_Empty
 	File isRequest_File_File `protobuf_oneof:"file"`
 }
 
 func (x *Request_File) Reset() {
 	*x = Request_File{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[13]
+		mi := &file_judge_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -878,7 +1726,7 @@ func (x *Request_File) String() string {
 func (*Request_File) ProtoMessage() {}
 
 func (x *Request_File) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[13]
+	mi := &file_judge_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -891,7 +1739,7 @@ func (x *Request_File) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Request_File.ProtoReflect.Descriptor instead.
 func (*Request_File) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{3, 6}
+	return file_judge_proto_rawDescGZIP(), []int{4, 8}
 }
 
 func (m *Request_File) GetFile() isRequest_File_File {
@@ -943,6 +1791,20 @@ func (x *Request_File) GetStreamOut() *Request_StreamOutput {
 	return nil
 }
 
+func (x *Request_File) GetUrl() *Request_URLFile {
+	if x, ok := x.GetFile().(*Request_File_Url); ok {
+		return x.Url
+	}
+	return nil
+}
+
+func (x *Request_File) GetEmpty() *Request_EmptyFile {
+	if x, ok := x.GetFile().(*Request_File_Empty); ok {
+		return x.Empty
+	}
+	return nil
+}
+
 type isRequest_File_File interface {
 	isRequest_File_File()
 }
@@ -973,6 +1835,16 @@ type Request_File_StreamOut struct {
 	StreamOut *Request_StreamOutput `protobuf:"bytes,6,opt,name=streamOut,proto3,oneof"`
 }
 
+type Request_File_Url struct {
+	// url fetched by the server, host should be allowed
+	Url *Request_URLFile `protobuf:"bytes,7,opt,name=url,proto3,oneof"`
+}
+
+type Request_File_Empty struct {
+	// empty input reaches EOF immediately
+	Empty *Request_EmptyFile `protobuf:"bytes,8,opt,name=empty,proto3,oneof"`
+}
+
 func (*Request_File_Local) isRequest_File_File() {}
 
 func (*Request_File_Memory) isRequest_File_File() {}
@@ -985,6 +1857,65 @@ func (*Request_File_StreamIn) isRequest_File_File() {}
 
 func (*Request_File_StreamOut) isRequest_File_File() {}
 
+func (*Request_File_Url) isRequest_File_File() {}
+
+func (*Request_File_Empty) isRequest_File_File() {}
+
+type Request_CopyOutDir struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Src    string `protobuf:"bytes,1,opt,name=src,proto3" json:"src,omitempty"`
+	Prefix string `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+}
+
+func (x *Request_CopyOutDir) Reset() {
+	*x = Request_CopyOutDir{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_judge_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Request_CopyOutDir) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Request_CopyOutDir) ProtoMessage() {}
+
+func (x *Request_CopyOutDir) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Request_CopyOutDir.ProtoReflect.Descriptor instead.
+func (*Request_CopyOutDir) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 9}
+}
+
+func (x *Request_CopyOutDir) GetSrc() string {
+	if x != nil {
+		return x.Src
+	}
+	return ""
+}
+
+func (x *Request_CopyOutDir) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
 type Request_CmdType struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1006,25 +1937,1354 @@ type Request_CmdType struct {
 	CopyOutDir        string                   `protobuf:"bytes,11,opt,name=copyOutDir,proto3" json:"copyOutDir,omitempty"`
 	CopyOutMax        uint64                   `protobuf:"varint,14,opt,name=copyOutMax,proto3" json:"copyOutMax,omitempty"`
 	StrictMemoryLimit bool                     `protobuf:"varint,16,opt,name=strictMemoryLimit,proto3" json:"strictMemoryLimit,omitempty"`
+	// profile selects the server side profile (empty uses default profile)
+	Profile string `protobuf:"bytes,17,opt,name=profile,proto3" json:"profile,omitempty"`
+	// max syscalls per second (0 disables), exceeded rate is reported as
+	// warning and kills the program if killOnSyscallRate
+	SyscallRateLimit  uint64 `protobuf:"varint,18,opt,name=syscallRateLimit,proto3" json:"syscallRateLimit,omitempty"`
+	KillOnSyscallRate bool   `protobuf:"varint,19,opt,name=killOnSyscallRate,proto3" json:"killOnSyscallRate,omitempty"`
+	// memory exceeded soft limit reports memoryWarning (memory.high on cgroup v2, 0 disables)
+	MemorySoftLimit uint64 `protobuf:"varint,20,opt,name=memorySoftLimit,proto3" json:"memorySoftLimit,omitempty"`
+	// signal sent once time limit exceeded (0 kills immediately), it is killed
+	// after killGracePeriod (ns, default 1s) if not exited
+	KillSignal      int32  `protobuf:"varint,21,opt,name=killSignal,proto3" json:"killSignal,omitempty"`
+	KillGracePeriod uint64 `protobuf:"varint,22,opt,name=killGracePeriod,proto3" json:"killGracePeriod,omitempty"`
+	// max cumulative bytes written to block devices (io cgroup), exceeded
+	// kills the program as WriteLimitExceeded (0 disables)
+	WriteLimit uint64 `protobuf:"varint,23,opt,name=writeLimit,proto3" json:"writeLimit,omitempty"`
+	// max number of files created in the work dir file system (inodes),
+	// exceeded kills the program as FileLimitExceeded (0 disables)
+	FileLimit uint64 `protobuf:"varint,26,opt,name=fileLimit,proto3" json:"fileLimit,omitempty"`
+	// max size of a single file written (RLIMIT_FSIZE), exceeded write gets
+	// SIGXFSZ as FileSizeLimitExceeded (0 disables)
+	FileSizeLimit uint64 `protobuf:"varint,27,opt,name=fileSizeLimit,proto3" json:"fileSizeLimit,omitempty"`
+	// builds and destroys cgroup for the run instead of the pooled one
+	FreshCgroup bool `protobuf:"varint,30,opt,name=freshCgroup,proto3" json:"freshCgroup,omitempty"`
+	// copy in file parsed as KEY=VALUE lines merged into env (env takes
+	// precedence)
+	EnvFile string `protobuf:"bytes,31,opt,name=envFile,proto3" json:"envFile,omitempty"`
+	// collects the input delivered to stdin as copy out file within max
+	// (e.g. interaction transcript), not for tty
+	StdinTee *Request_PipeCollector `protobuf:"bytes,32,opt,name=stdinTee,proto3" json:"stdinTee,omitempty"`
+	// terminates the program once it closed all pipe collectors and the
+	// quiet period (ns) passed, reported as exited (0 disables)
+	EarlyResultQuietPeriod uint64 `protobuf:"varint,33,opt,name=earlyResultQuietPeriod,proto3" json:"earlyResultQuietPeriod,omitempty"`
+	// memory reported and compared with memory limit, cgroup peak or the
+	// current memory sampled last before exit
+	MemoryAccounting Request_CmdType_MemoryAccountingType `protobuf:"varint,34,opt,name=memoryAccounting,proto3,enum=pb.Request_CmdType_MemoryAccountingType" json:"memoryAccounting,omitempty"`
+	// max user space instructions retired (hardware perf counter), exceeded
+	// kills the program as InstructionLimitExceeded, requires
+	// -instruction-counter (0 disables)
+	InstructionLimit uint64 `protobuf:"varint,35,opt,name=instructionLimit,proto3" json:"instructionLimit,omitempty"`
+	// omits copy out files exceeded copyOutMax (reported by omittedFiles)
+	// instead of failing the cmd
+	CopyOutPartial bool `protobuf:"varint,36,opt,name=copyOutPartial,proto3" json:"copyOutPartial,omitempty"`
+	// scheduling nice value (0 default to 19 lowest priority), also weights
+	// the cpu cgroup with -enable-cpu-rate, bounded below by -min-nice
+	Nice int32 `protobuf:"zigzag32,37,opt,name=nice,proto3" json:"nice,omitempty"`
+	// runs the program with stdout line buffered or unbuffered by the
+	// preloaded stdbuf library, requires -stdbuf-library
+	StdoutBuffering Request_CmdType_StdoutBufferingType `protobuf:"varint,38,opt,name=stdoutBuffering,proto3,enum=pb.Request_CmdType_StdoutBufferingType" json:"stdoutBuffering,omitempty"`
+	// max sockets held by the program and its children sampled by time
+	// limit checker, exceeded kills the program as SocketLimitExceeded and
+	// the peak is reported as socketPeak (0 disables), openFileLimit is the
+	// RLIMIT_NOFILE bounds the sockets opened between samples (0 default)
+	SocketLimit   uint64 `protobuf:"varint,39,opt,name=socketLimit,proto3" json:"socketLimit,omitempty"`
+	OpenFileLimit uint64 `protobuf:"varint,40,opt,name=openFileLimit,proto3" json:"openFileLimit,omitempty"`
+	// removes the files in the work dir of the reused environment before
+	// copy in instead of overwriting existing files only
+	CleanWorkDir bool `protobuf:"varint,41,opt,name=cleanWorkDir,proto3" json:"cleanWorkDir,omitempty"`
+	// RLIMIT_NPROC checked on fork / clone besides the cgroup procLimit (0
+	// uses procLimit with -nproc-from-proc-limit)
+	NprocLimit uint64 `protobuf:"varint,42,opt,name=nprocLimit,proto3" json:"nprocLimit,omitempty"`
+	// supplementary group ids of the program, each must be in
+	// -allowed-groups of the server
+	Groups []uint32 `protobuf:"varint,43,rep,packed,name=groups,proto3" json:"groups,omitempty"`
+	// reports the syscall counts of the program in debug info (debug only)
+	CountSyscalls bool `protobuf:"varint,44,opt,name=countSyscalls,proto3" json:"countSyscalls,omitempty"`
+	// max total size of the copy out files (0 uses -copy-out-total-limit),
+	// pipe collector outputs are counted against it with copyOutIncludePipes
+	CopyOutTotalMax     uint64 `protobuf:"varint,45,opt,name=copyOutTotalMax,proto3" json:"copyOutTotalMax,omitempty"`
+	CopyOutIncludePipes bool   `protobuf:"varint,46,opt,name=copyOutIncludePipes,proto3" json:"copyOutIncludePipes,omitempty"`
+	// cpu time reported and compared with cpu limit, cgroup cpu usage or the
+	// user time of the process and its waited children
+	TimeAccounting Request_CmdType_TimeAccountingType `protobuf:"varint,47,opt,name=timeAccounting,proto3,enum=pb.Request_CmdType_TimeAccountingType" json:"timeAccounting,omitempty"`
+	// collect regular files inside the dirs named with prefix (inline)
+	CopyOutDirs []*Request_CopyOutDir `protobuf:"bytes,24,rep,name=copyOutDirs,proto3" json:"copyOutDirs,omitempty"`
+	// reuse copy out files of the previous accepted run with the same
+	// normalized cmd (e.g. compile), requires compile cache enabled
+	Cache bool `protobuf:"varint,25,opt,name=cache,proto3" json:"cache,omitempty"`
+	// env variables injected with values redacted in logs, debug info and
+	// results
+	Secrets map[string]string `protobuf:"bytes,28,rep,name=secrets,proto3" json:"secrets,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// runs in the same environment after the program exited regardless of
+	// its status (e.g. teardown)
+	PostExec []string `protobuf:"bytes,29,rep,name=postExec,proto3" json:"postExec,omitempty"`
 }
 
 func (x *Request_CmdType) Reset() {
 	*x = Request_CmdType{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[14]
+		mi := &file_judge_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Request_CmdType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Request_CmdType) ProtoMessage() {}
+
+func (x *Request_CmdType) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Request_CmdType.ProtoReflect.Descriptor instead.
+func (*Request_CmdType) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 10}
+}
+
+func (x *Request_CmdType) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *Request_CmdType) GetEnv() []string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *Request_CmdType) GetFiles() []*Request_File {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *Request_CmdType) GetTty() bool {
+	if x != nil {
+		return x.Tty
+	}
+	return false
+}
+
+func (x *Request_CmdType) GetCpuTimeLimit() uint64 {
+	if x != nil {
+		return x.CpuTimeLimit
+	}
+	return 0
+}
+
+func (x *Request_CmdType) GetClockTimeLimit() uint64 {
+	if x != nil {
+		return x.ClockTimeLimit
+	}
+	return 0
+}
+
+func (x *Request_CmdType) GetMemoryLimit() uint64 {
+	if x != nil {
+		return x.MemoryLimit
+	}
+	return 0
+}
+
+func (x *Request_CmdType) GetStackLimit() uint64 {
+	if x != nil {
+		return x.StackLimit
+	}
+	return 0
+}
+
+func (x *Request_CmdType) GetProcLimit() uint64 {
+	if x != nil {
+		return x.ProcLimit
+	}
+	return 0
+}
+
+func (x *Request_CmdType) GetCPURateLimit() float64 {
+	if x != nil {
+		return x.CPURateLimit
+	}
+	return 0
+}
+
+func (x *Request_CmdType) GetCopyIn() map[string]*Request_File {
+	if x != nil {
+		return x.CopyIn
+	}
+	return nil
+}
+
+func (x *Request_CmdType) GetCopyOut() []string {
+	if x != nil {
+		return x.CopyOut
+	}
+	return nil
+}
+
+func (x *Request_CmdType) GetCopyOutCached() []string {
+	if x != nil {
+		return x.CopyOutCached
+	}
+	return nil
+}
+
+func (x *Request_CmdType) GetCopyOutDir() string {
+	if x != nil {
+		return x.CopyOutDir
+	}
+	return ""
+}
+
+func (x *Request_CmdType) GetCopyOutMax() uint64 {
+	if x != nil {
+		return x.CopyOutMax
+	}
+	return 0
+}
+
+func (x *Request_CmdType) GetStrictMemoryLimit() bool {
+	if x != nil {
+		return x.StrictMemoryLimit
+	}
+	return false
+}
+
+func (x *Request_CmdType) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *Request_CmdType) GetSyscallRateLimit() uint64 {
+	if x != nil {
+		return x.SyscallRateLimit
+	}
+	return 0
+}
+
+func (x *Request_CmdType) GetKillOnSyscallRate() bool {
+	if x != nil {
+		return x.KillOnSyscallRate
+	}
+	return false
+}
+
+func (x *Request_CmdType) GetMemorySoftLimit() uint64 {
+	if x != nil {
+		return x.MemorySoftLimit
+	}
+	return 0
+}
+
+func (x *Request_CmdType) GetKillSignal() int32 {
+	if x != nil {
+		return x.KillSignal
+	}
+	return 0
+}
+
+func (x *Request_CmdType) GetKillGracePeriod() uint64 {
+	if x != nil {
+		return x.KillGracePeriod
+	}
+	return 0
+}
+
+func (x *Request_CmdType) GetWriteLimit() uint64 {
+	if x != nil {
+		return x.WriteLimit
+	}
+	return 0
+}
+
+func (x *Request_CmdType) GetFileLimit() uint64 {
+	if x != nil {
+		return x.FileLimit
+	}
+	return 0
+}
+
+func (x *Request_CmdType) GetFileSizeLimit() uint64 {
+	if x != nil {
+		return x.FileSizeLimit
+	}
+	return 0
+}
+
+func (x *Request_CmdType) GetFreshCgroup() bool {
+	if x != nil {
+		return x.FreshCgroup
+	}
+	return false
+}
+
+func (x *Request_CmdType) GetEnvFile() string {
+	if x != nil {
+		return x.EnvFile
+	}
+	return ""
+}
+
+func (x *Request_CmdType) GetStdinTee() *Request_PipeCollector {
+	if x != nil {
+		return x.StdinTee
+	}
+	return nil
+}
+
+func (x *Request_CmdType) GetEarlyResultQuietPeriod() uint64 {
+	if x != nil {
+		return x.EarlyResultQuietPeriod
+	}
+	return 0
+}
+
+func (x *Request_CmdType) GetMemoryAccounting() Request_CmdType_MemoryAccountingType {
+	if x != nil {
+		return x.MemoryAccounting
+	}
+	return Request_CmdType_MemoryPeak
+}
+
+func (x *Request_CmdType) GetInstructionLimit() uint64 {
+	if x != nil {
+		return x.InstructionLimit
+	}
+	return 0
+}
+
+func (x *Request_CmdType) GetCopyOutPartial() bool {
+	if x != nil {
+		return x.CopyOutPartial
+	}
+	return false
+}
+
+func (x *Request_CmdType) GetNice() int32 {
+	if x != nil {
+		return x.Nice
+	}
+	return 0
+}
+
+func (x *Request_CmdType) GetStdoutBuffering() Request_CmdType_StdoutBufferingType {
+	if x != nil {
+		return x.StdoutBuffering
+	}
+	return Request_CmdType_BufferingDefault
+}
+
+func (x *Request_CmdType) GetSocketLimit() uint64 {
+	if x != nil {
+		return x.SocketLimit
+	}
+	return 0
+}
+
+func (x *Request_CmdType) GetOpenFileLimit() uint64 {
+	if x != nil {
+		return x.OpenFileLimit
+	}
+	return 0
+}
+
+func (x *Request_CmdType) GetCleanWorkDir() bool {
+	if x != nil {
+		return x.CleanWorkDir
+	}
+	return false
+}
+
+func (x *Request_CmdType) GetNprocLimit() uint64 {
+	if x != nil {
+		return x.NprocLimit
+	}
+	return 0
+}
+
+func (x *Request_CmdType) GetGroups() []uint32 {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *Request_CmdType) GetCountSyscalls() bool {
+	if x != nil {
+		return x.CountSyscalls
+	}
+	return false
+}
+
+func (x *Request_CmdType) GetCopyOutTotalMax() uint64 {
+	if x != nil {
+		return x.CopyOutTotalMax
+	}
+	return 0
+}
+
+func (x *Request_CmdType) GetCopyOutIncludePipes() bool {
+	if x != nil {
+		return x.CopyOutIncludePipes
+	}
+	return false
+}
+
+func (x *Request_CmdType) GetTimeAccounting() Request_CmdType_TimeAccountingType {
+	if x != nil {
+		return x.TimeAccounting
+	}
+	return Request_CmdType_TimeCgroup
+}
+
+func (x *Request_CmdType) GetCopyOutDirs() []*Request_CopyOutDir {
+	if x != nil {
+		return x.CopyOutDirs
+	}
+	return nil
+}
+
+func (x *Request_CmdType) GetCache() bool {
+	if x != nil {
+		return x.Cache
+	}
+	return false
+}
+
+func (x *Request_CmdType) GetSecrets() map[string]string {
+	if x != nil {
+		return x.Secrets
+	}
+	return nil
+}
+
+func (x *Request_CmdType) GetPostExec() []string {
+	if x != nil {
+		return x.PostExec
+	}
+	return nil
+}
+
+type Request_Checker struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cmd *Request_CmdType `protobuf:"bytes,1,opt,name=cmd,proto3" json:"cmd,omitempty"`
+	// result files of the cmds copied into the checker
+	Inputs []*Request_Checker_Input `protobuf:"bytes,2,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	// maps checker exit status to verdict, 0 is Accepted and the others are
+	// WrongAnswer if not mapped
+	ExitStatus map[int32]Response_Result_StatusType `protobuf:"bytes,3,rep,name=exitStatus,proto3" json:"exitStatus,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3,enum=pb.Response_Result_StatusType"`
+	Testlib    *Request_Checker_Testlib             `protobuf:"bytes,4,opt,name=testlib,proto3" json:"testlib,omitempty"`
+}
+
+func (x *Request_Checker) Reset() {
+	*x = Request_Checker{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_judge_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Request_Checker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Request_Checker) ProtoMessage() {}
+
+func (x *Request_Checker) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Request_Checker.ProtoReflect.Descriptor instead.
+func (*Request_Checker) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 11}
+}
+
+func (x *Request_Checker) GetCmd() *Request_CmdType {
+	if x != nil {
+		return x.Cmd
+	}
+	return nil
+}
+
+func (x *Request_Checker) GetInputs() []*Request_Checker_Input {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *Request_Checker) GetExitStatus() map[int32]Response_Result_StatusType {
+	if x != nil {
+		return x.ExitStatus
+	}
+	return nil
+}
+
+func (x *Request_Checker) GetTestlib() *Request_Checker_Testlib {
+	if x != nil {
+		return x.Testlib
+	}
+	return nil
+}
+
+type Request_PipeMap struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	In  *Request_PipeMap_PipeIndex `protobuf:"bytes,1,opt,name=in,proto3" json:"in,omitempty"`
+	Out *Request_PipeMap_PipeIndex `protobuf:"bytes,2,opt,name=out,proto3" json:"out,omitempty"`
+}
+
+func (x *Request_PipeMap) Reset() {
+	*x = Request_PipeMap{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_judge_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Request_PipeMap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Request_PipeMap) ProtoMessage() {}
+
+func (x *Request_PipeMap) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Request_PipeMap.ProtoReflect.Descriptor instead.
+func (*Request_PipeMap) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 12}
+}
+
+func (x *Request_PipeMap) GetIn() *Request_PipeMap_PipeIndex {
+	if x != nil {
+		return x.In
+	}
+	return nil
+}
+
+func (x *Request_PipeMap) GetOut() *Request_PipeMap_PipeIndex {
+	if x != nil {
+		return x.Out
+	}
+	return nil
+}
+
+// runs the single cmd against each input in order in the same environment,
+// the cmd copyIn (e.g. binary) is kept and the other files are removed
+// between runs, results are indexed by the inputs
+type Request_Input struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stdin  *Request_File            `protobuf:"bytes,1,opt,name=stdin,proto3" json:"stdin,omitempty"`
+	CopyIn map[string]*Request_File `protobuf:"bytes,2,rep,name=copyIn,proto3" json:"copyIn,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *Request_Input) Reset() {
+	*x = Request_Input{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_judge_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Request_Input) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Request_Input) ProtoMessage() {}
+
+func (x *Request_Input) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Request_Input.ProtoReflect.Descriptor instead.
+func (*Request_Input) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 14}
+}
+
+func (x *Request_Input) GetStdin() *Request_File {
+	if x != nil {
+		return x.Stdin
+	}
+	return nil
+}
+
+func (x *Request_Input) GetCopyIn() map[string]*Request_File {
+	if x != nil {
+		return x.CopyIn
+	}
+	return nil
+}
+
+// compares the collected output name of cmd index with the answer after
+// all cmds accepted and decides the verdict (not with checker)
+type Request_Compare struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index  int32                      `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Name   string                     `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Answer *Request_File              `protobuf:"bytes,3,opt,name=answer,proto3" json:"answer,omitempty"`
+	Policy Request_Compare_PolicyType `protobuf:"varint,4,opt,name=policy,proto3,enum=pb.Request_Compare_PolicyType" json:"policy,omitempty"`
+	// max absolute or relative error of numbers (Float)
+	Epsilon float64 `protobuf:"fixed64,5,opt,name=epsilon,proto3" json:"epsilon,omitempty"`
+}
+
+func (x *Request_Compare) Reset() {
+	*x = Request_Compare{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_judge_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Request_Compare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Request_Compare) ProtoMessage() {}
+
+func (x *Request_Compare) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Request_Compare.ProtoReflect.Descriptor instead.
+func (*Request_Compare) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 15}
+}
+
+func (x *Request_Compare) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *Request_Compare) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Request_Compare) GetAnswer() *Request_File {
+	if x != nil {
+		return x.Answer
+	}
+	return nil
+}
+
+func (x *Request_Compare) GetPolicy() Request_Compare_PolicyType {
+	if x != nil {
+		return x.Policy
+	}
+	return Request_Compare_Exact
+}
+
+func (x *Request_Compare) GetEpsilon() float64 {
+	if x != nil {
+		return x.Epsilon
+	}
+	return 0
+}
+
+type Request_Checker_Input struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index int32  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Name  string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Dst   string `protobuf:"bytes,3,opt,name=dst,proto3" json:"dst,omitempty"`
+}
+
+func (x *Request_Checker_Input) Reset() {
+	*x = Request_Checker_Input{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_judge_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Request_Checker_Input) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Request_Checker_Input) ProtoMessage() {}
+
+func (x *Request_Checker_Input) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Request_Checker_Input.ProtoReflect.Descriptor instead.
+func (*Request_Checker_Input) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 11, 0}
+}
+
+func (x *Request_Checker_Input) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *Request_Checker_Input) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Request_Checker_Input) GetDst() string {
+	if x != nil {
+		return x.Dst
+	}
+	return ""
+}
+
+// runs as testlib checker `args... input output answer` with the files
+// in the checker work dir, its exit status maps to the verdict by
+// testlib convention and stderr is returned as checkerComment
+type Request_Checker_Testlib struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Input  string `protobuf:"bytes,1,opt,name=input,proto3" json:"input,omitempty"`
+	Output string `protobuf:"bytes,2,opt,name=output,proto3" json:"output,omitempty"`
+	Answer string `protobuf:"bytes,3,opt,name=answer,proto3" json:"answer,omitempty"`
+}
+
+func (x *Request_Checker_Testlib) Reset() {
+	*x = Request_Checker_Testlib{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_judge_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Request_Checker_Testlib) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Request_Checker_Testlib) ProtoMessage() {}
+
+func (x *Request_Checker_Testlib) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Request_Checker_Testlib.ProtoReflect.Descriptor instead.
+func (*Request_Checker_Testlib) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 11, 2}
+}
+
+func (x *Request_Checker_Testlib) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
+}
+
+func (x *Request_Checker_Testlib) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
+}
+
+func (x *Request_Checker_Testlib) GetAnswer() string {
+	if x != nil {
+		return x.Answer
+	}
+	return ""
+}
+
+type Request_PipeMap_PipeIndex struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index int32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Fd    int32 `protobuf:"varint,2,opt,name=fd,proto3" json:"fd,omitempty"`
+}
+
+func (x *Request_PipeMap_PipeIndex) Reset() {
+	*x = Request_PipeMap_PipeIndex{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_judge_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Request_PipeMap_PipeIndex) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Request_PipeMap_PipeIndex) ProtoMessage() {}
+
+func (x *Request_PipeMap_PipeIndex) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Request_PipeMap_PipeIndex.ProtoReflect.Descriptor instead.
+func (*Request_PipeMap_PipeIndex) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{4, 12, 0}
+}
+
+func (x *Request_PipeMap_PipeIndex) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *Request_PipeMap_PipeIndex) GetFd() int32 {
+	if x != nil {
+		return x.Fd
+	}
+	return 0
+}
+
+type Response_Result struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status     Response_Result_StatusType `protobuf:"varint,1,opt,name=status,proto3,enum=pb.Response_Result_StatusType" json:"status,omitempty"`
+	ExitStatus int32                      `protobuf:"varint,2,opt,name=exitStatus,proto3" json:"exitStatus,omitempty"`
+	Error      string                     `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Time       uint64                     `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+	RunTime    uint64                     `protobuf:"varint,8,opt,name=runTime,proto3" json:"runTime,omitempty"`
+	Memory     uint64                     `protobuf:"varint,5,opt,name=memory,proto3" json:"memory,omitempty"`
+	Files      map[string][]byte          `protobuf:"bytes,6,rep,name=files,proto3" json:"files,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	FileIDs    map[string]string          `protobuf:"bytes,7,rep,name=fileIDs,proto3" json:"fileIDs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// time (ns) spent to get the environment from pool
+	EnvironmentTime uint64 `protobuf:"varint,9,opt,name=environmentTime,proto3" json:"environmentTime,omitempty"`
+	// time (ns) spent to get and set up the cgroup
+	CgroupTime uint64 `protobuf:"varint,10,opt,name=cgroupTime,proto3" json:"cgroupTime,omitempty"`
+	// warning reports abnormal behavior (e.g. high syscall rate)
+	Warning string `protobuf:"bytes,11,opt,name=warning,proto3" json:"warning,omitempty"`
+	// sampled peak number of processes / threads
+	ProcPeak uint64 `protobuf:"varint,12,opt,name=procPeak,proto3" json:"procPeak,omitempty"`
+	// number of retries because of internal error
+	Retries int32 `protobuf:"varint,13,opt,name=retries,proto3" json:"retries,omitempty"`
+	// peak memory exceeded memorySoftLimit
+	MemoryWarning bool `protobuf:"varint,14,opt,name=memoryWarning,proto3" json:"memoryWarning,omitempty"`
+	// resolved invocation if debug requested
+	Debug *Response_DebugInfo `protobuf:"bytes,15,opt,name=debug,proto3" json:"debug,omitempty"`
+	// result reused from the compile cache
+	CacheHit bool `protobuf:"varint,16,opt,name=cacheHit,proto3" json:"cacheHit,omitempty"`
+	// id of the preserved work dir (if preserved)
+	Preserved string `protobuf:"bytes,17,opt,name=preserved,proto3" json:"preserved,omitempty"`
+	// limits reduced to the server max limits
+	LimitsClamped []string `protobuf:"bytes,18,rep,name=limitsClamped,proto3" json:"limitsClamped,omitempty"`
+	// user / system cpu time (ns) split of time (cgroup enabled)
+	UserTime   uint64 `protobuf:"varint,19,opt,name=userTime,proto3" json:"userTime,omitempty"`
+	SystemTime uint64 `protobuf:"varint,20,opt,name=systemTime,proto3" json:"systemTime,omitempty"`
+	// result of the post exec cmd (if presents)
+	PostExec *Response_PostExecResult `protobuf:"bytes,21,opt,name=postExec,proto3" json:"postExec,omitempty"`
+	// why the program exited
+	ExitReason Response_Result_ExitReasonType `protobuf:"varint,22,opt,name=exitReason,proto3,enum=pb.Response_Result_ExitReasonType" json:"exitReason,omitempty"`
+	// raw peak memory if memory excludes page cache (estimate)
+	MemoryPeak uint64 `protobuf:"varint,23,opt,name=memoryPeak,proto3" json:"memoryPeak,omitempty"`
+	// terminated after the program closed its outputs
+	EarlyResult bool `protobuf:"varint,24,opt,name=earlyResult,proto3" json:"earlyResult,omitempty"`
+	// not reaped within the reap deadline after killed (hang on exit)
+	ReapTimeout bool `protobuf:"varint,25,opt,name=reapTimeout,proto3" json:"reapTimeout,omitempty"`
+	// user space instructions retired (with instructionLimit)
+	Instructions uint64 `protobuf:"varint,26,opt,name=instructions,proto3" json:"instructions,omitempty"`
+	// copy out files exceeded copyOutMax with their sizes (copyOutPartial)
+	OmittedFiles map[string]uint64 `protobuf:"bytes,27,rep,name=omittedFiles,proto3" json:"omittedFiles,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// peak number of sockets sampled (with socketLimit)
+	SocketPeak uint64 `protobuf:"varint,28,opt,name=socketPeak,proto3" json:"socketPeak,omitempty"`
+	// the process count limit failed the process / thread creation
+	ProcLimitHit Response_Result_ProcLimitHitType `protobuf:"varint,29,opt,name=procLimitHit,proto3,enum=pb.Response_Result_ProcLimitHitType" json:"procLimitHit,omitempty"`
+	// total bytes of the collected copy out files / pipe collector outputs
+	CopyOutBytes uint64 `protobuf:"varint,30,opt,name=copyOutBytes,proto3" json:"copyOutBytes,omitempty"`
+	PipeBytes    uint64 `protobuf:"varint,31,opt,name=pipeBytes,proto3" json:"pipeBytes,omitempty"`
+	// pipe collectors exceeded max with the bytes the program wrote (read
+	// from the pipe including the discarded, at least)
+	TruncatedFiles map[string]uint64 `protobuf:"bytes,32,rep,name=truncatedFiles,proto3" json:"truncatedFiles,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// max sampled swap usage of the cgroup (unless swap disabled)
+	SwapUsage uint64 `protobuf:"varint,33,opt,name=swapUsage,proto3" json:"swapUsage,omitempty"`
+}
+
+func (x *Response_Result) Reset() {
+	*x = Response_Result{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_judge_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Response_Result) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Response_Result) ProtoMessage() {}
+
+func (x *Response_Result) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Response_Result.ProtoReflect.Descriptor instead.
+func (*Response_Result) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{5, 0}
+}
+
+func (x *Response_Result) GetStatus() Response_Result_StatusType {
+	if x != nil {
+		return x.Status
+	}
+	return Response_Result_Invalid
+}
+
+func (x *Response_Result) GetExitStatus() int32 {
+	if x != nil {
+		return x.ExitStatus
+	}
+	return 0
+}
+
+func (x *Response_Result) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Response_Result) GetTime() uint64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *Response_Result) GetRunTime() uint64 {
+	if x != nil {
+		return x.RunTime
+	}
+	return 0
+}
+
+func (x *Response_Result) GetMemory() uint64 {
+	if x != nil {
+		return x.Memory
+	}
+	return 0
+}
+
+func (x *Response_Result) GetFiles() map[string][]byte {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *Response_Result) GetFileIDs() map[string]string {
+	if x != nil {
+		return x.FileIDs
+	}
+	return nil
+}
+
+func (x *Response_Result) GetEnvironmentTime() uint64 {
+	if x != nil {
+		return x.EnvironmentTime
+	}
+	return 0
+}
+
+func (x *Response_Result) GetCgroupTime() uint64 {
+	if x != nil {
+		return x.CgroupTime
+	}
+	return 0
+}
+
+func (x *Response_Result) GetWarning() string {
+	if x != nil {
+		return x.Warning
+	}
+	return ""
+}
+
+func (x *Response_Result) GetProcPeak() uint64 {
+	if x != nil {
+		return x.ProcPeak
+	}
+	return 0
+}
+
+func (x *Response_Result) GetRetries() int32 {
+	if x != nil {
+		return x.Retries
+	}
+	return 0
+}
+
+func (x *Response_Result) GetMemoryWarning() bool {
+	if x != nil {
+		return x.MemoryWarning
+	}
+	return false
+}
+
+func (x *Response_Result) GetDebug() *Response_DebugInfo {
+	if x != nil {
+		return x.Debug
+	}
+	return nil
+}
+
+func (x *Response_Result) GetCacheHit() bool {
+	if x != nil {
+		return x.CacheHit
+	}
+	return false
+}
+
+func (x *Response_Result) GetPreserved() string {
+	if x != nil {
+		return x.Preserved
+	}
+	return ""
+}
+
+func (x *Response_Result) GetLimitsClamped() []string {
+	if x != nil {
+		return x.LimitsClamped
+	}
+	return nil
+}
+
+func (x *Response_Result) GetUserTime() uint64 {
+	if x != nil {
+		return x.UserTime
+	}
+	return 0
+}
+
+func (x *Response_Result) GetSystemTime() uint64 {
+	if x != nil {
+		return x.SystemTime
+	}
+	return 0
+}
+
+func (x *Response_Result) GetPostExec() *Response_PostExecResult {
+	if x != nil {
+		return x.PostExec
+	}
+	return nil
+}
+
+func (x *Response_Result) GetExitReason() Response_Result_ExitReasonType {
+	if x != nil {
+		return x.ExitReason
+	}
+	return Response_Result_ExitReasonInvalid
+}
+
+func (x *Response_Result) GetMemoryPeak() uint64 {
+	if x != nil {
+		return x.MemoryPeak
+	}
+	return 0
+}
+
+func (x *Response_Result) GetEarlyResult() bool {
+	if x != nil {
+		return x.EarlyResult
+	}
+	return false
+}
+
+func (x *Response_Result) GetReapTimeout() bool {
+	if x != nil {
+		return x.ReapTimeout
+	}
+	return false
+}
+
+func (x *Response_Result) GetInstructions() uint64 {
+	if x != nil {
+		return x.Instructions
+	}
+	return 0
+}
+
+func (x *Response_Result) GetOmittedFiles() map[string]uint64 {
+	if x != nil {
+		return x.OmittedFiles
+	}
+	return nil
+}
+
+func (x *Response_Result) GetSocketPeak() uint64 {
+	if x != nil {
+		return x.SocketPeak
+	}
+	return 0
+}
+
+func (x *Response_Result) GetProcLimitHit() Response_Result_ProcLimitHitType {
+	if x != nil {
+		return x.ProcLimitHit
+	}
+	return Response_Result_ProcLimitHitNone
+}
+
+func (x *Response_Result) GetCopyOutBytes() uint64 {
+	if x != nil {
+		return x.CopyOutBytes
+	}
+	return 0
+}
+
+func (x *Response_Result) GetPipeBytes() uint64 {
+	if x != nil {
+		return x.PipeBytes
+	}
+	return 0
+}
+
+func (x *Response_Result) GetTruncatedFiles() map[string]uint64 {
+	if x != nil {
+		return x.TruncatedFiles
+	}
+	return nil
+}
+
+func (x *Response_Result) GetSwapUsage() uint64 {
+	if x != nil {
+		return x.SwapUsage
+	}
+	return 0
+}
+
+type Response_PostExecResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status     Response_Result_StatusType `protobuf:"varint,1,opt,name=status,proto3,enum=pb.Response_Result_StatusType" json:"status,omitempty"`
+	ExitStatus int32                      `protobuf:"varint,2,opt,name=exitStatus,proto3" json:"exitStatus,omitempty"`
+	Error      string                     `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Time       uint64                     `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *Response_PostExecResult) Reset() {
+	*x = Response_PostExecResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_judge_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Response_PostExecResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Response_PostExecResult) ProtoMessage() {}
+
+func (x *Response_PostExecResult) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Response_PostExecResult.ProtoReflect.Descriptor instead.
+func (*Response_PostExecResult) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{5, 1}
+}
+
+func (x *Response_PostExecResult) GetStatus() Response_Result_StatusType {
+	if x != nil {
+		return x.Status
+	}
+	return Response_Result_Invalid
+}
+
+func (x *Response_PostExecResult) GetExitStatus() int32 {
+	if x != nil {
+		return x.ExitStatus
+	}
+	return 0
+}
+
+func (x *Response_PostExecResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Response_PostExecResult) GetTime() uint64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type Response_DebugInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Profile           string   `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	Seccomp           string   `protobuf:"bytes,2,opt,name=seccomp,proto3" json:"seccomp,omitempty"`
+	Args              []string `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`
+	Env               []string `protobuf:"bytes,4,rep,name=env,proto3" json:"env,omitempty"`
+	CpuTimeLimit      uint64   `protobuf:"varint,5,opt,name=cpuTimeLimit,proto3" json:"cpuTimeLimit,omitempty"`
+	ClockTimeLimit    uint64   `protobuf:"varint,6,opt,name=clockTimeLimit,proto3" json:"clockTimeLimit,omitempty"`
+	MemoryLimit       uint64   `protobuf:"varint,7,opt,name=memoryLimit,proto3" json:"memoryLimit,omitempty"`
+	StackLimit        uint64   `protobuf:"varint,8,opt,name=stackLimit,proto3" json:"stackLimit,omitempty"`
+	OutputLimit       uint64   `protobuf:"varint,9,opt,name=outputLimit,proto3" json:"outputLimit,omitempty"`
+	ProcLimit         uint64   `protobuf:"varint,10,opt,name=procLimit,proto3" json:"procLimit,omitempty"`
+	CPURateLimit      float64  `protobuf:"fixed64,11,opt,name=CPURateLimit,proto3" json:"CPURateLimit,omitempty"`
+	StrictMemoryLimit bool     `protobuf:"varint,12,opt,name=strictMemoryLimit,proto3" json:"strictMemoryLimit,omitempty"`
+	CopyOutMax        uint64   `protobuf:"varint,13,opt,name=copyOutMax,proto3" json:"copyOutMax,omitempty"`
+	// cgroup strategy: pooled or fresh
+	Cgroup string `protobuf:"bytes,14,opt,name=cgroup,proto3" json:"cgroup,omitempty"`
+	// open fds with targets (e.g. "3 -> /w/a.txt") last sampled before the
+	// program exited or right before killed
+	OpenFileCount int32    `protobuf:"varint,15,opt,name=openFileCount,proto3" json:"openFileCount,omitempty"`
+	OpenFiles     []string `protobuf:"bytes,16,rep,name=openFiles,proto3" json:"openFiles,omitempty"`
+	// syscalls entered by the program and its children by name
+	Syscalls map[string]uint64 `protobuf:"bytes,17,rep,name=syscalls,proto3" json:"syscalls,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// cgroup version (0 without cgroup) and the controllers set up for the
+	// program, missing controllers are not limited by cgroup
+	CgroupVersion     int32    `protobuf:"varint,18,opt,name=cgroupVersion,proto3" json:"cgroupVersion,omitempty"`
+	CgroupControllers []string `protobuf:"bytes,19,rep,name=cgroupControllers,proto3" json:"cgroupControllers,omitempty"`
+	// stderr of the container init if it failed to set up the exec
+	Diagnosis string `protobuf:"bytes,20,opt,name=diagnosis,proto3" json:"diagnosis,omitempty"`
+}
+
+func (x *Response_DebugInfo) Reset() {
+	*x = Response_DebugInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_judge_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Request_CmdType) String() string {
+func (x *Response_DebugInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Request_CmdType) ProtoMessage() {}
+func (*Response_DebugInfo) ProtoMessage() {}
 
-func (x *Request_CmdType) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[14]
+func (x *Response_DebugInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1035,149 +3295,183 @@ func (x *Request_CmdType) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Request_CmdType.ProtoReflect.Descriptor instead.
-func (*Request_CmdType) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{3, 7}
+// Deprecated: Use Response_DebugInfo.ProtoReflect.Descriptor instead.
+func (*Response_DebugInfo) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{5, 2}
 }
 
-func (x *Request_CmdType) GetArgs() []string {
+func (x *Response_DebugInfo) GetProfile() string {
 	if x != nil {
-		return x.Args
+		return x.Profile
 	}
-	return nil
+	return ""
 }
 
-func (x *Request_CmdType) GetEnv() []string {
+func (x *Response_DebugInfo) GetSeccomp() string {
 	if x != nil {
-		return x.Env
+		return x.Seccomp
 	}
-	return nil
+	return ""
 }
 
-func (x *Request_CmdType) GetFiles() []*Request_File {
+func (x *Response_DebugInfo) GetArgs() []string {
 	if x != nil {
-		return x.Files
+		return x.Args
 	}
 	return nil
 }
 
-func (x *Request_CmdType) GetTty() bool {
+func (x *Response_DebugInfo) GetEnv() []string {
 	if x != nil {
-		return x.Tty
+		return x.Env
 	}
-	return false
+	return nil
 }
 
-func (x *Request_CmdType) GetCpuTimeLimit() uint64 {
+func (x *Response_DebugInfo) GetCpuTimeLimit() uint64 {
 	if x != nil {
 		return x.CpuTimeLimit
 	}
 	return 0
 }
 
-func (x *Request_CmdType) GetClockTimeLimit() uint64 {
+func (x *Response_DebugInfo) GetClockTimeLimit() uint64 {
 	if x != nil {
 		return x.ClockTimeLimit
 	}
 	return 0
 }
 
-func (x *Request_CmdType) GetMemoryLimit() uint64 {
+func (x *Response_DebugInfo) GetMemoryLimit() uint64 {
 	if x != nil {
 		return x.MemoryLimit
 	}
 	return 0
 }
 
-func (x *Request_CmdType) GetStackLimit() uint64 {
+func (x *Response_DebugInfo) GetStackLimit() uint64 {
 	if x != nil {
 		return x.StackLimit
 	}
 	return 0
 }
 
-func (x *Request_CmdType) GetProcLimit() uint64 {
+func (x *Response_DebugInfo) GetOutputLimit() uint64 {
+	if x != nil {
+		return x.OutputLimit
+	}
+	return 0
+}
+
+func (x *Response_DebugInfo) GetProcLimit() uint64 {
 	if x != nil {
 		return x.ProcLimit
 	}
 	return 0
 }
 
-func (x *Request_CmdType) GetCPURateLimit() float64 {
+func (x *Response_DebugInfo) GetCPURateLimit() float64 {
 	if x != nil {
 		return x.CPURateLimit
 	}
 	return 0
 }
 
-func (x *Request_CmdType) GetCopyIn() map[string]*Request_File {
+func (x *Response_DebugInfo) GetStrictMemoryLimit() bool {
 	if x != nil {
-		return x.CopyIn
+		return x.StrictMemoryLimit
 	}
-	return nil
+	return false
 }
 
-func (x *Request_CmdType) GetCopyOut() []string {
+func (x *Response_DebugInfo) GetCopyOutMax() uint64 {
 	if x != nil {
-		return x.CopyOut
+		return x.CopyOutMax
 	}
-	return nil
+	return 0
 }
 
-func (x *Request_CmdType) GetCopyOutCached() []string {
+func (x *Response_DebugInfo) GetCgroup() string {
 	if x != nil {
-		return x.CopyOutCached
+		return x.Cgroup
+	}
+	return ""
+}
+
+func (x *Response_DebugInfo) GetOpenFileCount() int32 {
+	if x != nil {
+		return x.OpenFileCount
+	}
+	return 0
+}
+
+func (x *Response_DebugInfo) GetOpenFiles() []string {
+	if x != nil {
+		return x.OpenFiles
 	}
 	return nil
 }
 
-func (x *Request_CmdType) GetCopyOutDir() string {
+func (x *Response_DebugInfo) GetSyscalls() map[string]uint64 {
 	if x != nil {
-		return x.CopyOutDir
+		return x.Syscalls
 	}
-	return ""
+	return nil
 }
 
-func (x *Request_CmdType) GetCopyOutMax() uint64 {
+func (x *Response_DebugInfo) GetCgroupVersion() int32 {
 	if x != nil {
-		return x.CopyOutMax
+		return x.CgroupVersion
 	}
 	return 0
 }
 
-func (x *Request_CmdType) GetStrictMemoryLimit() bool {
+func (x *Response_DebugInfo) GetCgroupControllers() []string {
 	if x != nil {
-		return x.StrictMemoryLimit
+		return x.CgroupControllers
 	}
-	return false
+	return nil
 }
 
-type Request_PipeMap struct {
+func (x *Response_DebugInfo) GetDiagnosis() string {
+	if x != nil {
+		return x.Diagnosis
+	}
+	return ""
+}
+
+// first difference located in the output (1 based) with the differing
+// line or token of both sides
+type Response_CompareResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	In  *Request_PipeMap_PipeIndex `protobuf:"bytes,1,opt,name=in,proto3" json:"in,omitempty"`
-	Out *Request_PipeMap_PipeIndex `protobuf:"bytes,2,opt,name=out,proto3" json:"out,omitempty"`
+	Match    bool   `protobuf:"varint,1,opt,name=match,proto3" json:"match,omitempty"`
+	Line     int32  `protobuf:"varint,2,opt,name=line,proto3" json:"line,omitempty"`
+	Column   int32  `protobuf:"varint,3,opt,name=column,proto3" json:"column,omitempty"`
+	Expected string `protobuf:"bytes,4,opt,name=expected,proto3" json:"expected,omitempty"`
+	Actual   string `protobuf:"bytes,5,opt,name=actual,proto3" json:"actual,omitempty"`
+	Error    string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
 }
 
-func (x *Request_PipeMap) Reset() {
-	*x = Request_PipeMap{}
+func (x *Response_CompareResult) Reset() {
+	*x = Response_CompareResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[15]
+		mi := &file_judge_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Request_PipeMap) String() string {
+func (x *Response_CompareResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Request_PipeMap) ProtoMessage() {}
+func (*Response_CompareResult) ProtoMessage() {}
 
-func (x *Request_PipeMap) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[15]
+func (x *Response_CompareResult) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1188,51 +3482,79 @@ func (x *Request_PipeMap) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Request_PipeMap.ProtoReflect.Descriptor instead.
-func (*Request_PipeMap) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{3, 8}
+// Deprecated: Use Response_CompareResult.ProtoReflect.Descriptor instead.
+func (*Response_CompareResult) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{5, 3}
 }
 
-func (x *Request_PipeMap) GetIn() *Request_PipeMap_PipeIndex {
+func (x *Response_CompareResult) GetMatch() bool {
 	if x != nil {
-		return x.In
+		return x.Match
 	}
-	return nil
+	return false
 }
 
-func (x *Request_PipeMap) GetOut() *Request_PipeMap_PipeIndex {
+func (x *Response_CompareResult) GetLine() int32 {
 	if x != nil {
-		return x.Out
+		return x.Line
 	}
-	return nil
+	return 0
 }
 
-type Request_PipeMap_PipeIndex struct {
+func (x *Response_CompareResult) GetColumn() int32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+func (x *Response_CompareResult) GetExpected() string {
+	if x != nil {
+		return x.Expected
+	}
+	return ""
+}
+
+func (x *Response_CompareResult) GetActual() string {
+	if x != nil {
+		return x.Actual
+	}
+	return ""
+}
+
+func (x *Response_CompareResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type StreamRequest_Input struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Index int32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
-	Fd    int32 `protobuf:"varint,2,opt,name=fd,proto3" json:"fd,omitempty"`
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Content []byte `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
 }
 
-func (x *Request_PipeMap_PipeIndex) Reset() {
-	*x = Request_PipeMap_PipeIndex{}
+func (x *StreamRequest_Input) Reset() {
+	*x = StreamRequest_Input{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[17]
+		mi := &file_judge_proto_msgTypes[40]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Request_PipeMap_PipeIndex) String() string {
+func (x *StreamRequest_Input) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Request_PipeMap_PipeIndex) ProtoMessage() {}
+func (*StreamRequest_Input) ProtoMessage() {}
 
-func (x *Request_PipeMap_PipeIndex) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[17]
+func (x *StreamRequest_Input) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[40]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1243,57 +3565,54 @@ func (x *Request_PipeMap_PipeIndex) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Request_PipeMap_PipeIndex.ProtoReflect.Descriptor instead.
-func (*Request_PipeMap_PipeIndex) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{3, 8, 0}
+// Deprecated: Use StreamRequest_Input.ProtoReflect.Descriptor instead.
+func (*StreamRequest_Input) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{6, 0}
 }
 
-func (x *Request_PipeMap_PipeIndex) GetIndex() int32 {
+func (x *StreamRequest_Input) GetName() string {
 	if x != nil {
-		return x.Index
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *Request_PipeMap_PipeIndex) GetFd() int32 {
+func (x *StreamRequest_Input) GetContent() []byte {
 	if x != nil {
-		return x.Fd
+		return x.Content
 	}
-	return 0
+	return nil
 }
 
-type Response_Result struct {
+type StreamRequest_Resize struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status     Response_Result_StatusType `protobuf:"varint,1,opt,name=status,proto3,enum=pb.Response_Result_StatusType" json:"status,omitempty"`
-	ExitStatus int32                      `protobuf:"varint,2,opt,name=exitStatus,proto3" json:"exitStatus,omitempty"`
-	Error      string                     `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
-	Time       uint64                     `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
-	RunTime    uint64                     `protobuf:"varint,8,opt,name=runTime,proto3" json:"runTime,omitempty"`
-	Memory     uint64                     `protobuf:"varint,5,opt,name=memory,proto3" json:"memory,omitempty"`
-	Files      map[string][]byte          `protobuf:"bytes,6,rep,name=files,proto3" json:"files,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	FileIDs    map[string]string          `protobuf:"bytes,7,rep,name=fileIDs,proto3" json:"fileIDs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Rows uint32 `protobuf:"varint,2,opt,name=rows,proto3" json:"rows,omitempty"`
+	Cols uint32 `protobuf:"varint,3,opt,name=cols,proto3" json:"cols,omitempty"`
+	X    uint32 `protobuf:"varint,4,opt,name=x,proto3" json:"x,omitempty"`
+	Y    uint32 `protobuf:"varint,5,opt,name=y,proto3" json:"y,omitempty"`
 }
 
-func (x *Response_Result) Reset() {
-	*x = Response_Result{}
+func (x *StreamRequest_Resize) Reset() {
+	*x = StreamRequest_Resize{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[18]
+		mi := &file_judge_proto_msgTypes[41]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Response_Result) String() string {
+func (x *StreamRequest_Resize) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Response_Result) ProtoMessage() {}
+func (*StreamRequest_Resize) ProtoMessage() {}
 
-func (x *Response_Result) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[18]
+func (x *StreamRequest_Resize) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[41]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1304,93 +3623,71 @@ func (x *Response_Result) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Response_Result.ProtoReflect.Descriptor instead.
-func (*Response_Result) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{4, 0}
-}
-
-func (x *Response_Result) GetStatus() Response_Result_StatusType {
-	if x != nil {
-		return x.Status
-	}
-	return Response_Result_Invalid
-}
-
-func (x *Response_Result) GetExitStatus() int32 {
-	if x != nil {
-		return x.ExitStatus
-	}
-	return 0
+// Deprecated: Use StreamRequest_Resize.ProtoReflect.Descriptor instead.
+func (*StreamRequest_Resize) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{6, 1}
 }
 
-func (x *Response_Result) GetError() string {
+func (x *StreamRequest_Resize) GetName() string {
 	if x != nil {
-		return x.Error
+		return x.Name
 	}
 	return ""
 }
 
-func (x *Response_Result) GetTime() uint64 {
+func (x *StreamRequest_Resize) GetRows() uint32 {
 	if x != nil {
-		return x.Time
+		return x.Rows
 	}
 	return 0
 }
 
-func (x *Response_Result) GetRunTime() uint64 {
+func (x *StreamRequest_Resize) GetCols() uint32 {
 	if x != nil {
-		return x.RunTime
+		return x.Cols
 	}
 	return 0
 }
 
-func (x *Response_Result) GetMemory() uint64 {
+func (x *StreamRequest_Resize) GetX() uint32 {
 	if x != nil {
-		return x.Memory
+		return x.X
 	}
 	return 0
 }
 
-func (x *Response_Result) GetFiles() map[string][]byte {
-	if x != nil {
-		return x.Files
-	}
-	return nil
-}
-
-func (x *Response_Result) GetFileIDs() map[string]string {
+func (x *StreamRequest_Resize) GetY() uint32 {
 	if x != nil {
-		return x.FileIDs
+		return x.Y
 	}
-	return nil
+	return 0
 }
 
-type StreamRequest_Input struct {
+// cancels the request, the running cmd is killed and the rest inputs are
+// not run, the response is still sent
+type StreamRequest_Abort struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Content []byte `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
 }
 
-func (x *StreamRequest_Input) Reset() {
-	*x = StreamRequest_Input{}
+func (x *StreamRequest_Abort) Reset() {
+	*x = StreamRequest_Abort{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[21]
+		mi := &file_judge_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StreamRequest_Input) String() string {
+func (x *StreamRequest_Abort) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StreamRequest_Input) ProtoMessage() {}
+func (*StreamRequest_Abort) ProtoMessage() {}
 
-func (x *StreamRequest_Input) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[21]
+func (x *StreamRequest_Abort) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1401,54 +3698,37 @@ func (x *StreamRequest_Input) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StreamRequest_Input.ProtoReflect.Descriptor instead.
-func (*StreamRequest_Input) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{5, 0}
-}
-
-func (x *StreamRequest_Input) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *StreamRequest_Input) GetContent() []byte {
-	if x != nil {
-		return x.Content
-	}
-	return nil
+// Deprecated: Use StreamRequest_Abort.ProtoReflect.Descriptor instead.
+func (*StreamRequest_Abort) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{6, 2}
 }
 
-type StreamRequest_Resize struct {
+type StreamResponse_Output struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Rows uint32 `protobuf:"varint,2,opt,name=rows,proto3" json:"rows,omitempty"`
-	Cols uint32 `protobuf:"varint,3,opt,name=cols,proto3" json:"cols,omitempty"`
-	X    uint32 `protobuf:"varint,4,opt,name=x,proto3" json:"x,omitempty"`
-	Y    uint32 `protobuf:"varint,5,opt,name=y,proto3" json:"y,omitempty"`
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Content []byte `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
 }
 
-func (x *StreamRequest_Resize) Reset() {
-	*x = StreamRequest_Resize{}
+func (x *StreamResponse_Output) Reset() {
+	*x = StreamResponse_Output{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[22]
+		mi := &file_judge_proto_msgTypes[43]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StreamRequest_Resize) String() string {
+func (x *StreamResponse_Output) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StreamRequest_Resize) ProtoMessage() {}
+func (*StreamResponse_Output) ProtoMessage() {}
 
-func (x *StreamRequest_Resize) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[22]
+func (x *StreamResponse_Output) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[43]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1459,72 +3739,51 @@ func (x *StreamRequest_Resize) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StreamRequest_Resize.ProtoReflect.Descriptor instead.
-func (*StreamRequest_Resize) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{5, 1}
+// Deprecated: Use StreamResponse_Output.ProtoReflect.Descriptor instead.
+func (*StreamResponse_Output) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{7, 0}
 }
 
-func (x *StreamRequest_Resize) GetName() string {
+func (x *StreamResponse_Output) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *StreamRequest_Resize) GetRows() uint32 {
-	if x != nil {
-		return x.Rows
-	}
-	return 0
-}
-
-func (x *StreamRequest_Resize) GetCols() uint32 {
-	if x != nil {
-		return x.Cols
-	}
-	return 0
-}
-
-func (x *StreamRequest_Resize) GetX() uint32 {
-	if x != nil {
-		return x.X
-	}
-	return 0
-}
-
-func (x *StreamRequest_Resize) GetY() uint32 {
+func (x *StreamResponse_Output) GetContent() []byte {
 	if x != nil {
-		return x.Y
+		return x.Content
 	}
-	return 0
+	return nil
 }
 
-type StreamResponse_Output struct {
+type StreamResponse_PartialResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Content []byte `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Index  int32            `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Result *Response_Result `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
 }
 
-func (x *StreamResponse_Output) Reset() {
-	*x = StreamResponse_Output{}
+func (x *StreamResponse_PartialResult) Reset() {
+	*x = StreamResponse_PartialResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_judge_proto_msgTypes[23]
+		mi := &file_judge_proto_msgTypes[44]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StreamResponse_Output) String() string {
+func (x *StreamResponse_PartialResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StreamResponse_Output) ProtoMessage() {}
+func (*StreamResponse_PartialResult) ProtoMessage() {}
 
-func (x *StreamResponse_Output) ProtoReflect() protoreflect.Message {
-	mi := &file_judge_proto_msgTypes[23]
+func (x *StreamResponse_PartialResult) ProtoReflect() protoreflect.Message {
+	mi := &file_judge_proto_msgTypes[44]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1535,21 +3794,21 @@ func (x *StreamResponse_Output) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StreamResponse_Output.ProtoReflect.Descriptor instead.
-func (*StreamResponse_Output) Descriptor() ([]byte, []int) {
-	return file_judge_proto_rawDescGZIP(), []int{6, 0}
+// Deprecated: Use StreamResponse_PartialResult.ProtoReflect.Descriptor instead.
+func (*StreamResponse_PartialResult) Descriptor() ([]byte, []int) {
+	return file_judge_proto_rawDescGZIP(), []int{7, 1}
 }
 
-func (x *StreamResponse_Output) GetName() string {
+func (x *StreamResponse_PartialResult) GetIndex() int32 {
 	if x != nil {
-		return x.Name
+		return x.Index
 	}
-	return ""
+	return 0
 }
 
-func (x *StreamResponse_Output) GetContent() []byte {
+func (x *StreamResponse_PartialResult) GetResult() *Response_Result {
 	if x != nil {
-		return x.Content
+		return x.Result
 	}
 	return nil
 }
@@ -1559,172 +3818,601 @@ var File_judge_proto protoreflect.FileDescriptor
 var file_judge_proto_rawDesc = []byte{
 	0x0a, 0x0b, 0x6a, 0x75, 0x64, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70,
 	0x62, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x20,
-	0x0a, 0x06, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x65,
-	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x44,
-	0x22, 0x3b, 0x0a, 0x0b, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12,
-	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x28, 0x0a,
-	0x0c, 0x46, 0x69, 0x6c, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a,
-	0x07, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
-	0x66, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x73, 0x22, 0xc5, 0x0b, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x44,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49,
-	0x44, 0x12, 0x25, 0x0a, 0x03, 0x63, 0x6d, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13,
-	0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6d, 0x64, 0x54,
-	0x79, 0x70, 0x65, 0x52, 0x03, 0x63, 0x6d, 0x64, 0x12, 0x35, 0x0a, 0x0b, 0x70, 0x69, 0x70, 0x65,
-	0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e,
-	0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x4d,
-	0x61, 0x70, 0x52, 0x0b, 0x70, 0x69, 0x70, 0x65, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x1a,
-	0x1d, 0x0a, 0x09, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x03,
-	0x73, 0x72, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x72, 0x63, 0x1a, 0x26,
-	0x0a, 0x0a, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07,
-	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63,
-	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x1a, 0x24, 0x0a, 0x0a, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64,
-	0x46, 0x69, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x1a, 0x35, 0x0a, 0x0d,
-	0x50, 0x69, 0x70, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x12, 0x0a,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03,
-	0x6d, 0x61, 0x78, 0x1a, 0x21, 0x0a, 0x0b, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e, 0x70,
-	0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x1a, 0x22, 0x0a, 0x0c, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
-	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x1a, 0xc3, 0x02, 0x0a, 0x04, 0x46,
-	0x69, 0x6c, 0x65, 0x12, 0x2d, 0x0a, 0x05, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
-	0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x46, 0x69, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x05, 0x6c, 0x6f, 0x63,
-	0x61, 0x6c, 0x12, 0x30, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
-	0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x06, 0x6d, 0x65,
-	0x6d, 0x6f, 0x72, 0x79, 0x12, 0x30, 0x0a, 0x06, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x06,
-	0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x12, 0x2f, 0x0a, 0x04, 0x70, 0x69, 0x70, 0x65, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x48,
-	0x00, 0x52, 0x04, 0x70, 0x69, 0x70, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61,
-	0x6d, 0x49, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e, 0x70,
-	0x75, 0x74, 0x48, 0x00, 0x52, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e, 0x12, 0x38,
-	0x0a, 0x09, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x75, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53,
-	0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x48, 0x00, 0x52, 0x09, 0x73,
-	0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x75, 0x74, 0x42, 0x06, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65,
-	0x1a, 0xed, 0x04, 0x0a, 0x07, 0x43, 0x6d, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04,
-	0x61, 0x72, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73,
-	0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x65,
-	0x6e, 0x76, 0x12, 0x26, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46,
-	0x69, 0x6c, 0x65, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74,
-	0x79, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x74, 0x74, 0x79, 0x12, 0x22, 0x0a, 0x0c,
-	0x63, 0x70, 0x75, 0x54, 0x69, 0x6d, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x0c, 0x63, 0x70, 0x75, 0x54, 0x69, 0x6d, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74,
-	0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x4c, 0x69, 0x6d,
-	0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x54,
-	0x69, 0x6d, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x65, 0x6d, 0x6f,
-	0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x6d,
-	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74,
-	0x61, 0x63, 0x6b, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a,
-	0x73, 0x74, 0x61, 0x63, 0x6b, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72,
-	0x6f, 0x63, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x70,
-	0x72, 0x6f, 0x63, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x43, 0x50, 0x55, 0x52,
-	0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c,
-	0x43, 0x50, 0x55, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x37, 0x0a, 0x06,
-	0x63, 0x6f, 0x70, 0x79, 0x49, 0x6e, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70,
+	0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb9,
+	0x01, 0x0a, 0x0b, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x22,
+	0x0a, 0x0c, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x67, 0x6f, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x6f, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x0e, 0x0a, 0x02,
+	0x6f, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x73, 0x12, 0x20, 0x0a, 0x0b,
+	0x70, 0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x69, 0x73, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0b, 0x70, 0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x69, 0x73, 0x6d, 0x12, 0x1a,
+	0x0a, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x22, 0x20, 0x0a, 0x06, 0x46, 0x69,
+	0x6c, 0x65, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x22, 0x4d, 0x0a, 0x0b,
+	0x46, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x6c,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x22, 0x28, 0x0a, 0x0c, 0x46,
+	0x69, 0x6c, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x66,
+	0x69, 0x6c, 0x65, 0x49, 0x44, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x66, 0x69,
+	0x6c, 0x65, 0x49, 0x44, 0x73, 0x22, 0xce, 0x26, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x44, 0x12,
+	0x25, 0x0a, 0x03, 0x63, 0x6d, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70,
 	0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6d, 0x64, 0x54, 0x79, 0x70,
-	0x65, 0x2e, 0x43, 0x6f, 0x70, 0x79, 0x49, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x63,
-	0x6f, 0x70, 0x79, 0x49, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74,
-	0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x12,
-	0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64,
-	0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x43,
-	0x61, 0x63, 0x68, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74,
-	0x44, 0x69, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x70, 0x79, 0x4f,
-	0x75, 0x74, 0x44, 0x69, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74,
-	0x4d, 0x61, 0x78, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x63, 0x6f, 0x70, 0x79, 0x4f,
-	0x75, 0x74, 0x4d, 0x61, 0x78, 0x12, 0x2c, 0x0a, 0x11, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x4d,
-	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x11, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69,
-	0x6d, 0x69, 0x74, 0x1a, 0x4b, 0x0a, 0x0b, 0x43, 0x6f, 0x70, 0x79, 0x49, 0x6e, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x6b, 0x65, 0x79, 0x12, 0x26, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
-	0x1a, 0x9c, 0x01, 0x0a, 0x07, 0x50, 0x69, 0x70, 0x65, 0x4d, 0x61, 0x70, 0x12, 0x2d, 0x0a, 0x02,
-	0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x4d, 0x61, 0x70, 0x2e, 0x50, 0x69,
-	0x70, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x02, 0x69, 0x6e, 0x12, 0x2f, 0x0a, 0x03, 0x6f,
-	0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x4d, 0x61, 0x70, 0x2e, 0x50, 0x69,
-	0x70, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x03, 0x6f, 0x75, 0x74, 0x1a, 0x31, 0x0a, 0x09,
-	0x50, 0x69, 0x70, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64,
+	0x65, 0x52, 0x03, 0x63, 0x6d, 0x64, 0x12, 0x35, 0x0a, 0x0b, 0x70, 0x69, 0x70, 0x65, 0x4d, 0x61,
+	0x70, 0x70, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x62,
+	0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x4d, 0x61, 0x70,
+	0x52, 0x0b, 0x70, 0x69, 0x70, 0x65, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x12, 0x24, 0x0a,
+	0x0d, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x12, 0x29, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x54,
+	0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x64, 0x65, 0x62, 0x75, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x64,
+	0x65, 0x62, 0x75, 0x67, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x74, 0x75,
+	0x72, 0x6e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67,
+	0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x77, 0x61, 0x72, 0x6d, 0x4f, 0x6e, 0x6c, 0x79, 0x18, 0x11, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x08, 0x77, 0x61, 0x72, 0x6d, 0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65,
+	0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x65, 0x65, 0x64, 0x12, 0x22,
+	0x0a, 0x0c, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x53, 0x65, 0x65, 0x64, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x53, 0x65,
+	0x65, 0x64, 0x12, 0x2d, 0x0a, 0x07, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x72, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x07, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65,
+	0x72, 0x12, 0x2c, 0x0a, 0x11, 0x70, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x4f, 0x6e, 0x46,
+	0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x70, 0x72,
+	0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x4f, 0x6e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x12,
+	0x20, 0x0a, 0x0b, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x75, 0x64, 0x67, 0x65, 0x74, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x75, 0x64, 0x67, 0x65,
+	0x74, 0x12, 0x26, 0x0a, 0x0e, 0x61, 0x63, 0x71, 0x75, 0x69, 0x72, 0x65, 0x54, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x18, 0x12, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x61, 0x63, 0x71, 0x75, 0x69,
+	0x72, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x29, 0x0a, 0x06, 0x69, 0x6e, 0x70,
+	0x75, 0x74, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x06, 0x69, 0x6e,
+	0x70, 0x75, 0x74, 0x73, 0x12, 0x2d, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x18,
+	0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x70,
+	0x61, 0x72, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x74, 0x6f, 0x70, 0x4f, 0x6e, 0x46, 0x61, 0x69,
+	0x6c, 0x75, 0x72, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x73, 0x74, 0x6f, 0x70,
+	0x4f, 0x6e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x1a, 0x31, 0x0a, 0x09, 0x4c, 0x6f, 0x63,
+	0x61, 0x6c, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x72, 0x63, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x72, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x6b,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x1a, 0x26, 0x0a, 0x0a,
+	0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x1a, 0x24, 0x0a, 0x0a, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x46, 0x69,
+	0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x1a, 0x1b, 0x0a, 0x07, 0x55, 0x52,
+	0x4c, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x1a, 0x0b, 0x0a, 0x09, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x46, 0x69, 0x6c, 0x65, 0x1a, 0xdd, 0x01, 0x0a, 0x0d, 0x50, 0x69, 0x70, 0x65, 0x43, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61,
+	0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x12, 0x40, 0x0a, 0x08,
+	0x6f, 0x6e, 0x45, 0x78, 0x63, 0x65, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x24,
+	0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x69, 0x70, 0x65,
+	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x08, 0x6f, 0x6e, 0x45, 0x78, 0x63, 0x65, 0x65, 0x64, 0x12, 0x22,
+	0x0a, 0x0c, 0x62, 0x61, 0x63, 0x6b, 0x70, 0x72, 0x65, 0x73, 0x73, 0x75, 0x72, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x62, 0x61, 0x63, 0x6b, 0x70, 0x72, 0x65, 0x73, 0x73, 0x75,
+	0x72, 0x65, 0x22, 0x40, 0x0a, 0x0a, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x0e, 0x0a, 0x0a, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x46, 0x61, 0x69, 0x6c, 0x10, 0x00,
+	0x12, 0x12, 0x0a, 0x0e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x54, 0x72, 0x75, 0x6e, 0x63, 0x61,
+	0x74, 0x65, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x4b, 0x69,
+	0x6c, 0x6c, 0x10, 0x02, 0x1a, 0x21, 0x0a, 0x0b, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e,
+	0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x1a, 0x22, 0x0a, 0x0c, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x1a, 0x9b, 0x03, 0x0a, 0x04,
+	0x46, 0x69, 0x6c, 0x65, 0x12, 0x2d, 0x0a, 0x05, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x46, 0x69, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x05, 0x6c, 0x6f,
+	0x63, 0x61, 0x6c, 0x12, 0x30, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x06, 0x6d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x30, 0x0a, 0x06, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x48, 0x00, 0x52,
+	0x06, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x12, 0x2f, 0x0a, 0x04, 0x70, 0x69, 0x70, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x48, 0x00, 0x52, 0x04, 0x70, 0x69, 0x70, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x49, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x62, 0x2e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e,
+	0x70, 0x75, 0x74, 0x48, 0x00, 0x52, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e, 0x12,
+	0x38, 0x0a, 0x09, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x75, 0x74, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x48, 0x00, 0x52, 0x09,
+	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x75, 0x74, 0x12, 0x27, 0x0a, 0x03, 0x75, 0x72, 0x6c,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x2e, 0x55, 0x52, 0x4c, 0x46, 0x69, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x03, 0x75,
+	0x72, 0x6c, 0x12, 0x2d, 0x0a, 0x05, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x05, 0x65, 0x6d, 0x70, 0x74,
+	0x79, 0x42, 0x06, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x1a, 0x36, 0x0a, 0x0a, 0x43, 0x6f, 0x70,
+	0x79, 0x4f, 0x75, 0x74, 0x44, 0x69, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x72, 0x63, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x72, 0x63, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69,
+	0x78, 0x1a, 0x94, 0x11, 0x0a, 0x07, 0x43, 0x6d, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67,
+	0x73, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03,
+	0x65, 0x6e, 0x76, 0x12, 0x26, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x46, 0x69, 0x6c, 0x65, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x74,
+	0x74, 0x79, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x74, 0x74, 0x79, 0x12, 0x22, 0x0a,
+	0x0c, 0x63, 0x70, 0x75, 0x54, 0x69, 0x6d, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0c, 0x63, 0x70, 0x75, 0x54, 0x69, 0x6d, 0x65, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x63, 0x6c, 0x6f, 0x63, 0x6b,
+	0x54, 0x69, 0x6d, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x65, 0x6d,
+	0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b,
+	0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x73,
+	0x74, 0x61, 0x63, 0x6b, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0a, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x70,
+	0x72, 0x6f, 0x63, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
+	0x70, 0x72, 0x6f, 0x63, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x43, 0x50, 0x55,
+	0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0c, 0x43, 0x50, 0x55, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x37, 0x0a,
+	0x06, 0x63, 0x6f, 0x70, 0x79, 0x49, 0x6e, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
+	0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6d, 0x64, 0x54, 0x79,
+	0x70, 0x65, 0x2e, 0x43, 0x6f, 0x70, 0x79, 0x49, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06,
+	0x63, 0x6f, 0x70, 0x79, 0x49, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75,
+	0x74, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74,
+	0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x43, 0x61, 0x63, 0x68, 0x65,
+	0x64, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74,
+	0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75,
+	0x74, 0x44, 0x69, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x70, 0x79,
+	0x4f, 0x75, 0x74, 0x44, 0x69, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75,
+	0x74, 0x4d, 0x61, 0x78, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x63, 0x6f, 0x70, 0x79,
+	0x4f, 0x75, 0x74, 0x4d, 0x61, 0x78, 0x12, 0x2c, 0x0a, 0x11, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74,
+	0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x11, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18,
+	0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x2a,
+	0x0a, 0x10, 0x73, 0x79, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x18, 0x12, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x73, 0x79, 0x73, 0x63, 0x61, 0x6c,
+	0x6c, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x2c, 0x0a, 0x11, 0x6b, 0x69,
+	0x6c, 0x6c, 0x4f, 0x6e, 0x53, 0x79, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x52, 0x61, 0x74, 0x65, 0x18,
+	0x13, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x6b, 0x69, 0x6c, 0x6c, 0x4f, 0x6e, 0x53, 0x79, 0x73,
+	0x63, 0x61, 0x6c, 0x6c, 0x52, 0x61, 0x74, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x6d, 0x65, 0x6d, 0x6f,
+	0x72, 0x79, 0x53, 0x6f, 0x66, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x14, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x53, 0x6f, 0x66, 0x74, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x6b, 0x69, 0x6c, 0x6c, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c,
+	0x18, 0x15, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6b, 0x69, 0x6c, 0x6c, 0x53, 0x69, 0x67, 0x6e,
+	0x61, 0x6c, 0x12, 0x28, 0x0a, 0x0f, 0x6b, 0x69, 0x6c, 0x6c, 0x47, 0x72, 0x61, 0x63, 0x65, 0x50,
+	0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x16, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x6b, 0x69, 0x6c,
+	0x6c, 0x47, 0x72, 0x61, 0x63, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x1e, 0x0a, 0x0a,
+	0x77, 0x72, 0x69, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x17, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0a, 0x77, 0x72, 0x69, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1c, 0x0a, 0x09,
+	0x66, 0x69, 0x6c, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x09, 0x66, 0x69, 0x6c, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x66, 0x69,
+	0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x1b, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0d, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x12, 0x20, 0x0a, 0x0b, 0x66, 0x72, 0x65, 0x73, 0x68, 0x43, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18,
+	0x1e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x66, 0x72, 0x65, 0x73, 0x68, 0x43, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x76, 0x46, 0x69, 0x6c, 0x65, 0x18, 0x1f, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x76, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x35, 0x0a, 0x08,
+	0x73, 0x74, 0x64, 0x69, 0x6e, 0x54, 0x65, 0x65, 0x18, 0x20, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x69, 0x70, 0x65,
+	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x08, 0x73, 0x74, 0x64, 0x69, 0x6e,
+	0x54, 0x65, 0x65, 0x12, 0x36, 0x0a, 0x16, 0x65, 0x61, 0x72, 0x6c, 0x79, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x51, 0x75, 0x69, 0x65, 0x74, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x21, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x16, 0x65, 0x61, 0x72, 0x6c, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x51, 0x75, 0x69, 0x65, 0x74, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x54, 0x0a, 0x10, 0x6d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x18,
+	0x22, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x28, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x2e, 0x43, 0x6d, 0x64, 0x54, 0x79, 0x70, 0x65, 0x2e, 0x4d, 0x65, 0x6d, 0x6f, 0x72,
+	0x79, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x10, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x69, 0x6e,
+	0x67, 0x12, 0x2a, 0x0a, 0x10, 0x69, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x23, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x69, 0x6e, 0x73,
+	0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x26, 0x0a,
+	0x0e, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x18,
+	0x24, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x50, 0x61,
+	0x72, 0x74, 0x69, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x69, 0x63, 0x65, 0x18, 0x25, 0x20,
+	0x01, 0x28, 0x11, 0x52, 0x04, 0x6e, 0x69, 0x63, 0x65, 0x12, 0x51, 0x0a, 0x0f, 0x73, 0x74, 0x64,
+	0x6f, 0x75, 0x74, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x26, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x27, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x43, 0x6d, 0x64, 0x54, 0x79, 0x70, 0x65, 0x2e, 0x53, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x42, 0x75,
+	0x66, 0x66, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0f, 0x73, 0x74, 0x64,
+	0x6f, 0x75, 0x74, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x20, 0x0a, 0x0b,
+	0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x27, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0b, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x24,
+	0x0a, 0x0d, 0x6f, 0x70, 0x65, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18,
+	0x28, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x6f, 0x70, 0x65, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6c, 0x65, 0x61, 0x6e, 0x57, 0x6f, 0x72,
+	0x6b, 0x44, 0x69, 0x72, 0x18, 0x29, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x63, 0x6c, 0x65, 0x61,
+	0x6e, 0x57, 0x6f, 0x72, 0x6b, 0x44, 0x69, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x6e, 0x70, 0x72, 0x6f,
+	0x63, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x2a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x6e, 0x70,
+	0x72, 0x6f, 0x63, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x73, 0x18, 0x2b, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73,
+	0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x79, 0x73, 0x63, 0x61, 0x6c, 0x6c,
+	0x73, 0x18, 0x2c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x79,
+	0x73, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75,
+	0x74, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x4d, 0x61, 0x78, 0x18, 0x2d, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0f, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x4d, 0x61, 0x78,
+	0x12, 0x30, 0x0a, 0x13, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x49, 0x6e, 0x63, 0x6c, 0x75,
+	0x64, 0x65, 0x50, 0x69, 0x70, 0x65, 0x73, 0x18, 0x2e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x63,
+	0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x50, 0x69, 0x70,
+	0x65, 0x73, 0x12, 0x4e, 0x0a, 0x0e, 0x74, 0x69, 0x6d, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x69, 0x6e, 0x67, 0x18, 0x2f, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x26, 0x2e, 0x70, 0x62, 0x2e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6d, 0x64, 0x54, 0x79, 0x70, 0x65, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x0e, 0x74, 0x69, 0x6d, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x69,
+	0x6e, 0x67, 0x12, 0x38, 0x0a, 0x0b, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x44, 0x69, 0x72,
+	0x73, 0x18, 0x18, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x44, 0x69, 0x72, 0x52,
+	0x0b, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75, 0x74, 0x44, 0x69, 0x72, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x63, 0x61, 0x63, 0x68, 0x65, 0x18, 0x19, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x63, 0x61, 0x63,
+	0x68, 0x65, 0x12, 0x3a, 0x0a, 0x07, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x18, 0x1c, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x43, 0x6d, 0x64, 0x54, 0x79, 0x70, 0x65, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x12, 0x1a,
+	0x0a, 0x08, 0x70, 0x6f, 0x73, 0x74, 0x45, 0x78, 0x65, 0x63, 0x18, 0x1d, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x08, 0x70, 0x6f, 0x73, 0x74, 0x45, 0x78, 0x65, 0x63, 0x1a, 0x4b, 0x0a, 0x0b, 0x43, 0x6f,
+	0x70, 0x79, 0x49, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x26, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3a, 0x0a, 0x0c, 0x53, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0x39, 0x0a, 0x14, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x41, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0e, 0x0a, 0x0a, 0x4d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x50, 0x65, 0x61, 0x6b, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x4d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x10, 0x01, 0x22, 0x51,
+	0x0a, 0x13, 0x53, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x69, 0x6e,
+	0x67, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x69,
+	0x6e, 0x67, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x42,
+	0x75, 0x66, 0x66, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x6e, 0x65, 0x10, 0x01, 0x12, 0x11,
+	0x0a, 0x0d, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x4e, 0x6f, 0x6e, 0x65, 0x10,
+	0x02, 0x22, 0x35, 0x0a, 0x12, 0x54, 0x69, 0x6d, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x69, 0x6e, 0x67, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0e, 0x0a, 0x0a, 0x54, 0x69, 0x6d, 0x65, 0x43,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x54, 0x69, 0x6d, 0x65, 0x50,
+	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x10, 0x01, 0x1a, 0xd4, 0x03, 0x0a, 0x07, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x65, 0x72, 0x12, 0x25, 0x0a, 0x03, 0x63, 0x6d, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43,
+	0x6d, 0x64, 0x54, 0x79, 0x70, 0x65, 0x52, 0x03, 0x63, 0x6d, 0x64, 0x12, 0x31, 0x0a, 0x06, 0x69,
+	0x6e, 0x70, 0x75, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x62,
+	0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x72,
+	0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x12, 0x43,
+	0x0a, 0x0a, 0x65, 0x78, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x23, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x45, 0x78, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x65, 0x78, 0x69, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x35, 0x0a, 0x07, 0x74, 0x65, 0x73, 0x74, 0x6c, 0x69, 0x62, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x6c, 0x69,
+	0x62, 0x52, 0x07, 0x74, 0x65, 0x73, 0x74, 0x6c, 0x69, 0x62, 0x1a, 0x43, 0x0a, 0x05, 0x49, 0x6e,
+	0x70, 0x75, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a,
+	0x03, 0x64, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x73, 0x74, 0x1a,
+	0x5d, 0x0a, 0x0f, 0x45, 0x78, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x34, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x54,
+	0x79, 0x70, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x4f,
+	0x0a, 0x07, 0x54, 0x65, 0x73, 0x74, 0x6c, 0x69, 0x62, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x70,
+	0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x1a,
+	0x9c, 0x01, 0x0a, 0x07, 0x50, 0x69, 0x70, 0x65, 0x4d, 0x61, 0x70, 0x12, 0x2d, 0x0a, 0x02, 0x69,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x4d, 0x61, 0x70, 0x2e, 0x50, 0x69, 0x70,
+	0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x02, 0x69, 0x6e, 0x12, 0x2f, 0x0a, 0x03, 0x6f, 0x75,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x4d, 0x61, 0x70, 0x2e, 0x50, 0x69, 0x70,
+	0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x03, 0x6f, 0x75, 0x74, 0x1a, 0x31, 0x0a, 0x09, 0x50,
+	0x69, 0x70, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x0e,
+	0x0a, 0x02, 0x66, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x66, 0x64, 0x1a, 0x37,
+	0x0a, 0x09, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0xb3, 0x01, 0x0a, 0x05, 0x49, 0x6e, 0x70, 0x75,
+	0x74, 0x12, 0x26, 0x0a, 0x05, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x69,
+	0x6c, 0x65, 0x52, 0x05, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x12, 0x35, 0x0a, 0x06, 0x63, 0x6f, 0x70,
+	0x79, 0x49, 0x6e, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x62, 0x2e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x2e, 0x43, 0x6f, 0x70,
+	0x79, 0x49, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x63, 0x6f, 0x70, 0x79, 0x49, 0x6e,
+	0x1a, 0x4b, 0x0a, 0x0b, 0x43, 0x6f, 0x70, 0x79, 0x49, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x26, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x69,
+	0x6c, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x86, 0x02,
+	0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64,
 	0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12,
-	0x0e, 0x0a, 0x02, 0x66, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x66, 0x64, 0x22,
-	0xb9, 0x06, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09,
-	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x44, 0x12, 0x2d, 0x0a, 0x07, 0x72, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x62,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x36, 0x0a,
+	0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e,
+	0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61,
+	0x72, 0x65, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x54, 0x79, 0x70, 0x65, 0x52, 0x06, 0x70,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x70, 0x73, 0x69, 0x6c, 0x6f, 0x6e,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x65, 0x70, 0x73, 0x69, 0x6c, 0x6f, 0x6e, 0x22,
+	0x55, 0x0a, 0x0a, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x54, 0x79, 0x70, 0x65, 0x12, 0x09, 0x0a,
+	0x05, 0x45, 0x78, 0x61, 0x63, 0x74, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x54, 0x72, 0x69, 0x6d,
+	0x54, 0x72, 0x61, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x49, 0x67,
+	0x6e, 0x6f, 0x72, 0x65, 0x57, 0x68, 0x69, 0x74, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x10, 0x02,
+	0x12, 0x09, 0x0a, 0x05, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x46,
+	0x6c, 0x6f, 0x61, 0x74, 0x10, 0x04, 0x22, 0xf9, 0x1e, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x44,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49,
+	0x44, 0x12, 0x2d, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x65, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x65, 0x65, 0x64, 0x12, 0x2d, 0x0a, 0x07, 0x63, 0x68,
+	0x65, 0x63, 0x6b, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x62,
 	0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72,
-	0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x1a,
-	0xc9, 0x05, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x36, 0x0a, 0x06, 0x73, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x70, 0x62, 0x2e,
+	0x52, 0x07, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x38, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x64, 0x69, 0x63, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x70, 0x62, 0x2e,
 	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x54, 0x79, 0x70, 0x65, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x65, 0x78, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07,
-	0x72, 0x75, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x72,
-	0x75, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x34,
-	0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x54, 0x79, 0x70, 0x65, 0x52, 0x07, 0x76, 0x65, 0x72, 0x64,
+	0x69, 0x63, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64,
+	0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x12, 0x30, 0x0a, 0x13, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x75, 0x64, 0x67, 0x65, 0x74,
+	0x45, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13,
+	0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x75, 0x64, 0x67, 0x65, 0x74, 0x45, 0x78, 0x63, 0x65, 0x65,
+	0x64, 0x65, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x72, 0x43, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x65, 0x72, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x34, 0x0a, 0x07, 0x63,
+	0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70,
+	0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61,
+	0x72, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72,
+	0x65, 0x1a, 0x96, 0x13, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x36, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x70,
+	0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x54, 0x79, 0x70, 0x65, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x65, 0x78, 0x69, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x72, 0x75, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x07, 0x72, 0x75, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f,
+	0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79,
+	0x12, 0x34, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1e, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x3a, 0x0a, 0x07, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x44,
+	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x46, 0x69, 0x6c,
+	0x65, 0x49, 0x44, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x66, 0x69, 0x6c, 0x65, 0x49,
+	0x44, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e,
+	0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x65, 0x6e, 0x76,
+	0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
+	0x63, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0a, 0x63, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x77,
+	0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x63, 0x50, 0x65,
+	0x61, 0x6b, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x63, 0x50, 0x65,
+	0x61, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x07, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d,
+	0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x57, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x0e, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0d, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x57, 0x61, 0x72, 0x6e, 0x69,
+	0x6e, 0x67, 0x12, 0x2c, 0x0a, 0x05, 0x64, 0x65, 0x62, 0x75, 0x67, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e,
+	0x44, 0x65, 0x62, 0x75, 0x67, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x64, 0x65, 0x62, 0x75, 0x67,
+	0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x63, 0x68, 0x65, 0x48, 0x69, 0x74, 0x18, 0x10, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x63, 0x61, 0x63, 0x68, 0x65, 0x48, 0x69, 0x74, 0x12, 0x1c, 0x0a, 0x09,
+	0x70, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x70, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x73, 0x43, 0x6c, 0x61, 0x6d, 0x70, 0x65, 0x64, 0x18, 0x12, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0d, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x43, 0x6c, 0x61, 0x6d, 0x70, 0x65, 0x64,
+	0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x13, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
+	0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x14, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0a, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x37, 0x0a, 0x08,
+	0x70, 0x6f, 0x73, 0x74, 0x45, 0x78, 0x65, 0x63, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x50, 0x6f, 0x73,
+	0x74, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x08, 0x70, 0x6f, 0x73,
+	0x74, 0x45, 0x78, 0x65, 0x63, 0x12, 0x42, 0x0a, 0x0a, 0x65, 0x78, 0x69, 0x74, 0x52, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x70, 0x62, 0x2e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x45,
+	0x78, 0x69, 0x74, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0a, 0x65,
+	0x78, 0x69, 0x74, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x65, 0x6d,
+	0x6f, 0x72, 0x79, 0x50, 0x65, 0x61, 0x6b, 0x18, 0x17, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x6d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x50, 0x65, 0x61, 0x6b, 0x12, 0x20, 0x0a, 0x0b, 0x65, 0x61, 0x72,
+	0x6c, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x18, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b,
+	0x65, 0x61, 0x72, 0x6c, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x72,
+	0x65, 0x61, 0x70, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x19, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0b, 0x72, 0x65, 0x61, 0x70, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x22, 0x0a,
+	0x0c, 0x69, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x1a, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x49, 0x0a, 0x0c, 0x6f, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65,
+	0x73, 0x18, 0x1b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x4f, 0x6d, 0x69,
+	0x74, 0x74, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c,
+	0x6f, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a,
+	0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x50, 0x65, 0x61, 0x6b, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0a, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x50, 0x65, 0x61, 0x6b, 0x12, 0x48, 0x0a, 0x0c,
+	0x70, 0x72, 0x6f, 0x63, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x48, 0x69, 0x74, 0x18, 0x1d, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x24, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x48, 0x69, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x63, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x48, 0x69, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x70, 0x79, 0x4f, 0x75,
+	0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x63, 0x6f,
+	0x70, 0x79, 0x4f, 0x75, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x69,
+	0x70, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x70,
+	0x69, 0x70, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x4f, 0x0a, 0x0e, 0x74, 0x72, 0x75, 0x6e,
+	0x63, 0x61, 0x74, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x20, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x27, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x54, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x46,
+	0x69, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0e, 0x74, 0x72, 0x75, 0x6e, 0x63,
+	0x61, 0x74, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x77, 0x61,
+	0x70, 0x55, 0x73, 0x61, 0x67, 0x65, 0x18, 0x21, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x73, 0x77,
+	0x61, 0x70, 0x55, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x38, 0x0a, 0x0a, 0x46, 0x69, 0x6c, 0x65, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x1a, 0x3a, 0x0a, 0x0c, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3f, 0x0a,
+	0x11, 0x4f, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x41,
+	0x0a, 0x13, 0x54, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0xc3, 0x04, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x0b, 0x0a, 0x07, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x10, 0x00, 0x12, 0x0c, 0x0a,
+	0x08, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x57,
+	0x72, 0x6f, 0x6e, 0x67, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x10, 0x02, 0x12, 0x14, 0x0a, 0x10,
+	0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x6c, 0x79, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74,
+	0x10, 0x03, 0x12, 0x17, 0x0a, 0x13, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x45, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x10, 0x04, 0x12, 0x15, 0x0a, 0x11, 0x54,
+	0x69, 0x6d, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x45, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64,
+	0x10, 0x05, 0x12, 0x17, 0x0a, 0x13, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x45, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x10, 0x06, 0x12, 0x0d, 0x0a, 0x09, 0x46,
+	0x69, 0x6c, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x10, 0x07, 0x12, 0x15, 0x0a, 0x11, 0x4e, 0x6f,
+	0x6e, 0x5a, 0x65, 0x72, 0x6f, 0x45, 0x78, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x10,
+	0x08, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x10, 0x09,
+	0x12, 0x14, 0x0a, 0x10, 0x44, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x6f, 0x75, 0x73, 0x53, 0x79, 0x73,
+	0x63, 0x61, 0x6c, 0x6c, 0x10, 0x0a, 0x12, 0x13, 0x0a, 0x0f, 0x4a, 0x75, 0x64, 0x67, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0x0b, 0x12, 0x16, 0x0a, 0x12, 0x49,
+	0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x10, 0x0c, 0x12, 0x11, 0x0a, 0x0d, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x10, 0x0d, 0x12, 0x17, 0x0a, 0x13, 0x53, 0x79, 0x73, 0x63, 0x61, 0x6c,
+	0x6c, 0x52, 0x61, 0x74, 0x65, 0x45, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x10, 0x0e, 0x12,
+	0x16, 0x0a, 0x12, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x45, 0x78, 0x63,
+	0x65, 0x65, 0x64, 0x65, 0x64, 0x10, 0x0f, 0x12, 0x15, 0x0a, 0x11, 0x46, 0x69, 0x6c, 0x65, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x45, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x10, 0x10, 0x12, 0x19,
+	0x0a, 0x15, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x45,
+	0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x10, 0x11, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x65, 0x67,
+	0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x10, 0x12,
+	0x12, 0x1a, 0x0a, 0x16, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x69, 0x6e,
+	0x74, 0x45, 0x78, 0x63, 0x65, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x10, 0x13, 0x12, 0x0b, 0x0a, 0x07,
+	0x41, 0x62, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x10, 0x14, 0x12, 0x1c, 0x0a, 0x18, 0x49, 0x6e, 0x73,
+	0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x45, 0x78, 0x63,
+	0x65, 0x65, 0x64, 0x65, 0x64, 0x10, 0x15, 0x12, 0x15, 0x0a, 0x11, 0x50, 0x72, 0x65, 0x73, 0x65,
+	0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x10, 0x16, 0x12, 0x17,
+	0x0a, 0x13, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x45, 0x78, 0x63,
+	0x65, 0x65, 0x64, 0x65, 0x64, 0x10, 0x17, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x6b, 0x69, 0x70, 0x70,
+	0x65, 0x64, 0x10, 0x18, 0x12, 0x10, 0x0a, 0x0c, 0x51, 0x75, 0x65, 0x75, 0x65, 0x54, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x10, 0x19, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x65, 0x61, 0x70, 0x54, 0x69,
+	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x10, 0x1a, 0x22, 0xd9, 0x01, 0x0a, 0x0e, 0x45, 0x78, 0x69, 0x74,
+	0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x15, 0x0a, 0x11, 0x45, 0x78,
+	0x69, 0x74, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x10,
+	0x00, 0x12, 0x14, 0x0a, 0x10, 0x45, 0x78, 0x69, 0x74, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x4e,
+	0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x45, 0x78, 0x69, 0x74, 0x52,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x10, 0x02,
+	0x12, 0x19, 0x0a, 0x15, 0x45, 0x78, 0x69, 0x74, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x4d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x10, 0x03, 0x12, 0x18, 0x0a, 0x14, 0x45,
+	0x78, 0x69, 0x74, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x4f, 0x74, 0x68, 0x65, 0x72, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x10, 0x04, 0x12, 0x1a, 0x0a, 0x16, 0x45, 0x78, 0x69, 0x74, 0x52, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x4b, 0x69, 0x6c, 0x6c, 0x65, 0x64, 0x42, 0x79, 0x50, 0x65, 0x65, 0x72, 0x10,
+	0x05, 0x12, 0x17, 0x0a, 0x13, 0x45, 0x78, 0x69, 0x74, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x43,
+	0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x65, 0x64, 0x10, 0x06, 0x12, 0x17, 0x0a, 0x13, 0x45, 0x78,
+	0x69, 0x74, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x6c, 0x65,
+	0x64, 0x10, 0x07, 0x22, 0x55, 0x0a, 0x10, 0x50, 0x72, 0x6f, 0x63, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x48, 0x69, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x50, 0x72, 0x6f, 0x63, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x48, 0x69, 0x74, 0x4e, 0x6f, 0x6e, 0x65, 0x10, 0x00, 0x12, 0x14, 0x0a,
+	0x10, 0x50, 0x72, 0x6f, 0x63, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x48, 0x69, 0x74, 0x50, 0x69, 0x64,
+	0x73, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x50, 0x72, 0x6f, 0x63, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x48, 0x69, 0x74, 0x4e, 0x70, 0x72, 0x6f, 0x63, 0x10, 0x02, 0x1a, 0x92, 0x01, 0x0a, 0x0e, 0x50,
+	0x6f, 0x73, 0x74, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x36, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e,
 	0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75,
-	0x6c, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x66,
-	0x69, 0x6c, 0x65, 0x73, 0x12, 0x3a, 0x0a, 0x07, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x73, 0x18,
-	0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49,
-	0x44, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x73,
-	0x1a, 0x38, 0x0a, 0x0a, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
-	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
-	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3a, 0x0a, 0x0c, 0x46, 0x69,
-	0x6c, 0x65, 0x49, 0x44, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xa2, 0x02, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64,
-	0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x10, 0x01,
-	0x12, 0x0f, 0x0a, 0x0b, 0x57, 0x72, 0x6f, 0x6e, 0x67, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x10,
-	0x02, 0x12, 0x14, 0x0a, 0x10, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x6c, 0x79, 0x43, 0x6f,
-	0x72, 0x72, 0x65, 0x63, 0x74, 0x10, 0x03, 0x12, 0x17, 0x0a, 0x13, 0x4d, 0x65, 0x6d, 0x6f, 0x72,
-	0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x45, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x10, 0x04,
-	0x12, 0x15, 0x0a, 0x11, 0x54, 0x69, 0x6d, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x45, 0x78, 0x63,
-	0x65, 0x65, 0x64, 0x65, 0x64, 0x10, 0x05, 0x12, 0x17, 0x0a, 0x13, 0x4f, 0x75, 0x74, 0x70, 0x75,
-	0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x45, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x10, 0x06,
-	0x12, 0x0d, 0x0a, 0x09, 0x46, 0x69, 0x6c, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x10, 0x07, 0x12,
-	0x15, 0x0a, 0x11, 0x4e, 0x6f, 0x6e, 0x5a, 0x65, 0x72, 0x6f, 0x45, 0x78, 0x69, 0x74, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x10, 0x08, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c,
-	0x6c, 0x65, 0x64, 0x10, 0x09, 0x12, 0x14, 0x0a, 0x10, 0x44, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x6f,
-	0x75, 0x73, 0x53, 0x79, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x10, 0x0a, 0x12, 0x13, 0x0a, 0x0f, 0x4a,
-	0x75, 0x64, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0x0b,
-	0x12, 0x16, 0x0a, 0x12, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x49, 0x6e, 0x74, 0x65, 0x72,
-	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x10, 0x0c, 0x12, 0x11, 0x0a, 0x0d, 0x49, 0x6e, 0x74, 0x65,
-	0x72, 0x6e, 0x61, 0x6c, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x10, 0x0d, 0x22, 0xd9, 0x02, 0x0a, 0x0d,
-	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a,
-	0x0b, 0x65, 0x78, 0x65, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48,
-	0x00, 0x52, 0x0b, 0x65, 0x78, 0x65, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x37,
-	0x0a, 0x09, 0x65, 0x78, 0x65, 0x63, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x48, 0x00, 0x52, 0x09, 0x65, 0x78,
-	0x65, 0x63, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x3a, 0x0a, 0x0a, 0x65, 0x78, 0x65, 0x63, 0x52,
-	0x65, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x62,
-	0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x52,
-	0x65, 0x73, 0x69, 0x7a, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x65, 0x78, 0x65, 0x63, 0x52, 0x65, 0x73,
-	0x69, 0x7a, 0x65, 0x1a, 0x35, 0x0a, 0x05, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x6c, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x54, 0x79, 0x70, 0x65, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x69, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x65, 0x78, 0x69, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x1a,
+	0xf2, 0x05, 0x0a, 0x09, 0x44, 0x65, 0x62, 0x75, 0x67, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x0a,
+	0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x63, 0x63, 0x6f,
+	0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x63, 0x63, 0x6f, 0x6d,
+	0x70, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x03, 0x65, 0x6e, 0x76, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x70, 0x75, 0x54, 0x69,
+	0x6d, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x63,
+	0x70, 0x75, 0x54, 0x69, 0x6d, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x63,
+	0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0e, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x63, 0x6b,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x6f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x63, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x63,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x43, 0x50, 0x55, 0x52, 0x61, 0x74, 0x65,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x43, 0x50, 0x55,
+	0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x2c, 0x0a, 0x11, 0x73, 0x74, 0x72,
+	0x69, 0x63, 0x74, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x4d, 0x65, 0x6d, 0x6f,
+	0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x70, 0x79, 0x4f,
+	0x75, 0x74, 0x4d, 0x61, 0x78, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x63, 0x6f, 0x70,
+	0x79, 0x4f, 0x75, 0x74, 0x4d, 0x61, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12,
+	0x24, 0x0a, 0x0d, 0x6f, 0x70, 0x65, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x0f, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x6f, 0x70, 0x65, 0x6e, 0x46, 0x69, 0x6c, 0x65,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x6e, 0x46, 0x69, 0x6c,
+	0x65, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x6e, 0x46, 0x69,
+	0x6c, 0x65, 0x73, 0x12, 0x40, 0x0a, 0x08, 0x73, 0x79, 0x73, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x18,
+	0x11, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x49, 0x6e, 0x66, 0x6f, 0x2e, 0x53, 0x79,
+	0x73, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x73, 0x79, 0x73,
+	0x63, 0x61, 0x6c, 0x6c, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x12, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x63, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2c, 0x0a, 0x11, 0x63,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x73,
+	0x18, 0x13, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x63, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x43, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x61,
+	0x67, 0x6e, 0x6f, 0x73, 0x69, 0x73, 0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69,
+	0x61, 0x67, 0x6e, 0x6f, 0x73, 0x69, 0x73, 0x1a, 0x3b, 0x0a, 0x0d, 0x53, 0x79, 0x73, 0x63, 0x61,
+	0x6c, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x9b, 0x01, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x12, 0x0a, 0x04,
+	0x6c, 0x69, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x70, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x70, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x12, 0x14, 0x0a, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x22, 0x9b, 0x03, 0x0a, 0x0d, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x0b, 0x65, 0x78, 0x65, 0x63, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x62, 0x2e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x65, 0x78, 0x65, 0x63, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x09, 0x65, 0x78, 0x65, 0x63, 0x49, 0x6e, 0x70,
+	0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x49, 0x6e, 0x70, 0x75,
+	0x74, 0x48, 0x00, 0x52, 0x09, 0x65, 0x78, 0x65, 0x63, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x3a,
+	0x0a, 0x0a, 0x65, 0x78, 0x65, 0x63, 0x52, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x48, 0x00, 0x52, 0x0a,
+	0x65, 0x78, 0x65, 0x63, 0x52, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x37, 0x0a, 0x09, 0x65, 0x78,
+	0x65, 0x63, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x70, 0x62, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x48, 0x00, 0x52, 0x09, 0x65, 0x78, 0x65, 0x63, 0x41, 0x62,
+	0x6f, 0x72, 0x74, 0x1a, 0x35, 0x0a, 0x05, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04,
 	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
 	0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
 	0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x1a, 0x60, 0x0a, 0x06, 0x52, 0x65,
@@ -1733,41 +4421,54 @@ var file_judge_proto_rawDesc = []byte{
 	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x12, 0x12, 0x0a, 0x04,
 	0x63, 0x6f, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x63, 0x6f, 0x6c, 0x73,
 	0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x01, 0x78, 0x12, 0x0c,
-	0x0a, 0x01, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x01, 0x79, 0x42, 0x09, 0x0a, 0x07,
-	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xc5, 0x01, 0x0a, 0x0e, 0x53, 0x74, 0x72, 0x65,
-	0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x0c, 0x65, 0x78,
-	0x65, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x0c, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00,
-	0x52, 0x0c, 0x65, 0x78, 0x65, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b,
-	0x0a, 0x0a, 0x65, 0x78, 0x65, 0x63, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x48, 0x00, 0x52,
-	0x0a, 0x65, 0x78, 0x65, 0x63, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x1a, 0x36, 0x0a, 0x06, 0x4f,
-	0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e,
-	0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74,
-	0x65, 0x6e, 0x74, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32,
-	0x9e, 0x02, 0x0a, 0x08, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x6f, 0x72, 0x12, 0x21, 0x0a, 0x04,
-	0x45, 0x78, 0x65, 0x63, 0x12, 0x0b, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x0c, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x37, 0x0a, 0x0a, 0x45, 0x78, 0x65, 0x63, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x11, 0x2e,
-	0x70, 0x62, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x12, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x30, 0x01, 0x12, 0x34, 0x0a, 0x08, 0x46, 0x69, 0x6c, 0x65,
-	0x4c, 0x69, 0x73, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x70,
-	0x62, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x26,
-	0x0a, 0x07, 0x46, 0x69, 0x6c, 0x65, 0x47, 0x65, 0x74, 0x12, 0x0a, 0x2e, 0x70, 0x62, 0x2e, 0x46,
-	0x69, 0x6c, 0x65, 0x49, 0x44, 0x1a, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x43,
-	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x07, 0x46, 0x69, 0x6c, 0x65, 0x41, 0x64,
-	0x64, 0x12, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65,
-	0x6e, 0x74, 0x1a, 0x0a, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x12, 0x30,
-	0x0a, 0x0a, 0x46, 0x69, 0x6c, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x0a, 0x2e, 0x70,
-	0x62, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x42, 0x1f, 0x5a, 0x1d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63,
-	0x72, 0x69, 0x79, 0x6c, 0x65, 0x2f, 0x67, 0x6f, 0x2d, 0x6a, 0x75, 0x64, 0x67, 0x65, 0x2f, 0x70,
-	0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x0a, 0x01, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x01, 0x79, 0x1a, 0x07, 0x0a, 0x05,
+	0x41, 0x62, 0x6f, 0x72, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0xdd, 0x02, 0x0a, 0x0e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x0c, 0x65, 0x78, 0x65, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x62, 0x2e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x65, 0x78, 0x65, 0x63, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x0a, 0x65, 0x78, 0x65, 0x63, 0x4f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x62,
+	0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e,
+	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x65, 0x78, 0x65, 0x63, 0x4f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x12, 0x42, 0x0a, 0x0a, 0x65, 0x78, 0x65, 0x63, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x50, 0x61, 0x72,
+	0x74, 0x69, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x65, 0x78,
+	0x65, 0x63, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x1a, 0x36, 0x0a, 0x06, 0x4f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x1a, 0x52, 0x0a, 0x0d, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x2b, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x32, 0xd2, 0x02, 0x0a, 0x08, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x6f, 0x72, 0x12, 0x21, 0x0a,
+	0x04, 0x45, 0x78, 0x65, 0x63, 0x12, 0x0b, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x37, 0x0a, 0x0a, 0x45, 0x78, 0x65, 0x63, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x11,
+	0x2e, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x12, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x30, 0x01, 0x12, 0x34, 0x0a, 0x08, 0x46, 0x69, 0x6c,
+	0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e,
+	0x70, 0x62, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x26, 0x0a, 0x07, 0x46, 0x69, 0x6c, 0x65, 0x47, 0x65, 0x74, 0x12, 0x0a, 0x2e, 0x70, 0x62, 0x2e,
+	0x46, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x1a, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6c, 0x65,
+	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x07, 0x46, 0x69, 0x6c, 0x65, 0x41,
+	0x64, 0x64, 0x12, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x1a, 0x0a, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x12,
+	0x30, 0x0a, 0x0a, 0x46, 0x69, 0x6c, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x0a, 0x2e,
+	0x70, 0x62, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x12, 0x32, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x42, 0x1f, 0x5a, 0x1d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x72, 0x69, 0x79, 0x6c, 0x65, 0x2f, 0x67, 0x6f, 0x2d, 0x6a, 0x75,
+	0x64, 0x67, 0x65, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1782,76 +4483,143 @@ func file_judge_proto_rawDescGZIP() []byte {
 	return file_judge_proto_rawDescData
 }
 
-var file_judge_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_judge_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_judge_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_judge_proto_msgTypes = make([]protoimpl.MessageInfo, 45)
 var file_judge_proto_goTypes = []interface{}{
-	(Response_Result_StatusType)(0),   // 0: pb.Response.Result.StatusType
-	(*FileID)(nil),                    // 1: pb.FileID
-	(*FileContent)(nil),               // 2: pb.FileContent
-	(*FileListType)(nil),              // 3: pb.FileListType
-	(*Request)(nil),                   // 4: pb.Request
-	(*Response)(nil),                  // 5: pb.Response
-	(*StreamRequest)(nil),             // 6: pb.StreamRequest
-	(*StreamResponse)(nil),            // 7: pb.StreamResponse
-	(*Request_LocalFile)(nil),         // 8: pb.Request.LocalFile
-	(*Request_MemoryFile)(nil),        // 9: pb.Request.MemoryFile
-	(*Request_CachedFile)(nil),        // 10: pb.Request.CachedFile
-	(*Request_PipeCollector)(nil),     // 11: pb.Request.PipeCollector
-	(*Request_StreamInput)(nil),       // 12: pb.Request.StreamInput
-	(*Request_StreamOutput)(nil),      // 13: pb.Request.StreamOutput
-	(*Request_File)(nil),              // 14: pb.Request.File
-	(*Request_CmdType)(nil),           // 15: pb.Request.CmdType
-	(*Request_PipeMap)(nil),           // 16: pb.Request.PipeMap
-	nil,                               // 17: pb.Request.CmdType.CopyInEntry
-	(*Request_PipeMap_PipeIndex)(nil), // 18: pb.Request.PipeMap.PipeIndex
-	(*Response_Result)(nil),           // 19: pb.Response.Result
-	nil,                               // 20: pb.Response.Result.FilesEntry
-	nil,                               // 21: pb.Response.Result.FileIDsEntry
-	(*StreamRequest_Input)(nil),       // 22: pb.StreamRequest.Input
-	(*StreamRequest_Resize)(nil),      // 23: pb.StreamRequest.Resize
-	(*StreamResponse_Output)(nil),     // 24: pb.StreamResponse.Output
-	(*emptypb.Empty)(nil),             // 25: google.protobuf.Empty
+	(Request_PipeCollector_PolicyType)(0),     // 0: pb.Request.PipeCollector.PolicyType
+	(Request_CmdType_MemoryAccountingType)(0), // 1: pb.Request.CmdType.MemoryAccountingType
+	(Request_CmdType_StdoutBufferingType)(0),  // 2: pb.Request.CmdType.StdoutBufferingType
+	(Request_CmdType_TimeAccountingType)(0),   // 3: pb.Request.CmdType.TimeAccountingType
+	(Request_Compare_PolicyType)(0),           // 4: pb.Request.Compare.PolicyType
+	(Response_Result_StatusType)(0),           // 5: pb.Response.Result.StatusType
+	(Response_Result_ExitReasonType)(0),       // 6: pb.Response.Result.ExitReasonType
+	(Response_Result_ProcLimitHitType)(0),     // 7: pb.Response.Result.ProcLimitHitType
+	(*VersionInfo)(nil),                       // 8: pb.VersionInfo
+	(*FileID)(nil),                            // 9: pb.FileID
+	(*FileContent)(nil),                       // 10: pb.FileContent
+	(*FileListType)(nil),                      // 11: pb.FileListType
+	(*Request)(nil),                           // 12: pb.Request
+	(*Response)(nil),                          // 13: pb.Response
+	(*StreamRequest)(nil),                     // 14: pb.StreamRequest
+	(*StreamResponse)(nil),                    // 15: pb.StreamResponse
+	(*Request_LocalFile)(nil),                 // 16: pb.Request.LocalFile
+	(*Request_MemoryFile)(nil),                // 17: pb.Request.MemoryFile
+	(*Request_CachedFile)(nil),                // 18: pb.Request.CachedFile
+	(*Request_URLFile)(nil),                   // 19: pb.Request.URLFile
+	(*Request_EmptyFile)(nil),                 // 20: pb.Request.EmptyFile
+	(*Request_PipeCollector)(nil),             // 21: pb.Request.PipeCollector
+	(*Request_StreamInput)(nil),               // 22: pb.Request.StreamInput
+	(*Request_StreamOutput)(nil),              // 23: pb.Request.StreamOutput
+	(*Request_File)(nil),                      // 24: pb.Request.File
+	(*Request_CopyOutDir)(nil),                // 25: pb.Request.CopyOutDir
+	(*Request_CmdType)(nil),                   // 26: pb.Request.CmdType
+	(*Request_Checker)(nil),                   // 27: pb.Request.Checker
+	(*Request_PipeMap)(nil),                   // 28: pb.Request.PipeMap
+	nil,                                       // 29: pb.Request.TagsEntry
+	(*Request_Input)(nil),                     // 30: pb.Request.Input
+	(*Request_Compare)(nil),                   // 31: pb.Request.Compare
+	nil,                                       // 32: pb.Request.CmdType.CopyInEntry
+	nil,                                       // 33: pb.Request.CmdType.SecretsEntry
+	(*Request_Checker_Input)(nil),             // 34: pb.Request.Checker.Input
+	nil,                                       // 35: pb.Request.Checker.ExitStatusEntry
+	(*Request_Checker_Testlib)(nil),           // 36: pb.Request.Checker.Testlib
+	(*Request_PipeMap_PipeIndex)(nil),         // 37: pb.Request.PipeMap.PipeIndex
+	nil,                                       // 38: pb.Request.Input.CopyInEntry
+	(*Response_Result)(nil),                   // 39: pb.Response.Result
+	(*Response_PostExecResult)(nil),           // 40: pb.Response.PostExecResult
+	(*Response_DebugInfo)(nil),                // 41: pb.Response.DebugInfo
+	(*Response_CompareResult)(nil),            // 42: pb.Response.CompareResult
+	nil,                                       // 43: pb.Response.Result.FilesEntry
+	nil,                                       // 44: pb.Response.Result.FileIDsEntry
+	nil,                                       // 45: pb.Response.Result.OmittedFilesEntry
+	nil,                                       // 46: pb.Response.Result.TruncatedFilesEntry
+	nil,                                       // 47: pb.Response.DebugInfo.SyscallsEntry
+	(*StreamRequest_Input)(nil),               // 48: pb.StreamRequest.Input
+	(*StreamRequest_Resize)(nil),              // 49: pb.StreamRequest.Resize
+	(*StreamRequest_Abort)(nil),               // 50: pb.StreamRequest.Abort
+	(*StreamResponse_Output)(nil),             // 51: pb.StreamResponse.Output
+	(*StreamResponse_PartialResult)(nil),      // 52: pb.StreamResponse.PartialResult
+	(*emptypb.Empty)(nil),                     // 53: google.protobuf.Empty
 }
 var file_judge_proto_depIdxs = []int32{
-	15, // 0: pb.Request.cmd:type_name -> pb.Request.CmdType
-	16, // 1: pb.Request.pipeMapping:type_name -> pb.Request.PipeMap
-	19, // 2: pb.Response.results:type_name -> pb.Response.Result
-	4,  // 3: pb.StreamRequest.execRequest:type_name -> pb.Request
-	22, // 4: pb.StreamRequest.execInput:type_name -> pb.StreamRequest.Input
-	23, // 5: pb.StreamRequest.execResize:type_name -> pb.StreamRequest.Resize
-	5,  // 6: pb.StreamResponse.execResponse:type_name -> pb.Response
-	24, // 7: pb.StreamResponse.execOutput:type_name -> pb.StreamResponse.Output
-	8,  // 8: pb.Request.File.local:type_name -> pb.Request.LocalFile
-	9,  // 9: pb.Request.File.memory:type_name -> pb.Request.MemoryFile
-	10, // 10: pb.Request.File.cached:type_name -> pb.Request.CachedFile
-	11, // 11: pb.Request.File.pipe:type_name -> pb.Request.PipeCollector
-	12, // 12: pb.Request.File.streamIn:type_name -> pb.Request.StreamInput
-	13, // 13: pb.Request.File.streamOut:type_name -> pb.Request.StreamOutput
-	14, // 14: pb.Request.CmdType.files:type_name -> pb.Request.File
-	17, // 15: pb.Request.CmdType.copyIn:type_name -> pb.Request.CmdType.CopyInEntry
-	18, // 16: pb.Request.PipeMap.in:type_name -> pb.Request.PipeMap.PipeIndex
-	18, // 17: pb.Request.PipeMap.out:type_name -> pb.Request.PipeMap.PipeIndex
-	14, // 18: pb.Request.CmdType.CopyInEntry.value:type_name -> pb.Request.File
-	0,  // 19: pb.Response.Result.status:type_name -> pb.Response.Result.StatusType
-	20, // 20: pb.Response.Result.files:type_name -> pb.Response.Result.FilesEntry
-	21, // 21: pb.Response.Result.fileIDs:type_name -> pb.Response.Result.FileIDsEntry
-	4,  // 22: pb.Executor.Exec:input_type -> pb.Request
-	6,  // 23: pb.Executor.ExecStream:input_type -> pb.StreamRequest
-	25, // 24: pb.Executor.FileList:input_type -> google.protobuf.Empty
-	1,  // 25: pb.Executor.FileGet:input_type -> pb.FileID
-	2,  // 26: pb.Executor.FileAdd:input_type -> pb.FileContent
-	1,  // 27: pb.Executor.FileDelete:input_type -> pb.FileID
-	5,  // 28: pb.Executor.Exec:output_type -> pb.Response
-	7,  // 29: pb.Executor.ExecStream:output_type -> pb.StreamResponse
-	3,  // 30: pb.Executor.FileList:output_type -> pb.FileListType
-	2,  // 31: pb.Executor.FileGet:output_type -> pb.FileContent
-	1,  // 32: pb.Executor.FileAdd:output_type -> pb.FileID
-	25, // 33: pb.Executor.FileDelete:output_type -> google.protobuf.Empty
-	28, // [28:34] is the sub-list for method output_type
-	22, // [22:28] is the sub-list for method input_type
-	22, // [22:22] is the sub-list for extension type_name
-	22, // [22:22] is the sub-list for extension extendee
-	0,  // [0:22] is the sub-list for field type_name
+	26, // 0: pb.Request.cmd:type_name -> pb.Request.CmdType
+	28, // 1: pb.Request.pipeMapping:type_name -> pb.Request.PipeMap
+	29, // 2: pb.Request.tags:type_name -> pb.Request.TagsEntry
+	27, // 3: pb.Request.checker:type_name -> pb.Request.Checker
+	30, // 4: pb.Request.inputs:type_name -> pb.Request.Input
+	31, // 5: pb.Request.compare:type_name -> pb.Request.Compare
+	39, // 6: pb.Response.results:type_name -> pb.Response.Result
+	39, // 7: pb.Response.checker:type_name -> pb.Response.Result
+	5,  // 8: pb.Response.verdict:type_name -> pb.Response.Result.StatusType
+	42, // 9: pb.Response.compare:type_name -> pb.Response.CompareResult
+	12, // 10: pb.StreamRequest.execRequest:type_name -> pb.Request
+	48, // 11: pb.StreamRequest.execInput:type_name -> pb.StreamRequest.Input
+	49, // 12: pb.StreamRequest.execResize:type_name -> pb.StreamRequest.Resize
+	50, // 13: pb.StreamRequest.execAbort:type_name -> pb.StreamRequest.Abort
+	13, // 14: pb.StreamResponse.execResponse:type_name -> pb.Response
+	51, // 15: pb.StreamResponse.execOutput:type_name -> pb.StreamResponse.Output
+	52, // 16: pb.StreamResponse.execResult:type_name -> pb.StreamResponse.PartialResult
+	0,  // 17: pb.Request.PipeCollector.onExceed:type_name -> pb.Request.PipeCollector.PolicyType
+	16, // 18: pb.Request.File.local:type_name -> pb.Request.LocalFile
+	17, // 19: pb.Request.File.memory:type_name -> pb.Request.MemoryFile
+	18, // 20: pb.Request.File.cached:type_name -> pb.Request.CachedFile
+	21, // 21: pb.Request.File.pipe:type_name -> pb.Request.PipeCollector
+	22, // 22: pb.Request.File.streamIn:type_name -> pb.Request.StreamInput
+	23, // 23: pb.Request.File.streamOut:type_name -> pb.Request.StreamOutput
+	19, // 24: pb.Request.File.url:type_name -> pb.Request.URLFile
+	20, // 25: pb.Request.File.empty:type_name -> pb.Request.EmptyFile
+	24, // 26: pb.Request.CmdType.files:type_name -> pb.Request.File
+	32, // 27: pb.Request.CmdType.copyIn:type_name -> pb.Request.CmdType.CopyInEntry
+	21, // 28: pb.Request.CmdType.stdinTee:type_name -> pb.Request.PipeCollector
+	1,  // 29: pb.Request.CmdType.memoryAccounting:type_name -> pb.Request.CmdType.MemoryAccountingType
+	2,  // 30: pb.Request.CmdType.stdoutBuffering:type_name -> pb.Request.CmdType.StdoutBufferingType
+	3,  // 31: pb.Request.CmdType.timeAccounting:type_name -> pb.Request.CmdType.TimeAccountingType
+	25, // 32: pb.Request.CmdType.copyOutDirs:type_name -> pb.Request.CopyOutDir
+	33, // 33: pb.Request.CmdType.secrets:type_name -> pb.Request.CmdType.SecretsEntry
+	26, // 34: pb.Request.Checker.cmd:type_name -> pb.Request.CmdType
+	34, // 35: pb.Request.Checker.inputs:type_name -> pb.Request.Checker.Input
+	35, // 36: pb.Request.Checker.exitStatus:type_name -> pb.Request.Checker.ExitStatusEntry
+	36, // 37: pb.Request.Checker.testlib:type_name -> pb.Request.Checker.Testlib
+	37, // 38: pb.Request.PipeMap.in:type_name -> pb.Request.PipeMap.PipeIndex
+	37, // 39: pb.Request.PipeMap.out:type_name -> pb.Request.PipeMap.PipeIndex
+	24, // 40: pb.Request.Input.stdin:type_name -> pb.Request.File
+	38, // 41: pb.Request.Input.copyIn:type_name -> pb.Request.Input.CopyInEntry
+	24, // 42: pb.Request.Compare.answer:type_name -> pb.Request.File
+	4,  // 43: pb.Request.Compare.policy:type_name -> pb.Request.Compare.PolicyType
+	24, // 44: pb.Request.CmdType.CopyInEntry.value:type_name -> pb.Request.File
+	5,  // 45: pb.Request.Checker.ExitStatusEntry.value:type_name -> pb.Response.Result.StatusType
+	24, // 46: pb.Request.Input.CopyInEntry.value:type_name -> pb.Request.File
+	5,  // 47: pb.Response.Result.status:type_name -> pb.Response.Result.StatusType
+	43, // 48: pb.Response.Result.files:type_name -> pb.Response.Result.FilesEntry
+	44, // 49: pb.Response.Result.fileIDs:type_name -> pb.Response.Result.FileIDsEntry
+	41, // 50: pb.Response.Result.debug:type_name -> pb.Response.DebugInfo
+	40, // 51: pb.Response.Result.postExec:type_name -> pb.Response.PostExecResult
+	6,  // 52: pb.Response.Result.exitReason:type_name -> pb.Response.Result.ExitReasonType
+	45, // 53: pb.Response.Result.omittedFiles:type_name -> pb.Response.Result.OmittedFilesEntry
+	7,  // 54: pb.Response.Result.procLimitHit:type_name -> pb.Response.Result.ProcLimitHitType
+	46, // 55: pb.Response.Result.truncatedFiles:type_name -> pb.Response.Result.TruncatedFilesEntry
+	5,  // 56: pb.Response.PostExecResult.status:type_name -> pb.Response.Result.StatusType
+	47, // 57: pb.Response.DebugInfo.syscalls:type_name -> pb.Response.DebugInfo.SyscallsEntry
+	39, // 58: pb.StreamResponse.PartialResult.result:type_name -> pb.Response.Result
+	12, // 59: pb.Executor.Exec:input_type -> pb.Request
+	14, // 60: pb.Executor.ExecStream:input_type -> pb.StreamRequest
+	53, // 61: pb.Executor.FileList:input_type -> google.protobuf.Empty
+	9,  // 62: pb.Executor.FileGet:input_type -> pb.FileID
+	10, // 63: pb.Executor.FileAdd:input_type -> pb.FileContent
+	9,  // 64: pb.Executor.FileDelete:input_type -> pb.FileID
+	53, // 65: pb.Executor.Version:input_type -> google.protobuf.Empty
+	13, // 66: pb.Executor.Exec:output_type -> pb.Response
+	15, // 67: pb.Executor.ExecStream:output_type -> pb.StreamResponse
+	11, // 68: pb.Executor.FileList:output_type -> pb.FileListType
+	10, // 69: pb.Executor.FileGet:output_type -> pb.FileContent
+	9,  // 70: pb.Executor.FileAdd:output_type -> pb.FileID
+	53, // 71: pb.Executor.FileDelete:output_type -> google.protobuf.Empty
+	8,  // 72: pb.Executor.Version:output_type -> pb.VersionInfo
+	66, // [66:73] is the sub-list for method output_type
+	59, // [59:66] is the sub-list for method input_type
+	59, // [59:59] is the sub-list for extension type_name
+	59, // [59:59] is the sub-list for extension extendee
+	0,  // [0:59] is the sub-list for field type_name
 }
 
 func init() { file_judge_proto_init() }
@@ -1861,7 +4629,7 @@ func file_judge_proto_init() {
 	}
 	if !protoimpl.UnsafeEnabled {
 		file_judge_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FileID); i {
+			switch v := v.(*VersionInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1873,7 +4641,7 @@ func file_judge_proto_init() {
 			}
 		}
 		file_judge_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FileContent); i {
+			switch v := v.(*FileID); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1885,7 +4653,7 @@ func file_judge_proto_init() {
 			}
 		}
 		file_judge_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FileListType); i {
+			switch v := v.(*FileContent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1897,7 +4665,7 @@ func file_judge_proto_init() {
 			}
 		}
 		file_judge_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Request); i {
+			switch v := v.(*FileListType); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1909,7 +4677,7 @@ func file_judge_proto_init() {
 			}
 		}
 		file_judge_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Response); i {
+			switch v := v.(*Request); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1921,7 +4689,7 @@ func file_judge_proto_init() {
 			}
 		}
 		file_judge_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StreamRequest); i {
+			switch v := v.(*Response); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1933,7 +4701,7 @@ func file_judge_proto_init() {
 			}
 		}
 		file_judge_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StreamResponse); i {
+			switch v := v.(*StreamRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1945,7 +4713,7 @@ func file_judge_proto_init() {
 			}
 		}
 		file_judge_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Request_LocalFile); i {
+			switch v := v.(*StreamResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1957,7 +4725,7 @@ func file_judge_proto_init() {
 			}
 		}
 		file_judge_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Request_MemoryFile); i {
+			switch v := v.(*Request_LocalFile); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1969,7 +4737,7 @@ func file_judge_proto_init() {
 			}
 		}
 		file_judge_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Request_CachedFile); i {
+			switch v := v.(*Request_MemoryFile); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1981,7 +4749,7 @@ func file_judge_proto_init() {
 			}
 		}
 		file_judge_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Request_PipeCollector); i {
+			switch v := v.(*Request_CachedFile); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1993,7 +4761,7 @@ func file_judge_proto_init() {
 			}
 		}
 		file_judge_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Request_StreamInput); i {
+			switch v := v.(*Request_URLFile); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2005,7 +4773,7 @@ func file_judge_proto_init() {
 			}
 		}
 		file_judge_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Request_StreamOutput); i {
+			switch v := v.(*Request_EmptyFile); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2017,7 +4785,7 @@ func file_judge_proto_init() {
 			}
 		}
 		file_judge_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Request_File); i {
+			switch v := v.(*Request_PipeCollector); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2029,7 +4797,7 @@ func file_judge_proto_init() {
 			}
 		}
 		file_judge_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Request_CmdType); i {
+			switch v := v.(*Request_StreamInput); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2041,7 +4809,19 @@ func file_judge_proto_init() {
 			}
 		}
 		file_judge_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Request_PipeMap); i {
+			switch v := v.(*Request_StreamOutput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_judge_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Request_File); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2053,7 +4833,7 @@ func file_judge_proto_init() {
 			}
 		}
 		file_judge_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Request_PipeMap_PipeIndex); i {
+			switch v := v.(*Request_CopyOutDir); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2065,6 +4845,102 @@ func file_judge_proto_init() {
 			}
 		}
 		file_judge_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Request_CmdType); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_judge_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Request_Checker); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_judge_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Request_PipeMap); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_judge_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Request_Input); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_judge_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Request_Compare); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_judge_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Request_Checker_Input); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_judge_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Request_Checker_Testlib); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_judge_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Request_PipeMap_PipeIndex); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_judge_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Response_Result); i {
 			case 0:
 				return &v.state
@@ -2076,7 +4952,43 @@ func file_judge_proto_init() {
 				return nil
 			}
 		}
-		file_judge_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+		file_judge_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Response_PostExecResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_judge_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Response_DebugInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_judge_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Response_CompareResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_judge_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*StreamRequest_Input); i {
 			case 0:
 				return &v.state
@@ -2088,7 +5000,7 @@ func file_judge_proto_init() {
 				return nil
 			}
 		}
-		file_judge_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+		file_judge_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*StreamRequest_Resize); i {
 			case 0:
 				return &v.state
@@ -2100,7 +5012,19 @@ func file_judge_proto_init() {
 				return nil
 			}
 		}
-		file_judge_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+		file_judge_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamRequest_Abort); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_judge_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*StreamResponse_Output); i {
 			case 0:
 				return &v.state
@@ -2112,31 +5036,47 @@ func file_judge_proto_init() {
 				return nil
 			}
 		}
+		file_judge_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamResponse_PartialResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
-	file_judge_proto_msgTypes[5].OneofWrappers = []interface{}{
+	file_judge_proto_msgTypes[6].OneofWrappers = []interface{}{
 		(*StreamRequest_ExecRequest)(nil),
 		(*StreamRequest_ExecInput)(nil),
 		(*StreamRequest_ExecResize)(nil),
+		(*StreamRequest_ExecAbort)(nil),
 	}
-	file_judge_proto_msgTypes[6].OneofWrappers = []interface{}{
+	file_judge_proto_msgTypes[7].OneofWrappers = []interface{}{
 		(*StreamResponse_ExecResponse)(nil),
 		(*StreamResponse_ExecOutput)(nil),
+		(*StreamResponse_ExecResult)(nil),
 	}
-	file_judge_proto_msgTypes[13].OneofWrappers = []interface{}{
+	file_judge_proto_msgTypes[16].OneofWrappers = []interface{}{
 		(*Request_File_Local)(nil),
 		(*Request_File_Memory)(nil),
 		(*Request_File_Cached)(nil),
 		(*Request_File_Pipe)(nil),
 		(*Request_File_StreamIn)(nil),
 		(*Request_File_StreamOut)(nil),
+		(*Request_File_Url)(nil),
+		(*Request_File_Empty)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_judge_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   24,
+			NumEnums:      8,
+			NumMessages:   45,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -24,7 +24,9 @@ type ExecutorClient interface {
 	// output. The first request must be execRequest and the following request
 	// must be execInput. The last response must be execResponse and the others
 	// are execOutput. TTY attribute will create single pty for the program thus
-	// stdout & stderr should have same name
+	// stdout & stderr should have same name. If partialResult is set in the
+	// execRequest, execResult will be sent for each cmd (or input) as soon as
+	// it finishes. execAbort cancels the request and the response is still sent
 	ExecStream(ctx context.Context, opts ...grpc.CallOption) (Executor_ExecStreamClient, error)
 	// FileList lists all files available in the file store
 	FileList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FileListType, error)
@@ -34,6 +36,8 @@ type ExecutorClient interface {
 	FileAdd(ctx context.Context, in *FileContent, opts ...grpc.CallOption) (*FileID, error)
 	// FileDelete deletes a file from the file store
 	FileDelete(ctx context.Context, in *FileID, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Version returns the build info and the capabilities of the server
+	Version(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*VersionInfo, error)
 }
 
 type executorClient struct {
@@ -120,6 +124,15 @@ func (c *executorClient) FileDelete(ctx context.Context, in *FileID, opts ...grp
 	return out, nil
 }
 
+func (c *executorClient) Version(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*VersionInfo, error) {
+	out := new(VersionInfo)
+	err := c.cc.Invoke(ctx, "/pb.Executor/Version", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ExecutorServer is the server API for Executor service.
 // All implementations must embed UnimplementedExecutorServer
 // for forward compatibility
@@ -130,7 +143,9 @@ type ExecutorServer interface {
 	// output. The first request must be execRequest and the following request
 	// must be execInput. The last response must be execResponse and the others
 	// are execOutput. TTY attribute will create single pty for the program thus
-	// stdout & stderr should have same name
+	// stdout & stderr should have same name. If partialResult is set in the
+	// execRequest, execResult will be sent for each cmd (or input) as soon as
+	// it finishes. execAbort cancels the request and the response is still sent
 	ExecStream(Executor_ExecStreamServer) error
 	// FileList lists all files available in the file store
 	FileList(context.Context, *emptypb.Empty) (*FileListType, error)
@@ -140,6 +155,8 @@ type ExecutorServer interface {
 	FileAdd(context.Context, *FileContent) (*FileID, error)
 	// FileDelete deletes a file from the file store
 	FileDelete(context.Context, *FileID) (*emptypb.Empty, error)
+	// Version returns the build info and the capabilities of the server
+	Version(context.Context, *emptypb.Empty) (*VersionInfo, error)
 	mustEmbedUnimplementedExecutorServer()
 }
 
@@ -165,6 +182,9 @@ func (UnimplementedExecutorServer) FileAdd(context.Context, *FileContent) (*File
 func (UnimplementedExecutorServer) FileDelete(context.Context, *FileID) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FileDelete not implemented")
 }
+func (UnimplementedExecutorServer) Version(context.Context, *emptypb.Empty) (*VersionInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Version not implemented")
+}
 func (UnimplementedExecutorServer) mustEmbedUnimplementedExecutorServer() {}
 
 // UnsafeExecutorServer may be embedded to opt out of forward compatibility for this service.
@@ -294,6 +314,24 @@ func _Executor_FileDelete_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Executor_Version_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorServer).Version(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Executor/Version",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorServer).Version(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Executor_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pb.Executor",
 	HandlerType: (*ExecutorServer)(nil),
@@ -318,6 +356,10 @@ var _Executor_serviceDesc = grpc.ServiceDesc{
 			MethodName: "FileDelete",
 			Handler:    _Executor_FileDelete_Handler,
 		},
+		{
+			MethodName: "Version",
+			Handler:    _Executor_Version_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -0,0 +1,80 @@
+package worker
+
+import (
+	"errors"
+	"time"
+
+	"github.com/criyle/go-judge/envexec"
+)
+
+// errAcquireTimeout fails the request not acquired its environments within
+// AcquireTimeout
+var errAcquireTimeout = errors.New("environment is not acquired within acquire timeout")
+
+// acquirePool bounds the time to get the environments of the request by the
+// deadline, the environment got after the deadline is put back to the pool
+type acquirePool struct {
+	envexec.EnvironmentPool
+	deadline time.Time
+}
+
+// acquirePool returns the pool of the request run bounded by AcquireTimeout
+// since the request accepted (queue included)
+func (w *worker) acquirePool(req workRequest, p envexec.EnvironmentPool) envexec.EnvironmentPool {
+	if req.AcquireTimeout <= 0 {
+		return p
+	}
+	return acquirePool{EnvironmentPool: p, deadline: req.accepted.Add(req.AcquireTimeout)}
+}
+
+func (p acquirePool) Get() (envexec.Environment, error) {
+	d := time.Until(p.deadline)
+	if d <= 0 {
+		return nil, errAcquireTimeout
+	}
+	type result struct {
+		env envexec.Environment
+		err error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		e, err := p.EnvironmentPool.Get()
+		ch <- result{env: e, err: err}
+	}()
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case r := <-ch:
+		return r.env, r.err
+	case <-timer.C:
+		go func() {
+			if r := <-ch; r.err == nil {
+				p.EnvironmentPool.Put(r.env)
+			}
+		}()
+		return nil, errAcquireTimeout
+	}
+}
+
+// Discard keeps the environment discarded by the pool underneath
+func (p acquirePool) Discard(e envexec.Environment) {
+	discardEnvironment(p.EnvironmentPool, e)
+}
+
+// acquireTimeout returns the results of the request as QueueTimeout if its
+// run failed to acquire the environments in time
+func (req *workRequest) acquireTimeout(rt Response) Response {
+	if !errors.Is(rt.Error, errAcquireTimeout) {
+		return rt
+	}
+	n := len(req.cmd)
+	if len(req.Inputs) > 0 {
+		n = len(req.Inputs)
+	}
+	rt = Response{Results: make([]Result, n)}
+	for i := range rt.Results {
+		rt.Results[i] = Result{Status: envexec.StatusQueueTimeout, Error: errAcquireTimeout.Error()}
+	}
+	return rt
+}
@@ -0,0 +1,30 @@
+package worker
+
+import "fmt"
+
+// checkArgsSize rejects cmds with argv / env byte length (including the
+// terminating null bytes as execve counts) exceeded the limits, which would
+// otherwise fail execve with E2BIG as internal error
+func (w *worker) checkArgsSize(cmds []Cmd) error {
+	if w.argsSizeLimit == 0 && w.envSizeLimit == 0 {
+		return nil
+	}
+	for i, c := range cmds {
+		if s := stringsSize(c.Args); w.argsSizeLimit > 0 && s > uint64(w.argsSizeLimit) {
+			return reject(RejectLimitExceeded, fmt.Sprintf("cmd[%d].args", i), "cmd %d: args have size (%d) exceeded the limit (%d)", i, s, w.argsSizeLimit)
+		}
+		s := stringsSize(c.Env) + stringsSize(c.Secrets.env())
+		if w.envSizeLimit > 0 && s > uint64(w.envSizeLimit) {
+			return reject(RejectLimitExceeded, fmt.Sprintf("cmd[%d].env", i), "cmd %d: env have size (%d) exceeded the limit (%d)", i, s, w.envSizeLimit)
+		}
+	}
+	return nil
+}
+
+func stringsSize(s []string) uint64 {
+	var rt uint64
+	for _, e := range s {
+		rt += uint64(len(e)) + 1
+	}
+	return rt
+}
@@ -0,0 +1,39 @@
+package worker
+
+import "time"
+
+// defaultBusyRetryAfter defines the retry hint if not configured
+const defaultBusyRetryAfter = time.Second
+
+// BusyError is the response error if the queue is full (with MaxQueue), the
+// request is rejected immediately instead of waiting for the queue
+type BusyError struct {
+	RetryAfter time.Duration // hint to retry the request after
+	Reason     string        // why the request is rejected (default queue is full)
+}
+
+func (e *BusyError) Error() string {
+	reason := e.Reason
+	if reason == "" {
+		reason = "queue is full"
+	}
+	return "worker: " + reason + ", retry after " + e.RetryAfter.String()
+}
+
+// retryAfter returns the configured retry hint of the busy rejection
+func (w *worker) retryAfter() time.Duration {
+	if w.busyRetryAfter <= 0 {
+		return defaultBusyRetryAfter
+	}
+	return w.busyRetryAfter
+}
+
+// RetryAfterSeconds returns the retry hint in seconds (at least 1) for the
+// Retry-After header
+func (e *BusyError) RetryAfterSeconds() int {
+	s := int((e.RetryAfter + time.Second - 1) / time.Second)
+	if s < 1 {
+		s = 1
+	}
+	return s
+}
@@ -0,0 +1,188 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/criyle/go-judge/envexec"
+)
+
+// Checker defines the cmd runs after all cmds accepted to judge their outputs,
+// its exit status maps to the verdict of the request
+type Checker struct {
+	Cmd Cmd
+
+	// Inputs copies result files of the cmds into the checker
+	Inputs []CheckerInput
+
+	// ExitStatus maps the checker exit status to the verdict, exit status 0
+	// is Accepted and the others are Wrong Answer if not mapped
+	ExitStatus map[int]envexec.Status
+
+	// Testlib runs the checker as testlib checker (optional)
+	Testlib *TestlibChecker
+
+	clamped []string // clamped limits of the cmd
+}
+
+// checkChecker validates and caps the checker cmd as the cmds of the request
+// (the copy in size is checked before queued), it returns the checker with
+// the cmd capped
+func (w *worker) checkChecker(ck *Checker) (*Checker, error) {
+	if ck == nil {
+		return nil, nil
+	}
+	cmd := []Cmd{ck.Cmd}
+	if err := w.checkArgsSize(cmd); err != nil {
+		return nil, withFieldPrefix(err, "checker")
+	}
+	cmd, clamped, err := w.limitCap.apply(cmd)
+	if err != nil {
+		return nil, withFieldPrefix(err, "checker")
+	}
+	rt := *ck
+	rt.Cmd, rt.clamped = cmd[0], clamped[0]
+	return &rt, nil
+}
+
+// TestlibChecker runs the checker as `args... input output answer` with the
+// files in the checker work dir (copied in or from Inputs), its exit status
+// maps to the verdict by testlib convention unless mapped by ExitStatus and
+// its stderr is the comment if the checker files are not specified
+type TestlibChecker struct {
+	Input  string
+	Output string
+	Answer string
+}
+
+// testlibComment is the collector name of the testlib checker stderr
+const testlibComment = "stderr"
+
+// testlibCommentMax is the max size of the testlib checker comment
+const testlibCommentMax = 64 << 10
+
+// testlibStatus maps the testlib checker exit status to the verdict
+func testlibStatus(exitStatus int) envexec.Status {
+	switch {
+	case exitStatus == 0: // _ok
+		return envexec.StatusAccepted
+	case exitStatus == 1, exitStatus == 4, exitStatus == 8: // _wa, _dirt, _unexpected_eof
+		return envexec.StatusWrongAnswer
+	case exitStatus == 2: // _pe
+		return envexec.StatusPresentationError
+	case exitStatus == 7, exitStatus >= 16: // _points, _partially
+		return envexec.StatusPartiallyCorrect
+	default: // _fail
+		return envexec.StatusJudgementFailed
+	}
+}
+
+// testlibCmd appends the testlib files to the checker args and collects its
+// stderr as the comment if the checker files are not specified
+func testlibCmd(c Cmd, t *TestlibChecker) (Cmd, error) {
+	for _, n := range []string{t.Input, t.Output, t.Answer} {
+		if _, ok := c.CopyIn[n]; !ok {
+			return c, fmt.Errorf("testlib checker file %q is not copied in", n)
+		}
+	}
+	c.Args = append(append([]string(nil), c.Args...), t.Input, t.Output, t.Answer)
+	if len(c.Files) == 0 {
+		c.Files = []CmdFile{nil, nil, &PipeCollector{
+			Name:   testlibComment,
+			Max:    testlibCommentMax,
+			Policy: envexec.CollectorPolicyTruncate,
+		}}
+	}
+	return c, nil
+}
+
+// CheckerInput copies result file Name of cmd Index into checker as Dst
+type CheckerInput struct {
+	Index int
+	Name  string
+	Dst   string
+}
+
+// workDoChecker runs the checker if all results are accepted and sets the
+// verdict of the response
+func (w *worker) workDoChecker(ctx context.Context, envPool envexec.EnvironmentPool, requestID string, ck *Checker, rt *Response) {
+	for _, r := range rt.Results {
+		if r.Status != envexec.StatusAccepted {
+			rt.Verdict = r.Status
+			return
+		}
+	}
+	c := ck.Cmd
+	c.CopyIn = make(map[string]CmdFile, len(ck.Cmd.CopyIn)+len(ck.Inputs))
+	for k, v := range ck.Cmd.CopyIn {
+		c.CopyIn[k] = v
+	}
+	for _, in := range ck.Inputs {
+		f, err := w.checkerInput(rt.Results, in)
+		if err != nil {
+			rt.Verdict = envexec.StatusJudgementFailed
+			rt.Checker = &Result{Status: envexec.StatusFileError, Error: err.Error()}
+			return
+		}
+		c.CopyIn[in.Dst] = f
+	}
+	if ck.Testlib != nil {
+		var err error
+		if c, err = testlibCmd(c, ck.Testlib); err != nil {
+			rt.Verdict = envexec.StatusJudgementFailed
+			rt.Checker = &Result{Status: envexec.StatusFileError, Error: err.Error()}
+			return
+		}
+	}
+
+	// the inputs are counted as copy in files
+	if err := w.checkCopyInSize([]Cmd{c}); err != nil {
+		rt.Verdict = envexec.StatusJudgementFailed
+		rt.Checker = &Result{Status: envexec.StatusFileError, Error: withFieldPrefix(err, "checker").Error()}
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	run := w.inflight.add(&Request{RequestID: requestID, Cmd: []Cmd{c}}, cancel)
+	defer w.inflight.remove(run)
+	crt := w.workDoSingle(ctx, envPool, c, false, run, nil, nil)
+	if crt.Error != nil {
+		rt.Verdict = envexec.StatusJudgementFailed
+		rt.Checker = &Result{Status: envexec.StatusInternalError, Error: crt.Error.Error()}
+		return
+	}
+	res := crt.Results[0]
+	res.LimitsClamped = ck.clamped
+	rt.Checker = &res
+	if ck.Testlib != nil {
+		rt.CheckerComment = strings.TrimSpace(string(res.Files[testlibComment]))
+	}
+	switch {
+	case res.Status != envexec.StatusAccepted && res.Status != envexec.StatusNonzeroExitStatus:
+		rt.Verdict = envexec.StatusJudgementFailed
+	case ck.ExitStatus != nil && ck.ExitStatus[res.ExitStatus] != envexec.StatusInvalid:
+		rt.Verdict = ck.ExitStatus[res.ExitStatus]
+	case ck.Testlib != nil:
+		rt.Verdict = testlibStatus(res.ExitStatus)
+	case res.ExitStatus == 0:
+		rt.Verdict = envexec.StatusAccepted
+	default:
+		rt.Verdict = envexec.StatusWrongAnswer
+	}
+}
+
+func (w *worker) checkerInput(results []Result, in CheckerInput) (CmdFile, error) {
+	if in.Index < 0 || in.Index >= len(results) {
+		return nil, fmt.Errorf("checker input index %d out of range", in.Index)
+	}
+	r := results[in.Index]
+	if b, ok := r.Files[in.Name]; ok {
+		return &MemoryFile{Content: b}, nil
+	}
+	if id, ok := r.FileIDs[in.Name]; ok {
+		return &CachedFile{FileID: id}, nil
+	}
+	return nil, fmt.Errorf("checker input %s of cmd %d does not exist", in.Name, in.Index)
+}
@@ -0,0 +1,331 @@
+package worker
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/criyle/go-judge/envexec"
+)
+
+// Compare compares the collected output file Name of cmd Index with Answer
+// after all cmds accepted and decides the verdict without a checker
+type Compare struct {
+	Index  int
+	Name   string
+	Answer CmdFile
+	Policy ComparePolicy
+
+	// Epsilon is the max absolute or relative error of the numbers
+	// (ComparePolicyFloat)
+	Epsilon float64
+}
+
+// ComparePolicy defines how the output is compared with the answer
+type ComparePolicy int
+
+// Compare policies
+const (
+	ComparePolicyExact            ComparePolicy = iota // byte to byte
+	ComparePolicyTrimTrailing                          // ignores trailing whitespace of lines and trailing empty lines
+	ComparePolicyIgnoreWhitespace                      // ignores all whitespace
+	ComparePolicyToken                                 // compares whitespace separated tokens
+	ComparePolicyFloat                                 // compares tokens, numbers within Epsilon
+)
+
+var comparePolicyToString = []string{
+	"exact",
+	"trimTrailing",
+	"ignoreWhitespace",
+	"token",
+	"float",
+}
+
+func (p ComparePolicy) String() string {
+	pi := int(p)
+	if pi < 0 || pi >= len(comparePolicyToString) {
+		return comparePolicyToString[0]
+	}
+	return comparePolicyToString[pi]
+}
+
+// CompareResult defines the result of the comparison, Line and Column (1
+// based) locate the first difference in the output with the differing line
+// or token of both sides
+type CompareResult struct {
+	Match    bool
+	Line     int
+	Column   int
+	Expected string
+	Actual   string
+	Error    string
+}
+
+// compareSnippetMax is the max length of the differing line or token
+const compareSnippetMax = 64
+
+// checkCompare validates the compare of the request
+func checkCompare(req *Request) error {
+	c := req.Compare
+	if c == nil {
+		return nil
+	}
+	n := len(req.Cmd)
+	if len(req.Inputs) > 0 {
+		n = len(req.Inputs)
+	}
+	switch {
+	case req.Checker != nil:
+		return reject(RejectInvalidArgument, "compare", "compare is not supported with checker")
+	case c.Index < 0 || c.Index >= n:
+		return reject(RejectInvalidArgument, "compare.index", "compare index %d out of range", c.Index)
+	case c.Answer == nil:
+		return reject(RejectInvalidArgument, "compare.answer", "compare answer is not specified")
+	case c.Policy < ComparePolicyExact || c.Policy > ComparePolicyFloat:
+		return reject(RejectInvalidArgument, "compare.policy", "compare policy %d is not valid", c.Policy)
+	case c.Epsilon < 0 || math.IsNaN(c.Epsilon):
+		return reject(RejectInvalidArgument, "compare.epsilon", "compare epsilon %v is not valid", c.Epsilon)
+	}
+	return nil
+}
+
+// workDoCompare compares the output if all results are accepted and sets the
+// verdict of the response
+func (w *worker) workDoCompare(c *Compare, rt *Response) {
+	for _, r := range rt.Results {
+		if r.Status != envexec.StatusAccepted {
+			rt.Verdict = r.Status
+			return
+		}
+	}
+	cr, err := w.compare(rt.Results, c)
+	if err != nil {
+		rt.Verdict = envexec.StatusJudgementFailed
+		rt.Compare = &CompareResult{Error: err.Error()}
+		return
+	}
+	rt.Compare = &cr
+	if cr.Match {
+		rt.Verdict = envexec.StatusAccepted
+	} else {
+		rt.Verdict = envexec.StatusWrongAnswer
+	}
+}
+
+func (w *worker) compare(results []Result, c *Compare) (CompareResult, error) {
+	output, ok := results[c.Index].Files[c.Name]
+	if !ok {
+		return CompareResult{}, fmt.Errorf("compare output %s of cmd %d is not collected", c.Name, c.Index)
+	}
+	f, err := w.prepareCopyIn(map[string]CmdFile{"answer": c.Answer})
+	if err != nil {
+		return CompareResult{}, err
+	}
+	answer, err := f["answer"].Content()
+	if err != nil {
+		return CompareResult{}, fmt.Errorf("compare answer: %v", err)
+	}
+	switch c.Policy {
+	case ComparePolicyTrimTrailing:
+		return compareLines(answer, output), nil
+	case ComparePolicyIgnoreWhitespace:
+		return compareIgnoreWhitespace(answer, output), nil
+	case ComparePolicyToken:
+		return compareTokens(answer, output, -1), nil
+	case ComparePolicyFloat:
+		return compareTokens(answer, output, c.Epsilon), nil
+	default:
+		return compareExact(answer, output), nil
+	}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
+
+// location returns the line and column of the offset
+func location(b []byte, off int) (int, int) {
+	line := bytes.Count(b[:off], []byte("\n")) + 1
+	return line, off - (bytes.LastIndexByte(b[:off], '\n') + 1) + 1
+}
+
+// lineAt returns the line contains the offset
+func lineAt(b []byte, off int) string {
+	if off > len(b) {
+		off = len(b)
+	}
+	s := bytes.LastIndexByte(b[:off], '\n') + 1
+	e := bytes.IndexByte(b[off:], '\n')
+	if e < 0 {
+		e = len(b)
+	} else {
+		e += off
+	}
+	return snippet(b[s:e])
+}
+
+func snippet(b []byte) string {
+	if len(b) > compareSnippetMax {
+		b = b[:compareSnippetMax]
+	}
+	return string(b)
+}
+
+func compareExact(answer, output []byte) CompareResult {
+	if bytes.Equal(answer, output) {
+		return CompareResult{Match: true}
+	}
+	i := 0
+	for i < len(answer) && i < len(output) && answer[i] == output[i] {
+		i++
+	}
+	l, c := location(output, i)
+	return CompareResult{Line: l, Column: c, Expected: lineAt(answer, i), Actual: lineAt(output, i)}
+}
+
+// trimLines splits the lines with trailing whitespace and trailing empty
+// lines removed
+func trimLines(b []byte) [][]byte {
+	lines := bytes.Split(b, []byte("\n"))
+	for i := range lines {
+		lines[i] = bytes.TrimRight(lines[i], " \t\r\v\f")
+	}
+	for len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func compareLines(answer, output []byte) CompareResult {
+	al, ol := trimLines(answer), trimLines(output)
+	for i := 0; i < len(al) || i < len(ol); i++ {
+		var a, o []byte
+		if i < len(al) {
+			a = al[i]
+		}
+		if i < len(ol) {
+			o = ol[i]
+		}
+		if bytes.Equal(a, o) {
+			continue
+		}
+		j := 0
+		for j < len(a) && j < len(o) && a[j] == o[j] {
+			j++
+		}
+		return CompareResult{Line: i + 1, Column: j + 1, Expected: snippet(a), Actual: snippet(o)}
+	}
+	return CompareResult{Match: true}
+}
+
+func compareIgnoreWhitespace(answer, output []byte) CompareResult {
+	i, j := 0, 0
+	for {
+		for i < len(answer) && isSpace(answer[i]) {
+			i++
+		}
+		for j < len(output) && isSpace(output[j]) {
+			j++
+		}
+		if i == len(answer) && j == len(output) {
+			return CompareResult{Match: true}
+		}
+		if i == len(answer) || j == len(output) || answer[i] != output[j] {
+			l, c := location(output, j)
+			return CompareResult{Line: l, Column: c, Expected: lineAt(answer, i), Actual: lineAt(output, j)}
+		}
+		i++
+		j++
+	}
+}
+
+// token is a whitespace separated token with its offset
+type token struct {
+	b   []byte
+	off int
+}
+
+func nextToken(b []byte, off int) (token, int) {
+	for off < len(b) && isSpace(b[off]) {
+		off++
+	}
+	s := off
+	for off < len(b) && !isSpace(b[off]) {
+		off++
+	}
+	return token{b: b[s:off], off: s}, off
+}
+
+// compareTokens compares the tokens, numbers are compared within eps unless
+// eps is negative
+func compareTokens(answer, output []byte, eps float64) CompareResult {
+	i, j := 0, 0
+	for {
+		var a, o token
+		a, i = nextToken(answer, i)
+		o, j = nextToken(output, j)
+		if len(a.b) == 0 && len(o.b) == 0 {
+			return CompareResult{Match: true}
+		}
+		if tokenEqual(a.b, o.b, eps) {
+			continue
+		}
+		l, c := location(output, o.off)
+		return CompareResult{Line: l, Column: c, Expected: snippet(a.b), Actual: snippet(o.b)}
+	}
+}
+
+func tokenEqual(a, o []byte, eps float64) bool {
+	if bytes.Equal(a, o) {
+		return true
+	}
+	if eps < 0 || !isDecimal(a) || !isDecimal(o) {
+		return false
+	}
+	fa, err := strconv.ParseFloat(string(a), 64)
+	if err != nil {
+		return false
+	}
+	fo, err := strconv.ParseFloat(string(o), 64)
+	if err != nil {
+		return false
+	}
+	d := math.Abs(fa - fo)
+	return d <= eps || d <= eps*math.Abs(fa)
+}
+
+// isDecimal reports whether the token is a decimal number as
+// [+-]digits[.digits][(e|E)[+-]digits] (no hex, inf or nan)
+func isDecimal(b []byte) bool {
+	i := 0
+	digits := func() bool {
+		s := i
+		for i < len(b) && b[i] >= '0' && b[i] <= '9' {
+			i++
+		}
+		return i > s
+	}
+	if i < len(b) && (b[i] == '+' || b[i] == '-') {
+		i++
+	}
+	if !digits() {
+		return false
+	}
+	if i < len(b) && b[i] == '.' {
+		i++
+		if !digits() {
+			return false
+		}
+	}
+	if i < len(b) && (b[i] == 'e' || b[i] == 'E') {
+		i++
+		if i < len(b) && (b[i] == '+' || b[i] == '-') {
+			i++
+		}
+		if !digits() {
+			return false
+		}
+	}
+	return i == len(b)
+}
@@ -0,0 +1,106 @@
+package worker
+
+import "testing"
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy ComparePolicy
+		eps    float64
+		answer string
+		output string
+		want   CompareResult
+	}{
+		{"exact", ComparePolicyExact, 0, "1 2\n", "1 2\n", CompareResult{Match: true}},
+		{"exact trailing line", ComparePolicyExact, 0, "1 2\n", "1 2\n\n", CompareResult{Line: 2, Column: 1}},
+		{"exact empty output", ComparePolicyExact, 0, "1\n", "", CompareResult{Line: 1, Column: 1, Expected: "1"}},
+		{"exact both empty", ComparePolicyExact, 0, "", "", CompareResult{Match: true}},
+		{"exact differ", ComparePolicyExact, 0, "1 2\n3 4\n", "1 2\n3 5\n", CompareResult{Line: 2, Column: 3, Expected: "3 4", Actual: "3 5"}},
+
+		{"trim trailing lines", ComparePolicyTrimTrailing, 0, "1 2\n", "1 2  \r\n\n\n", CompareResult{Match: true}},
+		{"trim missing answer line", ComparePolicyTrimTrailing, 0, "1\n2\n", "1\n", CompareResult{Line: 2, Column: 1, Expected: "2"}},
+		{"trim extra output line", ComparePolicyTrimTrailing, 0, "1\n", "1\n2\n", CompareResult{Line: 2, Column: 1, Actual: "2"}},
+		{"trim empty output", ComparePolicyTrimTrailing, 0, "\n\n", "", CompareResult{Match: true}},
+		{"trim leading space", ComparePolicyTrimTrailing, 0, "1\n", " 1\n", CompareResult{Line: 1, Column: 1, Expected: "1", Actual: " 1"}},
+
+		{"whitespace", ComparePolicyIgnoreWhitespace, 0, "1 2\n", "12", CompareResult{Match: true}},
+		{"whitespace trailing lines", ComparePolicyIgnoreWhitespace, 0, "1\n", "\n1\n\n\n", CompareResult{Match: true}},
+		{"whitespace empty output", ComparePolicyIgnoreWhitespace, 0, "1\n", "", CompareResult{Line: 1, Column: 1, Expected: "1"}},
+		{"whitespace differ", ComparePolicyIgnoreWhitespace, 0, "1 2\n", "1 3\n", CompareResult{Line: 1, Column: 3, Expected: "1 2", Actual: "1 3"}},
+
+		{"token", ComparePolicyToken, -1, "1 2\n", "1\n2", CompareResult{Match: true}},
+		{"token trailing lines", ComparePolicyToken, -1, "1 2", "1 2\n\n\n", CompareResult{Match: true}},
+		{"token empty output", ComparePolicyToken, -1, "1", "", CompareResult{Line: 1, Column: 1, Expected: "1"}},
+		{"token extra output", ComparePolicyToken, -1, "1", "1 2", CompareResult{Line: 1, Column: 3, Actual: "2"}},
+		{"token number not equal", ComparePolicyToken, -1, "1.0", "1", CompareResult{Line: 1, Column: 1, Expected: "1.0", Actual: "1"}},
+
+		{"float equal", ComparePolicyFloat, 1e-6, "1.0 -2", "1 -2.0000000", CompareResult{Match: true}},
+		{"float absolute eps", ComparePolicyFloat, 1e-3, "0.001", "0.0015", CompareResult{Match: true}},
+		{"float absolute eps exceeded", ComparePolicyFloat, 1e-3, "0.001", "0.003", CompareResult{Line: 1, Column: 1, Expected: "0.001", Actual: "0.003"}},
+		{"float relative eps", ComparePolicyFloat, 1e-6, "1000000000", "1000000500", CompareResult{Match: true}},
+		{"float relative eps exceeded", ComparePolicyFloat, 1e-6, "1000000000", "1000002000", CompareResult{Line: 1, Column: 1, Expected: "1000000000", Actual: "1000002000"}},
+		{"float exponent", ComparePolicyFloat, 1e-6, "1.5e3", "1500", CompareResult{Match: true}},
+		{"float trailing lines", ComparePolicyFloat, 1e-6, "1.0\n", "1\n\n\n", CompareResult{Match: true}},
+		{"float empty output", ComparePolicyFloat, 1e-6, "1.0", "", CompareResult{Line: 1, Column: 1, Expected: "1.0"}},
+		{"float word", ComparePolicyFloat, 1e-6, "yes", "yes", CompareResult{Match: true}},
+		{"float hex", ComparePolicyFloat, 1e-6, "1", "0x1p0", CompareResult{Line: 1, Column: 1, Expected: "1", Actual: "0x1p0"}},
+		{"float inf", ComparePolicyFloat, 1e-6, "1e400", "inf", CompareResult{Line: 1, Column: 1, Expected: "1e400", Actual: "inf"}},
+		{"float infinity", ComparePolicyFloat, 1e-6, "1", "Infinity", CompareResult{Line: 1, Column: 1, Expected: "1", Actual: "Infinity"}},
+		{"float nan", ComparePolicyFloat, 1e-6, "nan", "NaN", CompareResult{Line: 1, Column: 1, Expected: "nan", Actual: "NaN"}},
+		{"float missing fraction", ComparePolicyFloat, 1e-6, "1", "1.", CompareResult{Line: 1, Column: 1, Expected: "1", Actual: "1."}},
+		{"float missing integer", ComparePolicyFloat, 1e-6, "0.5", ".5", CompareResult{Line: 1, Column: 1, Expected: "0.5", Actual: ".5"}},
+		{"float underscore", ComparePolicyFloat, 1e-6, "1000", "1_000", CompareResult{Line: 1, Column: 1, Expected: "1000", Actual: "1_000"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got CompareResult
+			a, o := []byte(tc.answer), []byte(tc.output)
+			switch tc.policy {
+			case ComparePolicyTrimTrailing:
+				got = compareLines(a, o)
+			case ComparePolicyIgnoreWhitespace:
+				got = compareIgnoreWhitespace(a, o)
+			case ComparePolicyToken, ComparePolicyFloat:
+				got = compareTokens(a, o, tc.eps)
+			default:
+				got = compareExact(a, o)
+			}
+			if got != tc.want {
+				t.Errorf("got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsDecimal(t *testing.T) {
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{"0", true},
+		{"-12", true},
+		{"+3.25", true},
+		{"1e9", true},
+		{"1.5E-3", true},
+		{"2e+10", true},
+		{"", false},
+		{"-", false},
+		{"1.", false},
+		{".5", false},
+		{"1e", false},
+		{"1e+", false},
+		{"0x10", false},
+		{"0x1p-2", false},
+		{"inf", false},
+		{"+Inf", false},
+		{"infinity", false},
+		{"nan", false},
+		{"1_000", false},
+		{"1.2.3", false},
+	}
+	for _, tc := range tests {
+		if got := isDecimal([]byte(tc.s)); got != tc.want {
+			t.Errorf("isDecimal(%q) = %v, want %v", tc.s, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+package worker
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/criyle/go-judge/envexec"
+	"github.com/criyle/go-judge/file"
+	"github.com/criyle/go-judge/filestore"
+)
+
+// compileCache reuses the copy out files of accepted runs keyed by the
+// normalized cmd hash, cached files are kept in the file store as copies owned
+// by the cache and each hit gets its own copies (removed by the client)
+type compileCache struct {
+	fs         filestore.FileStore
+	ttl        time.Duration // 0 never expires
+	maxEntries int
+
+	mu      sync.Mutex
+	lru     *list.List // front is the most recently used
+	entries map[string]*list.Element
+}
+
+type compileCacheEntry struct {
+	key     string
+	files   map[string][]byte
+	fileIDs map[string]string
+	expire  time.Time
+}
+
+func newCompileCache(fs filestore.FileStore, maxEntries int, ttl time.Duration) *compileCache {
+	return &compileCache{
+		fs:         fs,
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		lru:        list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// get returns the cached result with copies of the cached files if all of
+// them still exist
+func (c *compileCache) get(key string) (Result, bool) {
+	c.mu.Lock()
+	el, ok := c.entries[key]
+	if !ok {
+		c.mu.Unlock()
+		return Result{}, false
+	}
+	e := el.Value.(*compileCacheEntry)
+	if !e.expire.IsZero() && time.Now().After(e.expire) {
+		c.remove(el)
+		c.mu.Unlock()
+		return Result{}, false
+	}
+	c.lru.MoveToFront(el)
+	c.mu.Unlock()
+
+	res := Result{
+		Status:   envexec.StatusAccepted,
+		Files:    make(map[string][]byte, len(e.files)),
+		FileIDs:  make(map[string]string, len(e.fileIDs)),
+		CacheHit: true,
+	}
+	for k, v := range e.files {
+		res.Files[k] = v
+	}
+	for k, id := range e.fileIDs {
+		nid, err := c.copyFile(id)
+		if err != nil {
+			c.removeFiles(res.FileIDs)
+			c.mu.Lock()
+			if el, ok := c.entries[key]; ok && el.Value == e {
+				c.remove(el)
+			}
+			c.mu.Unlock()
+			return Result{}, false
+		}
+		res.FileIDs[k] = nid
+	}
+	return res, true
+}
+
+// copyFile adds a copy of the file in the file store
+func (c *compileCache) copyFile(id string) (string, error) {
+	f := c.fs.Get(id)
+	if f == nil {
+		return "", fmt.Errorf("compile cache: file %s not exists", id)
+	}
+	b, err := f.Content()
+	if err != nil {
+		return "", err
+	}
+	return c.fs.Add(f.Name(), b)
+}
+
+func (c *compileCache) removeFiles(ids map[string]string) {
+	for _, id := range ids {
+		c.fs.Remove(id)
+	}
+}
+
+// put stores the result if it is accepted
+func (c *compileCache) put(key string, res Result) {
+	if res.Status != envexec.StatusAccepted {
+		return
+	}
+	// the files of the result belong to the client
+	fileIDs := make(map[string]string, len(res.FileIDs))
+	for k, id := range res.FileIDs {
+		nid, err := c.copyFile(id)
+		if err != nil {
+			c.removeFiles(fileIDs)
+			return
+		}
+		fileIDs[k] = nid
+	}
+	e := &compileCacheEntry{
+		key:     key,
+		files:   res.Files,
+		fileIDs: fileIDs,
+	}
+	if c.ttl > 0 {
+		e.expire = time.Now().Add(c.ttl)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.remove(el)
+	}
+	c.entries[key] = c.lru.PushFront(e)
+	for c.lru.Len() > c.maxEntries {
+		c.remove(c.lru.Back())
+	}
+}
+
+// remove drops the entry and its cached files
+func (c *compileCache) remove(el *list.Element) {
+	e := el.Value.(*compileCacheEntry)
+	c.lru.Remove(el)
+	delete(c.entries, e.key)
+	c.removeFiles(e.fileIDs)
+}
+
+// compileCacheKey returns the hash of the normalized cmd, limits are not
+// included since only accepted results are cached. It returns false if the
+// cmd cannot be cached (e.g. url or stream files)
+func (w *worker) compileCacheKey(image string, c Cmd) (string, bool) {
+	if w.compileCache == nil || !c.Cache {
+		return "", false
+	}
+	h := sha256.New()
+	writeStrings(h, "image", image, "profile", c.Profile, "tty", fmt.Sprint(c.TTY))
+
+	writeStrings(h, "args")
+	writeStrings(h, c.Args...)
+
+	env := append(append([]string(nil), c.Env...), c.Secrets.env()...)
+	sort.Strings(env)
+	writeStrings(h, "env")
+	writeStrings(h, env...)
+	writeStrings(h, "envFile", c.EnvFile)
+
+	writeStrings(h, "postExec")
+	writeStrings(h, c.PostExec...)
+
+	writeStrings(h, "files")
+	for _, f := range c.Files {
+		if !w.writeFileHash(h, f) {
+			return "", false
+		}
+	}
+	if c.StdinTee != nil {
+		writeStrings(h, "stdinTee", c.StdinTee.Name, fmt.Sprint(c.StdinTee.Max))
+	}
+
+	names := make([]string, 0, len(c.CopyIn))
+	for n := range c.CopyIn {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	writeStrings(h, "copyIn")
+	for _, n := range names {
+		writeStrings(h, n)
+		if !w.writeFileHash(h, c.CopyIn[n]) {
+			return "", false
+		}
+	}
+
+	copyOut := append([]string(nil), c.CopyOut...)
+	sort.Strings(copyOut)
+	writeStrings(h, "copyOut")
+	writeStrings(h, copyOut...)
+
+	copyOutCached := append([]string(nil), c.CopyOutCached...)
+	sort.Strings(copyOutCached)
+	writeStrings(h, "copyOutCached")
+	writeStrings(h, copyOutCached...)
+
+	writeStrings(h, "copyOutDirs")
+	for _, d := range c.CopyOutDirs {
+		writeStrings(h, d.Src, d.Prefix)
+	}
+	writeStrings(h, "copyOutDir", c.CopyOutDir, "copyOutMax", fmt.Sprint(c.CopyOutMax),
+		"copyOutPartial", fmt.Sprint(c.CopyOutPartial), "copyOutTotalMax", fmt.Sprint(c.CopyOutTotalMax),
+		"copyOutIncludePipes", fmt.Sprint(c.CopyOutIncludePipes))
+	return hex.EncodeToString(h.Sum(nil)), true
+}
+
+// writeFileHash writes the file content into hash, it returns false if the
+// file content cannot be determined before run
+func (w *worker) writeFileHash(h hash.Hash, f CmdFile) bool {
+	switch f := f.(type) {
+	case nil:
+		writeStrings(h, "nil")
+		return true
+
+	case *PipeCollector:
+		writeStrings(h, "pipe", f.Name, fmt.Sprint(f.Max), fmt.Sprint(f.Policy))
+		return true
+
+	case *EmptyFile:
+		writeStrings(h, "empty")
+		return true
+
+	case *LocalFile, *MemoryFile, *CachedFile:
+		ef, err := f.EnvFile(w.fs)
+		if err != nil {
+			return false
+		}
+		fi, ok := ef.(file.ReaderOpener)
+		if !ok {
+			return false
+		}
+		r, err := fi.Reader()
+		if err != nil {
+			return false
+		}
+		defer r.Close()
+
+		fh := sha256.New()
+		if _, err := io.Copy(fh, r); err != nil {
+			return false
+		}
+		writeStrings(h, "file", hex.EncodeToString(fh.Sum(nil)))
+		return true
+
+	default:
+		return false
+	}
+}
+
+func writeStrings(h hash.Hash, s ...string) {
+	for _, v := range s {
+		fmt.Fprintf(h, "%d:%s", len(v), v)
+	}
+}
@@ -0,0 +1,103 @@
+package worker
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/criyle/go-judge/file"
+)
+
+// checkRequestCopyIn checks the copy in size of the cmds and the checker of
+// the request
+func (w *worker) checkRequestCopyIn(req *Request) error {
+	if err := w.checkCopyInSize(req.Cmd); err != nil {
+		return err
+	}
+	if req.Checker != nil {
+		if err := w.checkCopyInSize([]Cmd{req.Checker.Cmd}); err != nil {
+			return withFieldPrefix(err, "checker")
+		}
+	}
+	return nil
+}
+
+// checkCopyInSize ensures each copy in file (including the content of the
+// stdin files) and total copy in files of the request are within limits
+// before they are copied into containers
+func (w *worker) checkCopyInSize(cmds []Cmd) error {
+	if w.copyInLimit == 0 && w.copyInTotalLimit == 0 {
+		return nil
+	}
+	var total int64
+	check := func(field, name string, f CmdFile) error {
+		s, err := w.copyInSize(f)
+		if err != nil {
+			return withFieldPrefix(err, field)
+		}
+		if w.copyInLimit > 0 && s > int64(w.copyInLimit) {
+			return reject(RejectLimitExceeded, field,
+				"copy in file %s have size (%d) exceeded the limit (%d)", name, s, w.copyInLimit)
+		}
+		total += s
+		return nil
+	}
+	for i, c := range cmds {
+		for name, f := range c.CopyIn {
+			if err := check(fmt.Sprintf("cmd[%d].copyIn.%s", i, name), name, f); err != nil {
+				return err
+			}
+		}
+		for j, f := range c.Files {
+			if f, ok := f.(*MemoryFile); ok {
+				if err := check(fmt.Sprintf("cmd[%d].files[%d]", i, j), fmt.Sprintf("files[%d]", j), f); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if w.copyInTotalLimit > 0 && total > int64(w.copyInTotalLimit) {
+		return reject(RejectLimitExceeded, "cmd", "copy in files have total size (%d) exceeded the limit (%d)", total, w.copyInTotalLimit)
+	}
+	return nil
+}
+
+// copyInSize returns the size of the copy in file, url file is limited while
+// fetching thus not counted
+func (w *worker) copyInSize(f CmdFile) (int64, error) {
+	switch f := f.(type) {
+	case *MemoryFile:
+		return int64(len(f.Content)), nil
+
+	case *LocalFile:
+		s, err := os.Stat(f.Src)
+		if err != nil {
+			return 0, err
+		}
+		return s.Size(), nil
+
+	case *CachedFile:
+		fd := w.fs.Get(f.FileID)
+		if fd == nil {
+			return 0, reject(RejectFileNotFound, "", "file not exists with id %v", f.FileID)
+		}
+		return fileSize(fd)
+	}
+	return 0, nil
+}
+
+func fileSize(f file.File) (int64, error) {
+	if rs, ok := f.(file.ReadSeekerOpener); ok {
+		r, err := rs.ReadSeeker()
+		if err != nil {
+			return 0, err
+		}
+		defer r.Close()
+		return r.Seek(0, io.SeekEnd)
+	}
+	c, err := f.Content()
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(c)), nil
+}
@@ -0,0 +1,111 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/criyle/go-judge/envexec"
+)
+
+// DebugInfo defines the resolved invocation of the cmd for auditing
+type DebugInfo struct {
+	Profile string
+	Seccomp string
+	Args    []string
+	Env     []string
+
+	CPULimit          time.Duration
+	ClockLimit        time.Duration
+	MemoryLimit       envexec.Size
+	StackLimit        envexec.Size
+	OutputLimit       envexec.Size
+	ProcLimit         uint64
+	CPURateLimit      float64
+	StrictMemoryLimit bool
+	CopyOutMax        envexec.Size
+	Cgroup            string // pooled or fresh
+
+	CgroupVersion     int // 0 without cgroup
+	CgroupControllers []string
+
+	OpenFiles []string          // last sampled before exit
+	Syscalls  map[string]uint64 // with CountSyscalls
+	Diagnosis string            // container init stderr
+}
+
+// debugEnabled returns whether debug info is enabled for the request
+func (w *worker) debugEnabled(debug bool) bool {
+	return debug && w.enableDebug
+}
+
+// debugInfo returns the resolved invocation and the sampled diagnosis of the
+// result if debug is enabled
+func (w *worker) debugInfo(debug bool, rc Cmd, c *envexec.Cmd, result envexec.Result) *DebugInfo {
+	if !w.debugEnabled(debug) {
+		return nil
+	}
+	profile := rc.Profile
+	if _, ok := w.profiles[defaultProfile]; ok && profile == "" {
+		profile = defaultProfile
+	}
+	d := &DebugInfo{
+		Profile:           profile,
+		Seccomp:           w.seccompName,
+		Args:              rc.Secrets.redactArgs(c.Args),
+		Env:               rc.Secrets.redactEnv(c.Env),
+		CPULimit:          c.TimeLimit,
+		ClockLimit:        clockLimit(rc),
+		MemoryLimit:       c.MemoryLimit,
+		StackLimit:        c.StackLimit,
+		OutputLimit:       c.OutputLimit,
+		ProcLimit:         c.ProcLimit,
+		CPURateLimit:      c.CPURateLimit,
+		StrictMemoryLimit: c.StrictMemoryLimit,
+		CopyOutMax:        c.CopyOutMax,
+
+		Cgroup:    w.cgroupStrategy(c.FreshCgroup),
+		OpenFiles: result.OpenFiles,
+		Syscalls:  result.SyscallCounts,
+		Diagnosis: result.Diagnosis,
+	}
+	if i := result.CgroupInfo; i != nil {
+		d.CgroupVersion, d.CgroupControllers = i.Version, i.Controllers
+	}
+	return d
+}
+
+// diagnoseError appends the diagnosis of the environment failure (e.g. the
+// stderr of the container init failed to build) to the error if debug enabled
+func (w *worker) diagnoseError(debug bool, err error) error {
+	var d envexec.Diagnoser
+	if err == nil || !w.debugEnabled(debug) || !errors.As(err, &d) {
+		return err
+	}
+	s := strings.TrimSpace(d.Diagnosis())
+	if s == "" {
+		return err
+	}
+	return fmt.Errorf("%w (diagnosis: %s)", err, s)
+}
+
+// checkCountSyscalls rejects syscall counts of the cmds unless debug enabled
+func (w *worker) checkCountSyscalls(req *Request) error {
+	if w.debugEnabled(req.Debug) {
+		return nil
+	}
+	for i, c := range req.Cmd {
+		if c.CountSyscalls {
+			return reject(RejectNotAllowed, fmt.Sprintf("cmd[%d].countSyscalls", i), "cmd %d: syscall counts require debug enabled", i)
+		}
+	}
+	return nil
+}
+
+func (w *worker) cgroupStrategy(fresh bool) string {
+	if w.cgroupPool && !fresh {
+		return "pooled"
+	}
+	return "fresh"
+}
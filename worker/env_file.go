@@ -0,0 +1,65 @@
+package worker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/criyle/go-judge/file"
+)
+
+// readEnvFile parses the copy in file as env variables, each line is
+// KEY=VALUE with optional export prefix and quoted value, empty lines and
+// lines start with # are ignored
+func readEnvFile(name string, f file.File) ([]string, error) {
+	b, err := f.Content()
+	if err != nil {
+		return nil, fmt.Errorf("env file %s: %v", name, err)
+	}
+	var rt []string
+	for i, l := range strings.Split(string(b), "\n") {
+		l = strings.TrimSpace(l)
+		if l == "" || strings.HasPrefix(l, "#") {
+			continue
+		}
+		kv := strings.SplitN(strings.TrimPrefix(l, "export "), "=", 2)
+		key := strings.TrimSpace(kv[0])
+		if len(kv) != 2 || !validEnvKey(key) {
+			return nil, fmt.Errorf("env file %s: line %d: invalid env %q", name, i+1, l)
+		}
+		value := strings.TrimSpace(kv[1])
+		if n := len(value); n >= 2 && (value[0] == '"' || value[0] == '\'') && value[n-1] == value[0] {
+			value = value[1 : n-1]
+		}
+		rt = append(rt, key+"="+value)
+	}
+	return rt, nil
+}
+
+func validEnvKey(k string) bool {
+	if k == "" {
+		return false
+	}
+	for i, c := range k {
+		if c == '_' || (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (i > 0 && c >= '0' && c <= '9') {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// mergeEnv appends env variables not already defined in base
+func mergeEnv(base, env []string) []string {
+	defined := make(map[string]bool, len(base))
+	for _, e := range base {
+		defined[strings.SplitN(e, "=", 2)[0]] = true
+	}
+	for _, e := range env {
+		k := strings.SplitN(e, "=", 2)[0]
+		if !defined[k] {
+			defined[k] = true
+			base = append(base, e)
+		}
+	}
+	return base
+}
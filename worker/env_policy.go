@@ -0,0 +1,84 @@
+package worker
+
+import "strings"
+
+// EnvPolicy restricts the env names cmd could set by Env, Secrets and
+// EnvFile (env of profiles and stdbuf are not restricted), a name ending
+// with * matches by prefix (e.g. LD_*)
+type EnvPolicy struct {
+	Allow []string // names allowed only if not empty
+	Deny  []string // names denied, takes precedence over Allow
+
+	// Strip removes the forbidden env with a warning instead of rejecting
+	Strip bool
+}
+
+func (p EnvPolicy) enabled() bool {
+	return len(p.Allow) > 0 || len(p.Deny) > 0
+}
+
+func (p EnvPolicy) allowed(name string) bool {
+	if matchEnvName(p.Deny, name) {
+		return false
+	}
+	return len(p.Allow) == 0 || matchEnvName(p.Allow, name)
+}
+
+func matchEnvName(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if strings.HasSuffix(p, "*") {
+			if strings.HasPrefix(name, strings.TrimSuffix(p, "*")) {
+				return true
+			}
+		} else if p == name {
+			return true
+		}
+	}
+	return false
+}
+
+func envKey(e string) string {
+	return strings.SplitN(e, "=", 2)[0]
+}
+
+// filter returns the env allowed, the forbidden ones of the field are
+// rejected unless Strip
+func (p EnvPolicy) filter(field string, env []string) ([]string, error) {
+	if !p.enabled() {
+		return env, nil
+	}
+	rt := make([]string, 0, len(env))
+	for _, e := range env {
+		k := envKey(e)
+		if p.allowed(k) {
+			rt = append(rt, e)
+			continue
+		}
+		if !p.Strip {
+			return nil, reject(RejectNotAllowed, field, "env %s is not allowed", k)
+		}
+	}
+	return rt, nil
+}
+
+// stripped returns the names of Env and Secrets stripped of each cmd in strip
+// mode (names from EnvFile are stripped without warning)
+func (p EnvPolicy) stripped(cmd []Cmd) [][]string {
+	if !p.enabled() || !p.Strip {
+		return nil
+	}
+	rt := make([][]string, len(cmd))
+	for i, c := range cmd {
+		for _, e := range c.Env {
+			if k := envKey(e); !p.allowed(k) {
+				rt[i] = append(rt[i], k)
+			}
+		}
+		for _, k := range c.Secrets.keys() {
+			if !p.allowed(k) {
+				rt[i] = append(rt[i], k)
+			}
+		}
+	}
+	return rt
+}
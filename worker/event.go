@@ -0,0 +1,73 @@
+package worker
+
+import (
+	"time"
+
+	"github.com/criyle/go-judge/envexec"
+)
+
+// EventType defines the lifecycle event type of the request
+type EventType int
+
+// Defines lifecycle event types
+const (
+	EventAccepted            EventType = iota + 1 // request received
+	EventQueued                                   // request waiting for the worker (or rejected as busy)
+	EventEnvironmentAcquired                      // cmd got environment
+	EventExecStarted                              // cmd started
+	EventExited                                   // cmd exited
+)
+
+var eventTypeToString = []string{
+	"invalid",
+	"accepted",
+	"queued",
+	"environment-acquired",
+	"exec-started",
+	"exited",
+}
+
+func (t EventType) String() string {
+	ti := int(t)
+	if ti < 0 || ti >= len(eventTypeToString) {
+		return eventTypeToString[0]
+	}
+	return eventTypeToString[ti]
+}
+
+// Event defines the lifecycle event of the request
+type Event struct {
+	Type      EventType
+	RequestID string
+	Index     int // cmd index, -1 for request events
+	Time      time.Time
+}
+
+var stageToEvent = map[envexec.Stage]EventType{
+	envexec.StageEnvironmentAcquired: EventEnvironmentAcquired,
+	envexec.StageExecStarted:         EventExecStarted,
+	envexec.StageExited:              EventExited,
+}
+
+// observeEvent reports the event if the observer presents
+func (w *worker) observeEvent(t EventType, requestID string, index int) {
+	if w.eventObserver == nil {
+		return
+	}
+	w.eventObserver(Event{
+		Type:      t,
+		RequestID: requestID,
+		Index:     index,
+		Time:      time.Now(),
+	})
+}
+
+// stageObserver returns the cmd stage observer reports events of the request
+func (w *worker) stageObserver(requestID string) func(int, envexec.Stage) {
+	if w.eventObserver == nil {
+		return nil
+	}
+	return func(i int, s envexec.Stage) {
+		w.observeEvent(stageToEvent[s], requestID, i)
+	}
+}
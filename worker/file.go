@@ -2,6 +2,7 @@ package worker
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/criyle/go-judge/envexec"
 	"github.com/criyle/go-judge/file"
@@ -20,20 +21,31 @@ var (
 	_ CmdFile = &LocalFile{}
 	_ CmdFile = &MemoryFile{}
 	_ CmdFile = &CachedFile{}
+	_ CmdFile = &EmptyFile{}
 	_ CmdFile = &PipeCollector{}
 )
 
 // LocalFile defines file stores on the local file system
 type LocalFile struct {
 	Src string
+
+	// Link links the file into the work dir as copy in instead of copying if
+	// possible (hard link of read-only file or reflink), e.g. shared dataset
+	Link bool
 }
 
 // EnvFile prepares file for envexec file
 func (f *LocalFile) EnvFile(fs filestore.FileStore) (interface{}, error) {
+	if f.Link {
+		return file.NewLinkFile(f.Src, f.Src), nil
+	}
 	return file.NewLocalFile(f.Src, f.Src), nil
 }
 
 func (f *LocalFile) String() string {
+	if f.Link {
+		return fmt.Sprintf("local:%s(link)", f.Src)
+	}
 	return fmt.Sprintf("local:%s", f.Src)
 }
 
@@ -60,7 +72,7 @@ type CachedFile struct {
 func (f *CachedFile) EnvFile(fs filestore.FileStore) (interface{}, error) {
 	fd := fs.Get(f.FileID)
 	if fd == nil {
-		return nil, fmt.Errorf("file not exists with id %v", f.FileID)
+		return nil, reject(RejectFileNotFound, "", "file not exists with id %v", f.FileID)
 	}
 	return fd, nil
 }
@@ -69,17 +81,41 @@ func (f *CachedFile) String() string {
 	return fmt.Sprintf("cached:(fileId:%s)", f.FileID)
 }
 
+// EmptyFile defines input that reaches EOF immediately (null device)
+type EmptyFile struct{}
+
+// EnvFile prepares file for envexec file
+func (f *EmptyFile) EnvFile(fs filestore.FileStore) (interface{}, error) {
+	return file.NewLocalFile(os.DevNull, os.DevNull), nil
+}
+
+func (f *EmptyFile) String() string {
+	return "empty"
+}
+
 // PipeCollector defines on the output (stdout / stderr) to be collected over pipe
 type PipeCollector struct {
-	Name string // pseudo name generated into copyOut
-	Max  int64  // max size to be collected
+	Name   string                  // pseudo name generated into copyOut
+	Max    int64                   // max size to be collected
+	Policy envexec.CollectorPolicy // action when max exceeded
+
+	// Backpressure closes the pipe once the program writes past max instead
+	// of draining the rest of the output
+	Backpressure bool
 }
 
 // EnvFile prepares file for envexec file
 func (f *PipeCollector) EnvFile(fs filestore.FileStore) (interface{}, error) {
-	return envexec.PipeCollector{Name: f.Name, SizeLimit: f.Max}, nil
+	return envexec.PipeCollector{Name: f.Name, SizeLimit: f.Max, Policy: f.Policy, Backpressure: f.Backpressure}, nil
+}
+
+// withMax returns a copy of the collector with max
+func (f *PipeCollector) withMax(max int64) *PipeCollector {
+	c := *f
+	c.Max = max
+	return &c
 }
 
 func (f *PipeCollector) String() string {
-	return fmt.Sprintf("pipeCollector:(name:%s,max:%d)", f.Name, f.Max)
+	return fmt.Sprintf("pipeCollector:(name:%s,max:%d,policy:%v,backpressure:%v)", f.Name, f.Max, f.Policy, f.Backpressure)
 }
@@ -0,0 +1,58 @@
+package worker
+
+import (
+	"time"
+
+	"github.com/criyle/go-judge/envexec"
+)
+
+// getEnvPool returns the environment pool of the image, empty image selects
+// the default environment pool
+func (w *worker) getEnvPool(image string) (envexec.EnvironmentPool, error) {
+	if image == "" {
+		return w.envPool, nil
+	}
+	p, ok := w.imagePools[image]
+	if !ok {
+		return nil, reject(RejectNotAllowed, "image", "image %s is not allowed", image)
+	}
+	return p, nil
+}
+
+// warmPool gets only the environments already pooled (e.g. prefork) of the
+// pool, it fails as busy if none is pooled instead of building a new one
+type warmPool struct {
+	envexec.EnvironmentPool
+	retryAfter time.Duration
+}
+
+// warmPool returns the pool of the request run, warm only if WarmOnly
+func (w *worker) warmPool(req *Request, p envexec.EnvironmentPool) envexec.EnvironmentPool {
+	if !req.WarmOnly {
+		return p
+	}
+	return warmPool{EnvironmentPool: p, retryAfter: w.retryAfter()}
+}
+
+func (p warmPool) Get() (envexec.Environment, error) {
+	if wp, ok := p.EnvironmentPool.(envexec.WarmEnvironmentPool); ok {
+		if e, ok := wp.GetWarm(); ok {
+			return e, nil
+		}
+	}
+	return nil, &BusyError{RetryAfter: p.retryAfter, Reason: "no warm environment"}
+}
+
+// Discard keeps the environment discarded by the pool underneath
+func (p warmPool) Discard(e envexec.Environment) {
+	discardEnvironment(p.EnvironmentPool, e)
+}
+
+// discardEnvironment discards the environment by the pool if supported
+func discardEnvironment(p envexec.EnvironmentPool, e envexec.Environment) {
+	if d, ok := p.(envexec.EnvironmentDiscarder); ok {
+		d.Discard(e)
+		return
+	}
+	p.Put(e)
+}
@@ -0,0 +1,148 @@
+package worker
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/criyle/go-judge/envexec"
+)
+
+// RunInfo defines a request currently executing
+type RunInfo struct {
+	ID        string
+	RequestID string
+	Args      [][]string
+	Elapsed   time.Duration
+
+	// Usage is the resource usage of each running cmd last sampled by its
+	// waiter (nil if the cmd is not running)
+	Usage []*envexec.Usage
+}
+
+// inflightRun tracks the processes and cancels an executing request
+type inflightRun struct {
+	id        string
+	requestID string
+	args      [][]string
+	start     time.Time
+	cancel    context.CancelFunc
+
+	mu    sync.Mutex
+	usage []*envexec.Usage
+}
+
+// inflight records executing requests by run id
+type inflight struct {
+	mu   sync.Mutex
+	runs map[string]*inflightRun
+	next uint64
+}
+
+func (f *inflight) add(req *Request, cancel context.CancelFunc) *inflightRun {
+	args := make([][]string, 0, len(req.Cmd))
+	for _, c := range req.Cmd {
+		args = append(args, c.Args)
+	}
+	r := &inflightRun{
+		id:        strconv.FormatUint(atomic.AddUint64(&f.next, 1), 10),
+		requestID: req.RequestID,
+		args:      args,
+		start:     time.Now(),
+		cancel:    cancel,
+		usage:     make([]*envexec.Usage, len(req.Cmd)),
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.runs == nil {
+		f.runs = make(map[string]*inflightRun)
+	}
+	f.runs[r.id] = r
+	return r
+}
+
+func (f *inflight) remove(r *inflightRun) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.runs, r.id)
+}
+
+func (f *inflight) list() []RunInfo {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	rt := make([]RunInfo, 0, len(f.runs))
+	for _, r := range f.runs {
+		rt = append(rt, r.info())
+	}
+	return rt
+}
+
+func (f *inflight) kill(id string) bool {
+	f.mu.Lock()
+	r, ok := f.runs[id]
+	f.mu.Unlock()
+	if ok {
+		r.cancel()
+	}
+	return ok
+}
+
+func (r *inflightRun) info() RunInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// the usage is not sampled here since the sampled process checks its
+	// limits (e.g. syscall rate) each time sampled
+	usage := append([]*envexec.Usage(nil), r.usage...)
+	return RunInfo{
+		ID:        r.id,
+		RequestID: r.requestID,
+		Args:      r.args,
+		Elapsed:   time.Since(r.start),
+		Usage:     usage,
+	}
+}
+
+// trackWaiter records the usage of the i-th cmd sampled by the waiter while
+// it is running
+func (r *inflightRun) trackWaiter(i int, wait func(context.Context, envexec.Process) bool) func(context.Context, envexec.Process) bool {
+	if r == nil || i >= len(r.usage) {
+		return wait
+	}
+	return func(ctx context.Context, p envexec.Process) bool {
+		r.setUsage(i, &envexec.Usage{})
+		defer r.setUsage(i, nil)
+		return wait(ctx, &trackedProcess{Process: p, run: r, index: i})
+	}
+}
+
+func (r *inflightRun) setUsage(i int, u *envexec.Usage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.usage[i] = u
+}
+
+// trackedProcess records the usage each time the waiter samples it
+type trackedProcess struct {
+	envexec.Process
+	run   *inflightRun
+	index int
+}
+
+func (p *trackedProcess) Usage() envexec.Usage {
+	u := p.Process.Usage()
+	p.run.setUsage(p.index, &u)
+	return u
+}
+
+// Inflight lists the requests currently executing
+func (w *worker) Inflight() []RunInfo {
+	return w.inflight.list()
+}
+
+// Kill cancels the executing request by its run id, it returns false if not
+// found
+func (w *worker) Kill(id string) bool {
+	return w.inflight.kill(id)
+}
@@ -0,0 +1,79 @@
+package worker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/criyle/go-judge/envexec"
+)
+
+// LimitCap defines the max limits of each cmd (0 unlimited), cmd with limits
+// over the cap is rejected unless Clamp
+type LimitCap struct {
+	CPULimit    time.Duration
+	ClockLimit  time.Duration
+	MemoryLimit envexec.Size
+	StackLimit  envexec.Size
+	ProcLimit   uint64
+
+	// KillGracePeriod is the max wait after the kill signal since the cmd
+	// runs over its clock limit for it
+	KillGracePeriod time.Duration
+
+	// CollectorMax is the max of each pipe collector (including stdin tee)
+	// since the collected output is buffered in memory
+	CollectorMax envexec.Size
+
+	// Clamp reduces the limits over the cap to the cap instead of rejecting
+	Clamp bool
+}
+
+// apply checks the limits of copies of the cmds against the cap, it returns
+// the names of clamped limits of each cmd in clamp mode
+func (l LimitCap) apply(cmd []Cmd) ([]Cmd, [][]string, error) {
+	rt := make([]Cmd, 0, len(cmd))
+	clamped := make([][]string, len(cmd))
+	for i, c := range cmd {
+		var err error
+		check := func(name string, over bool, clamp func()) {
+			if !over || err != nil {
+				return
+			}
+			if !l.Clamp {
+				err = reject(RejectLimitExceeded, fmt.Sprintf("cmd[%d].%s", i, name), "cmd %d: %s exceeded the max limit", i, name)
+				return
+			}
+			clamp()
+			clamped[i] = append(clamped[i], name)
+		}
+		check("cpuLimit", l.CPULimit > 0 && c.CPULimit > l.CPULimit, func() { c.CPULimit = l.CPULimit })
+		check("clockLimit", l.ClockLimit > 0 && c.ClockLimit > l.ClockLimit, func() { c.ClockLimit = l.ClockLimit })
+		check("memoryLimit", l.MemoryLimit > 0 && c.MemoryLimit > l.MemoryLimit, func() { c.MemoryLimit = l.MemoryLimit })
+		check("stackLimit", l.StackLimit > 0 && c.StackLimit > l.StackLimit, func() { c.StackLimit = l.StackLimit })
+		check("procLimit", l.ProcLimit > 0 && c.ProcLimit > l.ProcLimit, func() { c.ProcLimit = l.ProcLimit })
+		if g := l.KillGracePeriod; g > 0 {
+			check("killGracePeriod", c.KillGracePeriod > g, func() { c.KillGracePeriod = g })
+			// the unset grace period is 1s by envexec
+			if c.KillGracePeriod == 0 && g < time.Second {
+				c.KillGracePeriod = g
+			}
+		}
+		if m := int64(l.CollectorMax); m > 0 {
+			// the files and collectors are shared with the request
+			c.Files = append([]CmdFile(nil), c.Files...)
+			for j, f := range c.Files {
+				if pc, ok := f.(*PipeCollector); ok {
+					check(fmt.Sprintf("files[%d].max", j), pc.Max > m, func() { c.Files[j] = pc.withMax(m) })
+				}
+			}
+			if t := c.StdinTee; t != nil {
+				check("stdinTee.max", t.Max > m, func() { c.StdinTee = t.withMax(m) })
+			}
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		rt = append(rt, c)
+	}
+	return rt, clamped, nil
+}
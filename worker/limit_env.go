@@ -0,0 +1,30 @@
+package worker
+
+import (
+	"strconv"
+	"time"
+)
+
+// LimitEnv names the env exposing the time limits of cmd to the program in
+// milliseconds (empty names are not injected), the limits are informative
+// only and reflect the limits enforced (after clamp)
+type LimitEnv struct {
+	CPU   string // cpu time limit (e.g. TIME_LIMIT_MS)
+	Clock string // clock time limit, at least the cpu time limit
+}
+
+// env returns the env of the limits of the cmd
+func (e LimitEnv) env(rc Cmd) []string {
+	var env []string
+	if e.CPU != "" {
+		env = append(env, e.CPU+"="+formatMillis(rc.CPULimit))
+	}
+	if e.Clock != "" {
+		env = append(env, e.Clock+"="+formatMillis(clockLimit(rc)))
+	}
+	return env
+}
+
+func formatMillis(d time.Duration) string {
+	return strconv.FormatInt(d.Milliseconds(), 10)
+}
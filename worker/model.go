@@ -14,20 +14,133 @@ type Cmd struct {
 	Files []CmdFile
 	TTY   bool
 
+	// Secrets are injected as env variables, their values are redacted in
+	// logs, debug info and results
+	Secrets Secrets
+
+	// EnvFile names a copy in file parsed as KEY=VALUE lines merged into env
+	// (env defined in Env takes precedence)
+	EnvFile string
+
+	// Profile selects the server side profile (empty uses default profile)
+	Profile string
+
 	CPULimit          time.Duration
 	ClockLimit        time.Duration
 	MemoryLimit       envexec.Size
+	MemorySoftLimit   envexec.Size
 	StackLimit        envexec.Size
 	ProcLimit         uint64
 	CPURateLimit      float64
 	StrictMemoryLimit bool
 
+	SyscallRateLimit  uint64
+	KillOnSyscallRate bool
+
+	// WriteLimit kills the program once cumulative write bytes exceeded
+	WriteLimit envexec.Size
+
+	// FileLimit kills the program once number of created files in the work
+	// dir exceeded
+	FileLimit uint64
+
+	// SocketLimit kills the program once number of sockets held exceeded,
+	// OpenFileLimit is the RLIMIT_NOFILE bounds sockets between samples
+	SocketLimit   uint64
+	OpenFileLimit uint64
+
+	// NprocLimit is the RLIMIT_NPROC checked on fork / clone besides the
+	// cgroup ProcLimit, 0 uses ProcLimit if NprocFromProcLimit of the server
+	NprocLimit uint64
+
+	// FreshCgroup requests a newly built cgroup instead of the pooled one
+	FreshCgroup bool
+
+	// MemoryAccounting reports and limits the peak (default) or the current
+	// memory at exit
+	MemoryAccounting envexec.MemoryAccounting
+
+	// TimeAccounting reports and limits the cpu time of the cgroup (default)
+	// or the user time of the process itself
+	TimeAccounting envexec.TimeAccounting
+
+	// InstructionLimit kills the program once user space instructions
+	// retired exceeded (requires instruction counter enabled)
+	InstructionLimit uint64
+
+	// Nice is the scheduling nice value (0 default to 19 lowest priority),
+	// bounded below by the server MinNice
+	Nice int
+
+	// StdoutBuffering runs the program with stdout line buffered or
+	// unbuffered by the stdbuf library (requires the server configured one)
+	StdoutBuffering StdoutBuffering
+
+	// EarlyResultQuietPeriod terminates the program once it closed all pipe
+	// collectors and the quiet period passed (0 disables)
+	EarlyResultQuietPeriod time.Duration
+
+	// StdinTee collects the input delivered to stdin as a copy out file
+	// within its max (e.g. interaction transcript), not for TTY
+	StdinTee *PipeCollector
+
+	// FileSizeLimit limits the size of any single file written (RLIMIT_FSIZE)
+	FileSizeLimit envexec.Size
+
+	// KillSignal is sent once time limit exceeded (0 kills immediately)
+	// and kills after KillGracePeriod
+	KillSignal      int
+	KillGracePeriod time.Duration
+
 	CopyIn map[string]CmdFile
 
+	// CleanWorkDir removes the files in the work dir of the reused
+	// environment before copy in instead of overwriting existing files
+	CleanWorkDir bool
+
+	// Groups are the supplementary group IDs of the program, each must be
+	// allowed by the server AllowedGroups (gVisor sandbox only)
+	Groups []int
+
+	// CountSyscalls reports the syscall counts of the program in the debug
+	// info, the request must be debug enabled
+	CountSyscalls bool
+
 	CopyOut       []string
 	CopyOutCached []string
 	CopyOutMax    uint64
 	CopyOutDir    string
+
+	// CopyOutDirs collects regular files inside the dirs in the container as
+	// copy out files named with prefix
+	CopyOutDirs []CopyOutDir
+
+	// CopyOutPartial omits copy out files exceeded CopyOutMax (reported by
+	// OmittedFiles) instead of failing the cmd
+	CopyOutPartial bool
+
+	// CopyOutTotalMax is the max total size of the copy out files (0 uses
+	// the server CopyOutTotalLimit), pipe collector outputs are counted
+	// against it if CopyOutIncludePipes
+	CopyOutTotalMax     uint64
+	CopyOutIncludePipes bool
+
+	// PostExec runs in the same environment after the program exited
+	// regardless of its status (e.g. teardown)
+	PostExec []string
+
+	// Cache reuses the copy out files of the previous accepted run with the
+	// same normalized cmd (e.g. compile) if the compile cache is enabled
+	Cache bool
+
+	preserveID string // preserves work dir on failure if set
+}
+
+// CopyOutDir defines a dir in the container to collect files, collected files
+// are named with Prefix + file name
+type CopyOutDir struct {
+	Src    string
+	Prefix string
 }
 
 // PipeIndex defines indexing for a pipe fd
@@ -47,6 +160,62 @@ type Request struct {
 	RequestID   string
 	Cmd         []Cmd
 	PipeMapping []PipeMap
+
+	// Tags labels the request for metrics
+	Tags map[string]string
+
+	// Debug includes the resolved invocation in results (if enabled)
+	Debug bool
+
+	// Seed is injected as JUDGE_SEED env into each cmd (optional), the server
+	// generates one if GenerateSeed is set without Seed
+	Seed         *uint64
+	GenerateSeed bool
+
+	// Checker runs after all cmds accepted and decides the verdict (optional)
+	Checker *Checker
+
+	// Compare compares the output with the answer after all cmds accepted
+	// and decides the verdict instead of the checker (optional)
+	Compare *Compare
+
+	// PreserveOnFailure preserves the work dir of programs not accepted under
+	// the preserve dir (if enabled)
+	PreserveOnFailure bool
+
+	// Image selects the environment pool of the named mount configuration
+	// (empty uses the default)
+	Image string
+
+	// WarmOnly fails fast as busy (retryable) if no warm environment of the
+	// image is pooled instead of building one, the checker is not affected
+	WarmOnly bool
+
+	// ReturnFields selects the result fields to return (empty returns all),
+	// copy out files are not collected if neither files nor fileIds selected
+	ReturnFields []string
+
+	// ClockBudget is the wall time shared by all cmds (e.g. total interaction
+	// time), exceeded kills the cmds still running (0 disables)
+	ClockBudget time.Duration
+
+	// AcquireTimeout bounds the wall time since the request accepted (queue
+	// included) until the environments of the cmds are acquired, exceeded
+	// fails the cmds as QueueTimeout without running (0 disables)
+	AcquireTimeout time.Duration
+
+	// Inputs runs the single cmd against each input in order in the same
+	// environment reusing its copy in files (e.g. the binary), results are
+	// indexed by the inputs (optional)
+	Inputs []SequenceInput
+
+	// StopOnFailure skips the rest inputs once the result of an input is
+	// not accepted (with Inputs)
+	StopOnFailure bool
+
+	// ResultCallback receives the cmd index and its result as soon as each cmd
+	// finishes (optional), it may be called from multiple goroutines
+	ResultCallback func(int, Result)
 }
 
 // Result defines single command response
@@ -59,6 +228,82 @@ type Result struct {
 	Memory     envexec.Size
 	Files      map[string][]byte
 	FileIDs    map[string]string
+
+	// ExitReason reports why the program exited (e.g. killed by peer)
+	ExitReason envexec.ExitReason
+
+	EnvironmentTime time.Duration
+	CgroupTime      time.Duration
+
+	// MemoryPeak is the raw peak memory if Memory is estimated excluding
+	// page cache
+	MemoryPeak envexec.Size
+
+	// SwapUsage is the max sampled swap usage of the cgroup if swap is allowed
+	SwapUsage envexec.Size
+
+	// UserTime / SystemTime split the cpu time (cgroup enabled)
+	UserTime   time.Duration
+	SystemTime time.Duration
+
+	// MemoryWarning is true if memory exceeded the soft limit
+	MemoryWarning bool
+
+	// EarlyResult is true if the program was terminated after it closed the
+	// outputs (EarlyResultQuietPeriod)
+	EarlyResult bool
+
+	// ReapTimeout is true if the program was not reaped within the reap
+	// deadline after killed, its environment is destroyed
+	ReapTimeout bool
+
+	// Retries is the number of retries because of internal error
+	Retries int
+
+	// ProcPeak is the sampled peak number of processes / threads
+	ProcPeak uint64
+
+	// SocketPeak is the sampled peak number of sockets (SocketLimit)
+	SocketPeak uint64
+
+	// ProcLimitHit reports which process count limit failed the process /
+	// thread creation of the program
+	ProcLimitHit envexec.ProcLimitHit
+
+	// Instructions is the number of instructions retired (InstructionLimit)
+	Instructions uint64
+
+	// OmittedFiles are the copy out files exceeded the size limit with their
+	// sizes (CopyOutPartial)
+	OmittedFiles map[string]envexec.Size
+
+	// TruncatedFiles are the pipe collectors exceeded max with the bytes the
+	// program wrote (at least)
+	TruncatedFiles map[string]envexec.Size
+
+	// CopyOutBytes and PipeBytes are the collected bytes of the copy out
+	// files and the pipe collectors
+	CopyOutBytes envexec.Size
+	PipeBytes    envexec.Size
+
+	// Warning reports abnormal behavior (e.g. high syscall rate)
+	Warning string
+
+	// Debug is the resolved invocation if requested
+	Debug *DebugInfo
+
+	// CacheHit is true if the result is reused from the compile cache
+	CacheHit bool
+
+	// Preserved is the id of the preserved work dir (if preserved)
+	Preserved string
+
+	// LimitsClamped names the limits reduced to the max limits
+	LimitsClamped []string
+
+	// PostExec is the result of the post exec cmd (if presents), its failure
+	// does not change the status
+	PostExec *envexec.PostExecResult
 }
 
 // Response defines worker response for single request
@@ -66,6 +311,25 @@ type Response struct {
 	RequestID string
 	Results   []Result
 	Error     error
+	Tags      map[string]string
+	Seed      *uint64
+
+	// Checker is the result of the checker (if checker presents)
+	Checker *Result
+	// Verdict is the overall status decided by the checker or the compare
+	// (if presents), or the first status not accepted, or time limit
+	// exceeded if the clock budget exceeded
+	Verdict envexec.Status
+
+	// ClockBudgetExceeded is true if the cmds were killed since the clock
+	// budget of the request exceeded
+	ClockBudgetExceeded bool
+
+	// CheckerComment is the comment of the testlib checker (its stderr)
+	CheckerComment string
+
+	// Compare is the result of the comparison (if compare presents)
+	Compare *CompareResult
 }
 
 func (r Result) String() string {
@@ -78,6 +342,13 @@ func (r Result) String() string {
 		Memory     envexec.Size
 		Files      map[string]string
 		FileIDs    map[string]string
+
+		EnvironmentTime time.Duration
+		CgroupTime      time.Duration
+		MemoryWarning   bool
+		Retries         int
+		ProcPeak        uint64
+		Warning         string
 	}
 	d := Result{
 		Status:     r.Status,
@@ -88,6 +359,13 @@ func (r Result) String() string {
 		Memory:     r.Memory,
 		Files:      make(map[string]string),
 		FileIDs:    r.FileIDs,
+
+		EnvironmentTime: r.EnvironmentTime,
+		CgroupTime:      r.CgroupTime,
+		MemoryWarning:   r.MemoryWarning,
+		Retries:         r.Retries,
+		ProcPeak:        r.ProcPeak,
+		Warning:         r.Warning,
 	}
 	for k, v := range r.Files {
 		d.Files[k] = fmt.Sprintf("(len:%d)", len(v))
@@ -0,0 +1,85 @@
+package worker
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// withPreserve sets the preserve id of copies of the cmds if the request
+// preserves work dirs on failure
+func (w *worker) withPreserve(cmd []Cmd, req *Request) []Cmd {
+	if !req.PreserveOnFailure || !w.enableDebug || w.preserveDir == "" {
+		return cmd
+	}
+	id := preserveName(req.RequestID) + "-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	rt := make([]Cmd, 0, len(cmd))
+	for i, c := range cmd {
+		c.preserveID = id + "/" + strconv.Itoa(i)
+		rt = append(rt, c)
+	}
+	return rt
+}
+
+// releasePreserved removes the preserved work dirs of the response after the
+// preserve timeout
+func (w *worker) releasePreserved(rt Response) {
+	if w.preserveTimeout <= 0 {
+		return
+	}
+	for _, r := range rt.Results {
+		if r.Preserved == "" {
+			continue
+		}
+		dir := filepath.Join(w.preserveDir, filepath.Dir(r.Preserved))
+		time.AfterFunc(w.preserveTimeout, func() {
+			os.RemoveAll(dir)
+		})
+		return
+	}
+}
+
+// sweepPreserved removes the preserved work dirs left by the previous run of
+// the server after the preserve timeout since their modification, the timers
+// of them are lost on restart
+func (w *worker) sweepPreserved() {
+	if w.preserveDir == "" || w.preserveTimeout <= 0 {
+		return
+	}
+	entries, err := os.ReadDir(w.preserveDir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		fi, err := e.Info()
+		if err != nil || !fi.IsDir() {
+			continue
+		}
+		dir := filepath.Join(w.preserveDir, e.Name())
+		d := w.preserveTimeout - time.Since(fi.ModTime())
+		if d <= 0 {
+			os.RemoveAll(dir)
+			continue
+		}
+		time.AfterFunc(d, func() {
+			os.RemoveAll(dir)
+		})
+	}
+}
+
+// preserveName keeps the request id safe as a file name
+func preserveName(requestID string) string {
+	if requestID == "" {
+		return "request"
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, requestID)
+}
@@ -0,0 +1,68 @@
+package worker
+
+// defaultProfile is used when cmd does not specify a profile
+const defaultProfile = "default"
+
+// Profile defines server side configuration applied to cmd selecting it
+type Profile struct {
+	// ArgsPrefix is prepended to the cmd args (e.g. a launcher)
+	ArgsPrefix []string `yaml:"argsPrefix"`
+
+	// GPUDevices are the GPU device nodes (e.g. nvidia0) accessible to the cmd,
+	// they must be enabled by the server
+	GPUDevices []string `yaml:"gpuDevices"`
+
+	// Locale sets LANG and LC_ALL (e.g. C.UTF-8) and Timezone sets TZ
+	// (e.g. UTC) of the cmd, env defined in cmd takes precedence
+	Locale   string `yaml:"locale"`
+	Timezone string `yaml:"timezone"`
+
+	// Capabilities are retained by the cmd (e.g. CAP_NET_BIND_SERVICE), each
+	// must be allowed by the server (gVisor sandbox only)
+	Capabilities []string `yaml:"capabilities"`
+}
+
+// getProfile returns the profile by name, empty name selects the default
+// profile if it exists
+func (w *worker) getProfile(name string) (Profile, error) {
+	if name == "" {
+		return w.profiles[defaultProfile], nil
+	}
+	p, ok := w.profiles[name]
+	if !ok {
+		return Profile{}, reject(RejectInvalidArgument, "profile", "profile %s does not exist", name)
+	}
+	return p, nil
+}
+
+// env returns the locale and timezone env of the profile
+func (p Profile) env() []string {
+	var env []string
+	if p.Locale != "" {
+		env = append(env, "LANG="+p.Locale, "LC_ALL="+p.Locale)
+	}
+	if p.Timezone != "" {
+		env = append(env, "TZ="+p.Timezone)
+	}
+	return env
+}
+
+// checkCapabilities ensures the capabilities of the profile are allowed
+func (w *worker) checkCapabilities(p Profile) error {
+	for _, c := range p.Capabilities {
+		if !w.allowedCapabilities[c] {
+			return reject(RejectNotAllowed, "profile", "capability %s is not allowed", c)
+		}
+	}
+	return nil
+}
+
+// checkGPUDevices ensures the GPU devices of the profile are enabled
+func (w *worker) checkGPUDevices(p Profile) error {
+	for _, d := range p.GPUDevices {
+		if !w.gpuDevices[d] {
+			return reject(RejectNotAllowed, "profile", "gpu device %s is not enabled", d)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+)
+
+// RejectCode is the stable machine readable reason of the rejected request
+type RejectCode string
+
+// Defines reject codes
+const (
+	RejectInvalidArgument RejectCode = "INVALID_ARGUMENT" // malformed field
+	RejectLimitExceeded   RejectCode = "LIMIT_EXCEEDED"   // over the server limits
+	RejectFileNotFound    RejectCode = "FILE_NOT_FOUND"   // file id does not exist
+	RejectNotAllowed      RejectCode = "NOT_ALLOWED"      // not enabled by the server
+	RejectBusy            RejectCode = "BUSY"             // queue is full
+)
+
+// RejectError is the response error if the request is rejected before it is
+// executed, Field is the path of the field rejected (e.g. cmd[0].memoryLimit)
+type RejectError struct {
+	Code  RejectCode
+	Field string
+	Err   error
+}
+
+func (e *RejectError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RejectError) Unwrap() error {
+	return e.Err
+}
+
+// reject creates RejectError with formatted message
+func reject(code RejectCode, field string, format string, args ...interface{}) error {
+	return &RejectError{Code: code, Field: field, Err: fmt.Errorf(format, args...)}
+}
+
+// withFieldPrefix prefixes the field path of the RejectError (e.g. cmd[1])
+func withFieldPrefix(err error, prefix string) error {
+	var re *RejectError
+	if !errors.As(err, &re) {
+		return err
+	}
+	field := prefix
+	if re.Field != "" {
+		field += "." + re.Field
+	}
+	return &RejectError{Code: re.Code, Field: field, Err: re.Err}
+}
+
+// Rejection returns the RejectError if the request was rejected before
+// execution (including BusyError as RejectBusy)
+func Rejection(err error) (*RejectError, bool) {
+	var re *RejectError
+	if errors.As(err, &re) {
+		return re, true
+	}
+	var be *BusyError
+	if errors.As(err, &be) {
+		return &RejectError{Code: RejectBusy, Err: be}, true
+	}
+	return nil, false
+}
+
+// checkPipeMapping ensures the pipe mapping refers to existing cmds and fds
+// not occupied by the cmd files
+func checkPipeMapping(req *Request) error {
+	check := func(field string, p PipeIndex) error {
+		if p.Index < 0 || p.Index >= len(req.Cmd) {
+			return reject(RejectInvalidArgument, field+".index", "pipe index out of range %d", p.Index)
+		}
+		files := req.Cmd[p.Index].Files
+		if p.Fd < 0 {
+			return reject(RejectInvalidArgument, field+".fd", "pipe fd out of range %d", p.Fd)
+		}
+		if p.Fd < len(files) && files[p.Fd] != nil {
+			return reject(RejectInvalidArgument, field+".fd", "pipe fd have been occupied %d %d", p.Index, p.Fd)
+		}
+		return nil
+	}
+	for i, p := range req.PipeMapping {
+		if err := check(fmt.Sprintf("pipeMapping[%d].in", i), p.In); err != nil {
+			return err
+		}
+		if err := check(fmt.Sprintf("pipeMapping[%d].out", i), p.Out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
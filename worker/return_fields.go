@@ -0,0 +1,226 @@
+package worker
+
+import (
+	"strings"
+
+	"github.com/criyle/go-judge/filestore"
+)
+
+// returnFields defines the set of result fields to return, nil returns all
+type returnFields map[string]bool
+
+var resultFieldNames = []string{
+	"status", "exitStatus", "error", "time", "runTime", "memory", "files",
+	"fileIds", "environmentTime", "cgroupTime", "memoryWarning", "retries",
+	"procPeak", "warning", "debug", "cacheHit", "preserved", "limitsClamped",
+	"postExec", "exitReason", "earlyResult",
+	"reapTimeout", "instructions", "omittedFiles",
+	"socketPeak", "procLimitHit", "copyOutBytes", "pipeBytes",
+	"truncatedFiles",
+}
+
+func newReturnFields(f []string) (returnFields, error) {
+	if len(f) == 0 {
+		return nil, nil
+	}
+	valid := make(map[string]bool, len(resultFieldNames))
+	for _, n := range resultFieldNames {
+		valid[n] = true
+	}
+	rt := make(returnFields, len(f))
+	for _, n := range f {
+		if !valid[n] {
+			return nil, reject(RejectInvalidArgument, "returnFields", "return field %s is not valid", n)
+		}
+		rt[n] = true
+	}
+	return rt, nil
+}
+
+func (f returnFields) has(n string) bool {
+	return f == nil || f[n]
+}
+
+// judgedFiles returns the result file names of each cmd read by the compare
+// or the checker of the request
+func judgedFiles(req *Request) map[int]map[string]bool {
+	rt := make(map[int]map[string]bool)
+	add := func(i int, name string) {
+		if rt[i] == nil {
+			rt[i] = make(map[string]bool)
+		}
+		rt[i][name] = true
+	}
+	if req.Compare != nil {
+		add(req.Compare.Index, req.Compare.Name)
+	}
+	if req.Checker != nil {
+		for _, in := range req.Checker.Inputs {
+			add(in.Index, in.Name)
+		}
+	}
+	return rt
+}
+
+// prepareCmd drops the copy out of the cmd if files are not returned and
+// the cached copy out if file ids are not returned (otherwise stored but
+// unreachable), the files judged are kept and cleared by filter, pipe
+// collectors are kept to drain the outputs
+func (f returnFields) prepareCmd(rc []Cmd, judged map[int]map[string]bool) []Cmd {
+	files, fileIDs := f.has("files"), f.has("fileIds")
+	if files && fileIDs {
+		return rc
+	}
+	rt := make([]Cmd, 0, len(rc))
+	for i, c := range rc {
+		keep := judged[i]
+		if !files {
+			c.CopyOut = keptNames(c.CopyOut, keep)
+			var dirs []CopyOutDir
+			for _, d := range c.CopyOutDirs {
+				if keptPrefix(d.Prefix, keep) {
+					dirs = append(dirs, d)
+				}
+			}
+			c.CopyOutDirs = dirs
+		}
+		if !fileIDs {
+			c.CopyOutCached = keptNames(c.CopyOutCached, keep)
+		}
+		rt = append(rt, c)
+	}
+	return rt
+}
+
+func keptNames(names []string, keep map[string]bool) []string {
+	var rt []string
+	for _, n := range names {
+		if keep[n] {
+			rt = append(rt, n)
+		}
+	}
+	return rt
+}
+
+// keptPrefix returns whether any of the kept names is inside the copy out
+// dir named with the prefix
+func keptPrefix(prefix string, keep map[string]bool) bool {
+	for n := range keep {
+		if strings.HasPrefix(n, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeUnreturned removes the cached copy out kept for the checker once
+// judged if file ids are not returned
+func (f returnFields) removeUnreturned(fs filestore.FileStore, rt Response) {
+	if f.has("fileIds") {
+		return
+	}
+	for _, r := range rt.Results {
+		for _, id := range r.FileIDs {
+			fs.Remove(id)
+		}
+	}
+}
+
+// filter clears the fields not requested, status is always returned
+func (f returnFields) filter(r Result) Result {
+	if f == nil {
+		return r
+	}
+	rt := Result{
+		Status: r.Status,
+	}
+	if f["exitStatus"] {
+		rt.ExitStatus = r.ExitStatus
+	}
+	if f["error"] {
+		rt.Error = r.Error
+	}
+	if f["time"] {
+		rt.Time = r.Time
+		rt.UserTime = r.UserTime
+		rt.SystemTime = r.SystemTime
+	}
+	if f["runTime"] {
+		rt.RunTime = r.RunTime
+	}
+	if f["memory"] {
+		rt.Memory = r.Memory
+		rt.MemoryPeak = r.MemoryPeak
+		rt.SwapUsage = r.SwapUsage
+	}
+	if f["files"] {
+		rt.Files = r.Files
+	}
+	if f["fileIds"] {
+		rt.FileIDs = r.FileIDs
+	}
+	if f["environmentTime"] {
+		rt.EnvironmentTime = r.EnvironmentTime
+	}
+	if f["cgroupTime"] {
+		rt.CgroupTime = r.CgroupTime
+	}
+	if f["memoryWarning"] {
+		rt.MemoryWarning = r.MemoryWarning
+	}
+	if f["retries"] {
+		rt.Retries = r.Retries
+	}
+	if f["procPeak"] {
+		rt.ProcPeak = r.ProcPeak
+	}
+	if f["warning"] {
+		rt.Warning = r.Warning
+	}
+	if f["debug"] {
+		rt.Debug = r.Debug
+	}
+	if f["cacheHit"] {
+		rt.CacheHit = r.CacheHit
+	}
+	if f["preserved"] {
+		rt.Preserved = r.Preserved
+	}
+	if f["limitsClamped"] {
+		rt.LimitsClamped = r.LimitsClamped
+	}
+	if f["postExec"] {
+		rt.PostExec = r.PostExec
+	}
+	if f["exitReason"] {
+		rt.ExitReason = r.ExitReason
+	}
+	if f["earlyResult"] {
+		rt.EarlyResult = r.EarlyResult
+	}
+	if f["reapTimeout"] {
+		rt.ReapTimeout = r.ReapTimeout
+	}
+	if f["instructions"] {
+		rt.Instructions = r.Instructions
+	}
+	if f["omittedFiles"] {
+		rt.OmittedFiles = r.OmittedFiles
+	}
+	if f["truncatedFiles"] {
+		rt.TruncatedFiles = r.TruncatedFiles
+	}
+	if f["socketPeak"] {
+		rt.SocketPeak = r.SocketPeak
+	}
+	if f["procLimitHit"] {
+		rt.ProcLimitHit = r.ProcLimitHit
+	}
+	if f["copyOutBytes"] {
+		rt.CopyOutBytes = r.CopyOutBytes
+	}
+	if f["pipeBytes"] {
+		rt.PipeBytes = r.PipeBytes
+	}
+	return rt
+}
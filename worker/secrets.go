@@ -0,0 +1,88 @@
+package worker
+
+import (
+	"sort"
+	"strings"
+)
+
+// redacted replaces the secret values in logs, debug info and results
+const redacted = "***"
+
+// Secrets defines env variables injected into the cmd, their values are
+// redacted when printed and from the debug info, args and error of the result
+type Secrets map[string]string
+
+// String prints the secret names only
+func (s Secrets) String() string {
+	var b strings.Builder
+	b.WriteString("map[")
+	for i, k := range s.keys() {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(k + ":" + redacted)
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+// GoString prints the secret names only
+func (s Secrets) GoString() string {
+	return s.String()
+}
+
+func (s Secrets) keys() []string {
+	rt := make([]string, 0, len(s))
+	for k := range s {
+		rt = append(rt, k)
+	}
+	sort.Strings(rt)
+	return rt
+}
+
+// env returns the secrets as env variables
+func (s Secrets) env() []string {
+	rt := make([]string, 0, len(s))
+	for _, k := range s.keys() {
+		rt = append(rt, k+"="+s[k])
+	}
+	return rt
+}
+
+// redact replaces the secret values inside the string
+func (s Secrets) redact(str string) string {
+	for _, v := range s {
+		if v != "" {
+			str = strings.ReplaceAll(str, v, redacted)
+		}
+	}
+	return str
+}
+
+// redactArgs returns copy of the args with secret values replaced
+func (s Secrets) redactArgs(args []string) []string {
+	if len(s) == 0 {
+		return args
+	}
+	rt := make([]string, 0, len(args))
+	for _, a := range args {
+		rt = append(rt, s.redact(a))
+	}
+	return rt
+}
+
+// redactEnv returns copy of the env with secret variables replaced
+func (s Secrets) redactEnv(env []string) []string {
+	if len(s) == 0 {
+		return env
+	}
+	rt := make([]string, 0, len(env))
+	for _, e := range env {
+		kv := strings.SplitN(e, "=", 2)
+		if _, ok := s[kv[0]]; ok {
+			e = kv[0] + "=" + redacted
+		}
+		rt = append(rt, s.redact(e))
+	}
+	return rt
+}
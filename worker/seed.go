@@ -0,0 +1,38 @@
+package worker
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"strconv"
+)
+
+// seedEnv is the env variable of the seed injected into each cmd
+const seedEnv = "JUDGE_SEED"
+
+// requestSeed returns the seed of the request, it generates one if requested
+func requestSeed(req *Request) (*uint64, error) {
+	if req.Seed != nil || !req.GenerateSeed {
+		return req.Seed, nil
+	}
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return nil, err
+	}
+	// keep 53 bits so that the seed is exact as JSON number
+	s := binary.LittleEndian.Uint64(b[:]) & (1<<53 - 1)
+	return &s, nil
+}
+
+// withSeed injects the seed env into copies of the cmds
+func withSeed(cmd []Cmd, seed *uint64) []Cmd {
+	if seed == nil {
+		return cmd
+	}
+	e := seedEnv + "=" + strconv.FormatUint(*seed, 10)
+	rt := make([]Cmd, 0, len(cmd))
+	for _, c := range cmd {
+		c.Env = append(append(make([]string, 0, len(c.Env)+1), c.Env...), e)
+		rt = append(rt, c)
+	}
+	return rt
+}
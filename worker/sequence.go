@@ -0,0 +1,121 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/criyle/go-judge/envexec"
+)
+
+// SequenceInput defines an input of the cmd run in sequence, Stdin replaces
+// the stdin of the cmd (if presents) and CopyIn is copied in for the run only
+type SequenceInput struct {
+	Stdin  CmdFile
+	CopyIn map[string]CmdFile
+}
+
+// checkInputs ensures the request with inputs runs a single cmd on its own
+func checkInputs(req *Request) error {
+	if len(req.Inputs) == 0 {
+		if req.StopOnFailure {
+			return reject(RejectInvalidArgument, "stopOnFailure", "stopOnFailure requires inputs")
+		}
+		return nil
+	}
+	switch {
+	case len(req.Cmd) != 1:
+		return reject(RejectInvalidArgument, "inputs", "inputs requires exactly one cmd")
+	case len(req.PipeMapping) > 0:
+		return reject(RejectInvalidArgument, "inputs", "inputs is not supported with pipe mapping")
+	case req.Checker != nil:
+		return reject(RejectInvalidArgument, "inputs", "inputs is not supported with checker")
+	case req.ClockBudget > 0:
+		return reject(RejectInvalidArgument, "inputs", "inputs is not supported with clock budget")
+	case req.Cmd[0].TTY:
+		return reject(RejectInvalidArgument, "inputs", "inputs is not supported with tty")
+	}
+	return nil
+}
+
+// sequenceCmds creates the cmd for each input, the copy in files of the cmd
+// are only copied in by the first run and kept for the rest
+func sequenceCmds(rc Cmd, inputs []SequenceInput) ([]Cmd, []string) {
+	keep := make([]string, 0, len(rc.CopyIn))
+	for n := range rc.CopyIn {
+		keep = append(keep, n)
+	}
+	rt := make([]Cmd, 0, len(inputs))
+	for i, in := range inputs {
+		c := rc
+		c.CopyIn = make(map[string]CmdFile, len(in.CopyIn))
+		// the later runs clean the work dir except the kept files
+		c.CleanWorkDir = rc.CleanWorkDir && i == 0
+		if i == 0 {
+			for k, v := range rc.CopyIn {
+				c.CopyIn[k] = v
+			}
+		}
+		for k, v := range in.CopyIn {
+			c.CopyIn[k] = v
+		}
+		if in.Stdin != nil {
+			c.Files = append([]CmdFile(nil), rc.Files...)
+			if len(c.Files) == 0 {
+				c.Files = append(c.Files, nil)
+			}
+			c.Files[0] = in.Stdin
+		}
+		rt = append(rt, c)
+	}
+	return rt, keep
+}
+
+// workDoSequence runs the cmd against each input in order in the same
+// environment, the work dir is cleaned between runs except the copy in files
+// of the cmd (e.g. the binary)
+func (w *worker) workDoSequence(ctx context.Context, envPool envexec.EnvironmentPool, rc Cmd, inputs []SequenceInput, stopOnFailure, debug bool, run *inflightRun, cb func(int, Result), stage func(int, envexec.Stage)) (rt Response) {
+	rcs, keep := sequenceCmds(rc, inputs)
+	cs := make([]*envexec.Cmd, 0, len(rcs))
+	copyOutSets := make([]map[string]bool, 0, len(rcs))
+	for i, cc := range rcs {
+		c, os, err := w.prepareCmd(cc)
+		if err != nil {
+			rt.Error = withFieldPrefix(err, fmt.Sprintf("inputs[%d]", i))
+			return
+		}
+		c.Waiter = run.trackWaiter(0, c.Waiter)
+		c.SampleOpenFiles = w.debugEnabled(debug)
+		c.ReportCgroup = w.debugEnabled(debug)
+		if stage != nil {
+			i := i
+			c.StageObserver = func(s envexec.Stage) { stage(i, s) }
+		}
+		cs = append(cs, c)
+		copyOutSets = append(copyOutSets, os)
+	}
+	rts := make([]Result, len(cs))
+	s := envexec.Sequence{
+		EnvironmentPool: envPool,
+		Cmd:             cs,
+		Keep:            keep,
+		StopOnFailure:   stopOnFailure,
+
+		ResultCallback: func(i int, result envexec.Result) {
+			rts[i] = w.convertResult(result, copyOutSets[i])
+			rts[i].Error = rcs[i].Secrets.redact(rts[i].Error)
+			rts[i].Debug = w.debugInfo(debug, rcs[i], cs[i], result)
+			if result.Preserved {
+				rts[i].Preserved = rcs[i].preserveID
+			}
+			if cb != nil {
+				cb(i, rts[i])
+			}
+		},
+	}
+	if _, err := s.Run(ctx); err != nil {
+		rt.Error = err
+		return
+	}
+	rt.Results = rts
+	return
+}
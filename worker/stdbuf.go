@@ -0,0 +1,56 @@
+package worker
+
+import "strings"
+
+// StdoutBuffering defines the stdout buffering mode of the program applied
+// by the preloaded stdbuf shim (libstdbuf.so of coreutils), so that the
+// output printed before killed is not lost in the libc buffer
+type StdoutBuffering int
+
+// StdoutBuffering modes
+const (
+	StdoutBufferingDefault StdoutBuffering = iota // libc default (full buffered for pipes)
+	StdoutBufferingLine                           // flushed on each line
+	StdoutBufferingNone                           // unbuffered
+)
+
+var stdoutBufferingToString = []string{
+	"",
+	"line",
+	"none",
+}
+
+func (b StdoutBuffering) String() string {
+	bi := int(b)
+	if bi < 0 || bi >= len(stdoutBufferingToString) {
+		return stdoutBufferingToString[0]
+	}
+	return stdoutBufferingToString[bi]
+}
+
+// stdbufEnv is the env read by libstdbuf.so for stdout buffering
+const stdbufEnv = "_STDBUF_O"
+
+// withStdbuf preloads the stdbuf library (prepended to LD_PRELOAD if the
+// cmd defined one) with the buffering mode of stdout, it only applies to
+// dynamically linked programs using libc stdio
+func withStdbuf(env []string, lib string, b StdoutBuffering) []string {
+	mode := "L"
+	if b == StdoutBufferingNone {
+		mode = "0"
+	}
+	preload := "LD_PRELOAD=" + lib
+	rt := make([]string, 0, len(env)+2)
+	for _, e := range env {
+		switch {
+		case strings.HasPrefix(e, "LD_PRELOAD="):
+			if v := strings.TrimPrefix(e, "LD_PRELOAD="); v != "" {
+				preload += ":" + v
+			}
+		case strings.HasPrefix(e, stdbufEnv+"="):
+		default:
+			rt = append(rt, e)
+		}
+	}
+	return append(rt, preload, stdbufEnv+"="+mode)
+}
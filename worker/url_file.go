@@ -0,0 +1,249 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/criyle/go-judge/envexec"
+	"github.com/criyle/go-judge/file"
+	"github.com/criyle/go-judge/filestore"
+)
+
+const maxURLRedirects = 5
+
+var _ CmdFile = &URLFile{}
+
+// URLFile defines file fetched by the server from an allow-listed http(s) url
+type URLFile struct {
+	URL string
+}
+
+// EnvFile prepares file for envexec file, url file is only available through
+// the worker with url fetching enabled
+func (f *URLFile) EnvFile(fs filestore.FileStore) (interface{}, error) {
+	return nil, fmt.Errorf("url file is not enabled: %s", f.URL)
+}
+
+func (f *URLFile) String() string {
+	return fmt.Sprintf("url:%s", f.URL)
+}
+
+// privateNetworks defines address ranges that are not reachable by url fetcher
+var privateNetworks = mustParseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"224.0.0.0/4",
+	"240.0.0.0/4",
+	"::/128",
+	"::1/128",
+	"fc00::/7",
+	"fe80::/10",
+	"ff00::/8",
+)
+
+// urlFetcher fetches url file with restricted host, size and time
+type urlFetcher struct {
+	allowHosts []string
+	maxSize    int64
+	timeout    time.Duration
+	client     *http.Client
+}
+
+func newURLFetcher(allowHosts []string, timeout time.Duration, maxSize envexec.Size, allowPrivate bool) *urlFetcher {
+	f := &urlFetcher{
+		allowHosts: allowHosts,
+		maxSize:    int64(maxSize),
+		timeout:    timeout,
+	}
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		// checked after name resolution to avoid dns rebinding
+		dialer.Control = denyPrivateAddress
+	}
+	f.client = &http.Client{
+		Transport: &http.Transport{
+			// proxy is not used to ensure the address check applies
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   timeout,
+			ResponseHeaderTimeout: timeout,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxURLRedirects {
+				return fmt.Errorf("url file: stopped after %d redirects", maxURLRedirects)
+			}
+			return f.checkURL(req.URL)
+		},
+	}
+	return f
+}
+
+// checkURL ensures the url is http(s) and its host is allowed
+func (f *urlFetcher) checkURL(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("url file: scheme %q is not allowed", u.Scheme)
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, h := range f.allowHosts {
+		h = strings.ToLower(h)
+		if host == h || (strings.HasPrefix(h, "*.") && strings.HasSuffix(host, h[1:])) {
+			return nil
+		}
+	}
+	return fmt.Errorf("url file: host %q is not allowed", host)
+}
+
+// file creates file that fetches the url content when read
+func (f *urlFetcher) file(uf *URLFile) (file.File, error) {
+	u, err := url.Parse(uf.URL)
+	if err != nil {
+		return nil, fmt.Errorf("url file: %v", err)
+	}
+	if err := f.checkURL(u); err != nil {
+		return nil, err
+	}
+	return &urlFile{url: u.String(), fetcher: f}, nil
+}
+
+// fetch starts the request and returns body limited by max size
+func (f *urlFetcher) fetch(u string) (io.ReadCloser, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), f.timeout)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		cancel()
+		return nil, fmt.Errorf("url file: %s returned %s", u, resp.Status)
+	}
+	if f.maxSize > 0 && resp.ContentLength > f.maxSize {
+		resp.Body.Close()
+		cancel()
+		return nil, fmt.Errorf("url file: %s have size (%d) exceeded the limit (%d)", u, resp.ContentLength, f.maxSize)
+	}
+	return &limitedBody{body: resp.Body, cancel: cancel, url: u, remain: f.maxSize, max: f.maxSize}, nil
+}
+
+// limitedBody reads the response body and fails once the max size exceeded
+type limitedBody struct {
+	body   io.ReadCloser
+	cancel context.CancelFunc
+	url    string
+	remain int64
+	max    int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.max <= 0 {
+		return b.body.Read(p)
+	}
+	if b.remain < 0 {
+		return 0, fmt.Errorf("url file: %s exceeded the size limit (%d)", b.url, b.max)
+	}
+	// read one more byte to detect exceeded size
+	if int64(len(p)) > b.remain+1 {
+		p = p[:b.remain+1]
+	}
+	n, err := b.body.Read(p)
+	b.remain -= int64(n)
+	if b.remain < 0 {
+		return n + int(b.remain), fmt.Errorf("url file: %s exceeded the size limit (%d)", b.url, b.max)
+	}
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	defer b.cancel()
+	return b.body.Close()
+}
+
+var _ file.File = &urlFile{}
+
+// urlFile streams the url content on each read
+type urlFile struct {
+	url     string
+	fetcher *urlFetcher
+}
+
+func (f *urlFile) Name() string {
+	return ""
+}
+
+func (f *urlFile) Reader() (io.ReadCloser, error) {
+	return f.fetcher.fetch(f.url)
+}
+
+func (f *urlFile) Content() ([]byte, error) {
+	r, err := f.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(r); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (f *urlFile) Open() (*os.File, error) {
+	b, err := f.Content()
+	if err != nil {
+		return nil, err
+	}
+	return file.NewMemFile("", b).Open()
+}
+
+func (f *urlFile) String() string {
+	return fmt.Sprintf("[urlfile:%v]", f.url)
+}
+
+func denyPrivateAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("url file: invalid address %q", host)
+	}
+	for _, n := range privateNetworks {
+		if n.Contains(ip) {
+			return fmt.Errorf("url file: address %s is not allowed", host)
+		}
+	}
+	return nil
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	rt := make([]*net.IPNet, 0, len(cidrs))
+	for _, c := range cidrs {
+		_, n, err := net.ParseCIDR(c)
+		if err != nil {
+			panic(err)
+		}
+		rt = append(rt, n)
+	}
+	return rt
+}
@@ -16,11 +16,16 @@ type waiter struct {
 	realTimeLimit time.Duration
 }
 
-func (w *waiter) Wait(ctx context.Context, u envexec.Process) bool {
-	if w.realTimeLimit < w.timeLimit {
-		w.realTimeLimit = w.timeLimit
+// clockLimit returns the clock limit of the cmd enforced by the waiter, it is
+// at least the cpu limit
+func clockLimit(rc Cmd) time.Duration {
+	if rc.ClockLimit < rc.CPULimit {
+		return rc.CPULimit
 	}
+	return rc.ClockLimit
+}
 
+func (w *waiter) Wait(ctx context.Context, u envexec.Process) bool {
 	start := time.Now()
 
 	tickInterval := w.tickInterval
@@ -3,8 +3,12 @@ package worker
 import (
 	"context"
 	"fmt"
+	"os"
 	"path"
+	"path/filepath"
+	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/criyle/go-judge/envexec"
@@ -14,17 +18,61 @@ import (
 
 const maxWaiting = 512
 
+// maxNice is the lowest scheduling priority
+const maxNice = 19
+
 // Config defines worker configuration
 type Config struct {
 	FileStore             filestore.FileStore
 	EnvironmentPool       envexec.EnvironmentPool
+	ImagePools            map[string]envexec.EnvironmentPool
 	Parallelism           int
 	WorkDir               string
 	TimeLimitTickInterval time.Duration
 	ExtraMemoryLimit      envexec.Size
 	OutputLimit           envexec.Size
 	CopyOutLimit          envexec.Size
+	CopyOutTotalLimit     envexec.Size
+	CopyInLimit           envexec.Size
+	CopyInTotalLimit      envexec.Size
+	ArgsSizeLimit         envexec.Size
+	EnvSizeLimit          envexec.Size
+	BufferPoolLimit       envexec.Size
+	URLAllowHosts         []string
+	URLTimeout            time.Duration
+	URLMaxSize            envexec.Size
+	URLAllowPrivate       bool
+	RetryLimit            int
+	RetryBackoff          time.Duration
+	EnableDebug           bool
+	CompileCacheSize      int
+	CompileCacheTTL       time.Duration
+	PreserveDir           string
+	PreserveTimeout       time.Duration
+	LimitCap              LimitCap
+	PostExecTimeLimit     time.Duration
+	ReapDeadline          time.Duration
+	CgroupPool            bool
+	MaxQueue              int
+	BusyRetryAfter        time.Duration
+	SignalStatus          map[int]envexec.Status
+	GPUDevices            []string
+	InstructionCounter    bool
+	SeccompName           string
+	Profiles              map[string]Profile
 	ExecObserver          func(Response)
+	EventObserver         func(Event)
+
+	CopyOutCollision       envexec.CollisionPolicy
+	CopyOutCaseInsensitive bool
+	CopyOutFileLimit       int      // including files inside copy out dirs
+	MinNice                int      // lowest nice cmd could request
+	StdbufLibrary          string   // libstdbuf.so inside the container
+	NprocFromProcLimit     bool     // RLIMIT_NPROC defaults to ProcLimit
+	AllowedGroups          []int    // supplementary groups cmd could request
+	AllowedCapabilities    []string // capabilities profiles could retain
+	EnvPolicy              EnvPolicy
+	LimitEnv               LimitEnv
 }
 
 // Worker defines interface for executor
@@ -32,6 +80,8 @@ type Worker interface {
 	Start()
 	Submit(context.Context, *Request) <-chan Response
 	Execute(context.Context, *Request) <-chan Response
+	Inflight() []RunInfo
+	Kill(id string) bool
 	Shutdown()
 }
 
@@ -39,6 +89,7 @@ type Worker interface {
 type worker struct {
 	fs          filestore.FileStore
 	envPool     envexec.EnvironmentPool
+	imagePools  map[string]envexec.EnvironmentPool
 	parallelism int
 	workDir     string
 
@@ -46,8 +97,45 @@ type worker struct {
 	extraMemoryLimit      envexec.Size
 	outputLimit           envexec.Size
 	copyOutLimit          envexec.Size
+	copyOutTotalLimit     envexec.Size
+	copyInLimit           envexec.Size
+	copyInTotalLimit      envexec.Size
+	argsSizeLimit         envexec.Size
+	envSizeLimit          envexec.Size
+	bufferPool            envexec.BufferPool
+	urlFetcher            *urlFetcher
+	retryLimit            int
+	retryBackoff          time.Duration
+	enableDebug           bool
+	compileCache          *compileCache
+	preserveDir           string
+	preserveTimeout       time.Duration
+	limitCap              LimitCap
+	postExecTimeLimit     time.Duration
+	reapDeadline          time.Duration
+	cgroupPool            bool
+	maxQueue              int
+	busyRetryAfter        time.Duration
+	signalStatus          map[int]envexec.Status
+	gpuDevices            map[string]bool
+	instructionCounter    bool
+	copyOutCollision      envexec.CollisionPolicy
+	caseInsensitive       bool
+	copyOutFileLimit      int
+	minNice               int
+	stdbufLibrary         string
+	nprocFromProcLimit    bool
+	allowedGroups         map[int]bool
+	allowedCapabilities   map[string]bool
+	envPolicy             EnvPolicy
+	limitEnv              LimitEnv
+	seccompName           string
+	profiles              map[string]Profile
+
+	execObserver  func(Response)
+	eventObserver func(Event)
 
-	execObserver func(Response)
+	inflight inflight
 
 	startOnce sync.Once
 	stopOnce  sync.Once
@@ -60,28 +148,101 @@ type workRequest struct {
 	*Request
 	context.Context
 	resultCh chan<- Response
+	seed     *uint64
+	cmd      []Cmd      // cmd with limits capped
+	clamped  [][]string // clamped limits of each cmd
+	stripped [][]string // stripped env names of each cmd
+	checker  *Checker   // checker with the cmd capped
+	accepted time.Time  // accepted to submit or execute
+	err      error      // rejected before queued
 }
 
 // New creates new worker
 func New(conf Config) Worker {
+	var bufferPool envexec.BufferPool
+	if conf.BufferPoolLimit > 0 {
+		bufferPool = envexec.NewBufferPool(int(conf.BufferPoolLimit))
+	}
+	var fetcher *urlFetcher
+	if len(conf.URLAllowHosts) > 0 {
+		fetcher = newURLFetcher(conf.URLAllowHosts, conf.URLTimeout, conf.URLMaxSize, conf.URLAllowPrivate)
+	}
+	var cache *compileCache
+	if conf.CompileCacheSize > 0 {
+		cache = newCompileCache(conf.FileStore, conf.CompileCacheSize, conf.CompileCacheTTL)
+	}
+	gpuDevices := make(map[string]bool, len(conf.GPUDevices))
+	for _, d := range conf.GPUDevices {
+		gpuDevices[d] = true
+	}
+	allowedGroups := make(map[int]bool, len(conf.AllowedGroups))
+	for _, g := range conf.AllowedGroups {
+		allowedGroups[g] = true
+	}
+	allowedCapabilities := make(map[string]bool, len(conf.AllowedCapabilities))
+	for _, c := range conf.AllowedCapabilities {
+		allowedCapabilities[c] = true
+	}
 	return &worker{
 		fs:                    conf.FileStore,
 		envPool:               conf.EnvironmentPool,
+		imagePools:            conf.ImagePools,
 		parallelism:           conf.Parallelism,
 		workDir:               conf.WorkDir,
 		timeLimitTickInterval: conf.TimeLimitTickInterval,
 		extraMemoryLimit:      conf.ExtraMemoryLimit,
 		outputLimit:           conf.OutputLimit,
 		copyOutLimit:          conf.CopyOutLimit,
+		copyOutTotalLimit:     conf.CopyOutTotalLimit,
+		copyInLimit:           conf.CopyInLimit,
+		copyInTotalLimit:      conf.CopyInTotalLimit,
+		argsSizeLimit:         conf.ArgsSizeLimit,
+		envSizeLimit:          conf.EnvSizeLimit,
+		bufferPool:            bufferPool,
+		urlFetcher:            fetcher,
+		retryLimit:            conf.RetryLimit,
+		retryBackoff:          conf.RetryBackoff,
+		enableDebug:           conf.EnableDebug,
+		compileCache:          cache,
+		preserveDir:           conf.PreserveDir,
+		preserveTimeout:       conf.PreserveTimeout,
+		limitCap:              conf.LimitCap,
+		postExecTimeLimit:     conf.PostExecTimeLimit,
+		reapDeadline:          conf.ReapDeadline,
+		cgroupPool:            conf.CgroupPool,
+		maxQueue:              conf.MaxQueue,
+		busyRetryAfter:        conf.BusyRetryAfter,
+		signalStatus:          conf.SignalStatus,
+		gpuDevices:            gpuDevices,
+		instructionCounter:    conf.InstructionCounter,
+		copyOutCollision:      conf.CopyOutCollision,
+		caseInsensitive:       conf.CopyOutCaseInsensitive,
+		copyOutFileLimit:      conf.CopyOutFileLimit,
+		minNice:               conf.MinNice,
+		stdbufLibrary:         conf.StdbufLibrary,
+		nprocFromProcLimit:    conf.NprocFromProcLimit,
+		allowedGroups:         allowedGroups,
+		allowedCapabilities:   allowedCapabilities,
+		envPolicy:             conf.EnvPolicy,
+		limitEnv:              conf.LimitEnv,
+		seccompName:           conf.SeccompName,
+		profiles:              conf.Profiles,
 		execObserver:          conf.ExecObserver,
+		eventObserver:         conf.EventObserver,
 	}
 }
 
 // Start starts worker loops with given parallelism
 func (w *worker) Start() {
 	w.startOnce.Do(func() {
-		w.workCh = make(chan workRequest, maxWaiting)
+		// with max queue, requests over the queue length are rejected
+		queueLen := maxWaiting
+		if w.maxQueue > 0 {
+			queueLen = w.maxQueue
+		}
+		w.workCh = make(chan workRequest, queueLen)
 		w.done = make(chan struct{})
+		w.sweepPreserved()
 		w.wg.Add(w.parallelism)
 		for i := 0; i < w.parallelism; i++ {
 			go w.loop()
@@ -92,10 +253,30 @@ func (w *worker) Start() {
 // Submit submits a single request
 func (w *worker) Submit(ctx context.Context, req *Request) <-chan Response {
 	ch := make(chan Response, 1)
-	w.workCh <- workRequest{
+	w.observeEvent(EventAccepted, req.RequestID, -1)
+	wq := workRequest{
 		Request:  req,
 		Context:  ctx,
 		resultCh: ch,
+		accepted: time.Now(),
+	}
+	// the copy in size is checked before queued to reject the large request
+	// early, it is responded without the worker then
+	if wq.err = w.checkRequestCopyIn(req); wq.err != nil {
+		w.workDoCmd(wq)
+		return ch
+	}
+	// reported before sent since the worker may report the events of the
+	// run once received
+	w.observeEvent(EventQueued, req.RequestID, -1)
+	if w.maxQueue <= 0 {
+		w.workCh <- wq
+	} else {
+		select {
+		case w.workCh <- wq:
+		default:
+			ch <- Response{Error: &BusyError{RetryAfter: w.retryAfter()}}
+		}
 	}
 	return ch
 }
@@ -103,14 +284,20 @@ func (w *worker) Submit(ctx context.Context, req *Request) <-chan Response {
 // Execute will execute the request in new goroutine (bypass the parallelism limit)
 func (w *worker) Execute(ctx context.Context, req *Request) <-chan Response {
 	ch := make(chan Response, 1)
+	w.observeEvent(EventAccepted, req.RequestID, -1)
+	wq := workRequest{
+		Request:  req,
+		Context:  ctx,
+		resultCh: ch,
+		accepted: time.Now(),
+	}
+	if wq.err = w.checkRequestCopyIn(req); wq.err != nil {
+		w.workDoCmd(wq)
+		return ch
+	}
 	w.wg.Add(1)
 	go func() {
 		defer w.wg.Done()
-		wq := workRequest{
-			Request:  req,
-			Context:  ctx,
-			resultCh: ch,
-		}
 		w.workDoCmd(wq)
 	}()
 	return ch
@@ -141,26 +328,234 @@ func (w *worker) loop() {
 
 func (w *worker) workDoCmd(req workRequest) {
 	var rt Response
-	if len(req.Cmd) == 1 {
-		rt = w.workDoSingle(req.Context, req.Cmd[0])
+	fields, err := newReturnFields(req.ReturnFields)
+	if err == nil {
+		err = req.err
+	}
+	if err == nil {
+		err = w.checkArgsSize(req.Cmd)
+	}
+	if err == nil {
+		err = checkPipeMapping(req.Request)
+	}
+	if err == nil {
+		err = checkInputs(req.Request)
+	}
+	if err == nil {
+		err = checkCompare(req.Request)
+	}
+	if err == nil {
+		err = w.checkCountSyscalls(req.Request)
+	}
+	if err == nil {
+		req.cmd, req.clamped, err = w.limitCap.apply(req.Cmd)
+		req.stripped = w.envPolicy.stripped(req.Cmd)
+	}
+	if err == nil {
+		req.checker, err = w.checkChecker(req.Checker)
+	}
+	var envPool envexec.EnvironmentPool
+	if err == nil {
+		envPool, err = w.getEnvPool(req.Image)
+	}
+	if err == nil {
+		req.seed, err = requestSeed(req.Request)
+	}
+	if err != nil {
+		rt.Error = err
 	} else {
-		rt = w.workDoGroup(req.Context, req.Cmd, req.PipeMapping)
+		ctx, cancel := context.WithCancel(req.Context)
+		run := w.inflight.add(req.Request, cancel)
+		req.Context = ctx
+		runPool := w.acquirePool(req, w.warmPool(req.Request, envPool))
+		rt = req.acquireTimeout(w.workDoRetry(req, runPool, fields, run))
+		rt.Error = w.diagnoseError(req.Debug, rt.Error)
+		for i := range rt.Results {
+			req.annotate(i, &rt.Results[i])
+		}
+		if req.checker != nil && rt.Error == nil {
+			w.workDoChecker(req.Context, envPool, req.RequestID, req.checker, &rt)
+		}
+		if req.Compare != nil && rt.Error == nil {
+			w.workDoCompare(req.Compare, &rt)
+		}
+		fields.removeUnreturned(w.fs, rt)
+		w.inflight.remove(run)
+		cancel()
 	}
 	rt.RequestID = req.RequestID
+	rt.Tags = req.Tags
+	rt.Seed = req.seed
 	if w.execObserver != nil {
 		w.execObserver(rt)
 	}
+	for i, r := range rt.Results {
+		rt.Results[i] = fields.filter(r)
+	}
+	if rt.Checker != nil {
+		c := fields.filter(*rt.Checker)
+		rt.Checker = &c
+	}
 	req.resultCh <- rt
 }
 
-func (w *worker) workDoSingle(ctx context.Context, rc Cmd) (rt Response) {
+// workDoRetry runs the request and retries with backoff if any of the results
+// is internal error (never retries for errors of the program)
+func (w *worker) workDoRetry(req workRequest, envPool envexec.EnvironmentPool, fields returnFields, run *inflightRun) (rt Response) {
+	cmd := fields.prepareCmd(withSeed(req.cmd, req.seed), judgedFiles(req.Request))
+	stage := w.stageObserver(req.RequestID)
+	cb := req.ResultCallback
+	if cb != nil {
+		cb = func(i int, r Result) {
+			req.annotate(i, &r)
+			req.ResultCallback(i, fields.filter(r))
+		}
+	}
+	if len(cmd) == 1 && len(req.Inputs) == 0 {
+		if key, ok := w.compileCacheKey(req.Image, cmd[0]); ok {
+			if res, ok := w.compileCache.get(key); ok {
+				if cb != nil {
+					cb(0, res)
+				}
+				return Response{Results: []Result{res}}
+			}
+			defer func() {
+				if rt.Error == nil && len(rt.Results) == 1 {
+					w.compileCache.put(key, rt.Results[0])
+				}
+			}()
+		}
+	}
+	var prevBuf *resultBuffer
+	for i := 0; ; i++ {
+		// the results are called back once the attempt is final
+		var buf *resultBuffer
+		acb := cb
+		if cb != nil {
+			buf = &resultBuffer{cb: cb, retries: i, stream: i >= w.retryLimit}
+			acb = buf.callback
+		}
+		prev := rt
+		// each attempt preserves its work dirs by its own id
+		acmd := w.withPreserve(cmd, req.Request)
+		// the clock budget is shared by the cmds of the group
+		if len(req.Inputs) > 0 {
+			rt = w.workDoSequence(req.Context, envPool, acmd[0], req.Inputs, req.StopOnFailure, req.Debug, run, acb, stage)
+		} else if len(acmd) == 1 && req.ClockBudget == 0 {
+			rt = w.workDoSingle(req.Context, envPool, acmd[0], req.Debug, run, acb, stage)
+		} else {
+			rt = w.workDoGroup(req.Context, envPool, acmd, req.PipeMapping, req.ClockBudget, req.Debug, run, acb, stage)
+		}
+		w.releasePreserved(rt)
+		// the results of the previous attempt are kept if the retry failed to
+		// run (e.g. environment not available)
+		if i > 0 && rt.Error != nil {
+			prevBuf.flush()
+			return prev
+		}
+		for j := range rt.Results {
+			rt.Results[j].Retries = i
+		}
+		if i >= w.retryLimit || !hasInternalError(rt) || req.Context.Err() != nil {
+			buf.flush()
+			return rt
+		}
+		// the retries are not bounded by the acquire timeout of the request
+		if p, ok := envPool.(acquirePool); ok {
+			envPool = p.EnvironmentPool
+		}
+		select {
+		case <-req.Context.Done():
+			buf.flush()
+			return rt
+		case <-time.After(w.retryBackoff << i):
+		}
+		prevBuf = buf
+	}
+}
+
+// resultBuffer holds the results called back by an attempt until the attempt
+// is final, the results of the last attempt are called back directly
+type resultBuffer struct {
+	cb      func(int, Result)
+	retries int
+	stream  bool
+
+	mu      sync.Mutex
+	results []indexedResult
+}
+
+type indexedResult struct {
+	index  int
+	result Result
+}
+
+func (b *resultBuffer) callback(i int, r Result) {
+	r.Retries = b.retries
+	if b.stream {
+		b.cb(i, r)
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.results = append(b.results, indexedResult{index: i, result: r})
+}
+
+// flush calls back the buffered results of the final attempt
+func (b *resultBuffer) flush() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	rs := b.results
+	b.results = nil
+	b.mu.Unlock()
+	for _, r := range rs {
+		b.cb(r.index, r.result)
+	}
+}
+
+// annotate sets the clamped limits and the stripped env warning of result i
+func (req *workRequest) annotate(i int, r *Result) {
+	// results of the inputs are of the same cmd
+	if len(req.Inputs) > 0 {
+		i = 0
+	}
+	if i < len(req.clamped) {
+		r.LimitsClamped = req.clamped[i]
+	}
+	if i < len(req.stripped) && len(req.stripped[i]) > 0 {
+		w := "env stripped: " + strings.Join(req.stripped[i], ", ")
+		if r.Warning != "" {
+			w = r.Warning + "; " + w
+		}
+		r.Warning = w
+	}
+}
+
+func hasInternalError(rt Response) bool {
+	for _, r := range rt.Results {
+		if r.Status == envexec.StatusInternalError {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *worker) workDoSingle(ctx context.Context, envPool envexec.EnvironmentPool, rc Cmd, debug bool, run *inflightRun, cb func(int, Result), stage func(int, envexec.Stage)) (rt Response) {
 	c, copyOutSet, err := w.prepareCmd(rc)
 	if err != nil {
-		rt.Error = err
+		rt.Error = withFieldPrefix(err, "cmd[0]")
 		return
 	}
+	c.Waiter = run.trackWaiter(0, c.Waiter)
+	c.SampleOpenFiles = w.debugEnabled(debug)
+	c.ReportCgroup = w.debugEnabled(debug)
+	if stage != nil {
+		c.StageObserver = func(s envexec.Stage) { stage(0, s) }
+	}
 	s := &envexec.Single{
-		EnvironmentPool: w.envPool,
+		EnvironmentPool: envPool,
 		Cmd:             c,
 	}
 	result, err := s.Run(ctx)
@@ -169,51 +564,110 @@ func (w *worker) workDoSingle(ctx context.Context, rc Cmd) (rt Response) {
 		return
 	}
 	res := w.convertResult(result, copyOutSet)
+	res.Error = rc.Secrets.redact(res.Error)
+	res.Debug = w.debugInfo(debug, rc, c, result)
+	if result.Preserved {
+		res.Preserved = rc.preserveID
+	}
+	if cb != nil {
+		cb(0, res)
+	}
 	rt.Results = []Result{res}
 	return
 }
 
-func (w *worker) workDoGroup(ctx context.Context, rc []Cmd, pm []PipeMap) (rt Response) {
-	var rts []Result
+func (w *worker) workDoGroup(ctx context.Context, envPool envexec.EnvironmentPool, rc []Cmd, pm []PipeMap, budget time.Duration, debug bool, run *inflightRun, cb func(int, Result), stage func(int, envexec.Stage)) (rt Response) {
 	p := preparePipeMapping(pm)
 	cs := make([]*envexec.Cmd, 0, len(rc))
 	copyOutSets := make([]map[string]bool, 0, len(rc))
-	for _, cc := range rc {
+	for i, cc := range rc {
 		c, os, err := w.prepareCmd(cc)
 		if err != nil {
-			rt.Error = err
+			rt.Error = withFieldPrefix(err, fmt.Sprintf("cmd[%d]", i))
 			return
 		}
+		c.Waiter = run.trackWaiter(i, c.Waiter)
+		c.SampleOpenFiles = w.debugEnabled(debug)
+		c.ReportCgroup = w.debugEnabled(debug)
+		if stage != nil {
+			i := i
+			c.StageObserver = func(s envexec.Stage) { stage(i, s) }
+		}
 		cs = append(cs, c)
 		copyOutSets = append(copyOutSets, os)
 	}
+	// results are converted as soon as each cmd finishes
+	rts := make([]Result, len(cs))
 	g := envexec.Group{
-		EnvironmentPool: w.envPool,
+		EnvironmentPool: envPool,
 
 		Cmd:   cs,
 		Pipes: p,
+
+		ClockBudget: budget,
+
+		ResultCallback: func(i int, result envexec.Result) {
+			rts[i] = w.convertResult(result, copyOutSets[i])
+			rts[i].Error = rc[i].Secrets.redact(rts[i].Error)
+			rts[i].Debug = w.debugInfo(debug, rc[i], cs[i], result)
+			if result.Preserved {
+				rts[i].Preserved = rc[i].preserveID
+			}
+			if cb != nil {
+				cb(i, rts[i])
+			}
+		},
 	}
 	results, err := g.Run(ctx)
 	if err != nil {
 		rt.Error = err
 		return
 	}
-	rts = make([]Result, 0, len(results))
-	for i, result := range results {
-		res := w.convertResult(result, copyOutSets[i])
-		rts = append(rts, res)
-	}
 	rt.Results = rts
+	for _, r := range results {
+		if r.ClockBudgetExceeded {
+			rt.ClockBudgetExceeded = true
+			rt.Verdict = envexec.StatusTimeLimitExceeded
+		}
+	}
 	return
 }
 
+// nprocLimit returns the RLIMIT_NPROC of cmd
+func (w *worker) nprocLimit(rc Cmd) uint64 {
+	if rc.NprocLimit == 0 && w.nprocFromProcLimit {
+		return rc.ProcLimit
+	}
+	return rc.NprocLimit
+}
+
 func (w *worker) convertResult(result envexec.Result, copyOutSet map[string]bool) (res Result) {
 	res.Status = result.Status
 	res.ExitStatus = result.ExitStatus
+	res.ExitReason = result.ExitReason
 	res.Error = result.Error
 	res.Time = result.Time
 	res.RunTime = result.RunTime
 	res.Memory = result.Memory
+	res.MemoryPeak = result.MemoryPeak
+	res.SwapUsage = result.SwapUsage
+	res.EnvironmentTime = result.EnvironmentTime
+	res.CgroupTime = result.CgroupTime
+	res.UserTime = result.UserTime
+	res.SystemTime = result.SystemTime
+	res.PostExec = result.PostExec
+	res.ProcPeak = result.ProcPeak
+	res.SocketPeak = result.SocketPeak
+	res.ProcLimitHit = result.ProcLimitHit
+	res.Instructions = result.Instructions
+	res.OmittedFiles = result.OmittedFiles
+	res.TruncatedFiles = result.TruncatedFiles
+	res.CopyOutBytes = result.CopyOutBytes
+	res.PipeBytes = result.PipeBytes
+	res.MemoryWarning = result.MemoryWarning
+	res.EarlyResult = result.EarlyResult
+	res.ReapTimeout = result.ReapTimeout
+	res.Warning = result.Warning
 	res.Files = make(map[string][]byte)
 	res.FileIDs = make(map[string]string)
 
@@ -224,7 +678,8 @@ func (w *worker) convertResult(result envexec.Result, copyOutSet map[string]bool
 			res.Error = err.Error()
 			return
 		}
-		if copyOutSet[name] {
+		// files not in the set are collected from copy out dirs
+		if inline, ok := copyOutSet[name]; inline || !ok {
 			res.Files[name] = b
 		} else {
 			id, err := w.fs.Add(name, b)
@@ -240,6 +695,37 @@ func (w *worker) convertResult(result envexec.Result, copyOutSet map[string]bool
 }
 
 func (w *worker) prepareCmd(rc Cmd) (*envexec.Cmd, map[string]bool, error) {
+	profile, err := w.getProfile(rc.Profile)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := w.checkGPUDevices(profile); err != nil {
+		return nil, nil, err
+	}
+	if err := w.checkCapabilities(profile); err != nil {
+		return nil, nil, err
+	}
+	if rc.InstructionLimit > 0 && !w.instructionCounter {
+		return nil, nil, reject(RejectNotAllowed, "instructionLimit", "instruction counter is not enabled")
+	}
+	if rc.StdoutBuffering != StdoutBufferingDefault && w.stdbufLibrary == "" {
+		return nil, nil, reject(RejectNotAllowed, "stdoutBuffering", "stdbuf library is not configured")
+	}
+	if rc.Nice > maxNice {
+		return nil, nil, reject(RejectInvalidArgument, "nice", "nice %d is greater than %d", rc.Nice, maxNice)
+	}
+	if rc.Nice < w.minNice {
+		return nil, nil, reject(RejectNotAllowed, "nice", "nice %d is less than the server minimum %d", rc.Nice, w.minNice)
+	}
+	for _, g := range rc.Groups {
+		if !w.allowedGroups[g] {
+			return nil, nil, reject(RejectNotAllowed, "groups", "group %d is not allowed", g)
+		}
+	}
+	args := make([]string, 0, len(profile.ArgsPrefix)+len(rc.Args))
+	args = append(args, profile.ArgsPrefix...)
+	args = append(args, rc.Args...)
+
 	files, pipeFileName, err := w.prepareCmdFiles(rc.Files)
 	if err != nil {
 		return nil, nil, err
@@ -249,6 +735,48 @@ func (w *worker) prepareCmd(rc Cmd) (*envexec.Cmd, map[string]bool, error) {
 		return nil, nil, err
 	}
 
+	// env defined in cmd takes precedence over the env file
+	cmdEnv, err := w.envPolicy.filter("env", rc.Env)
+	if err != nil {
+		return nil, nil, err
+	}
+	secretEnv, err := w.envPolicy.filter("secrets", rc.Secrets.env())
+	if err != nil {
+		return nil, nil, err
+	}
+	env := append(append([]string(nil), cmdEnv...), secretEnv...)
+	if rc.EnvFile != "" {
+		f, ok := copyIn[rc.EnvFile]
+		if !ok {
+			return nil, nil, reject(RejectInvalidArgument, "envFile", "env file %s is not copied in", rc.EnvFile)
+		}
+		fe, err := readEnvFile(rc.EnvFile, f)
+		if err != nil {
+			return nil, nil, err
+		}
+		if fe, err = w.envPolicy.filter("envFile", fe); err != nil {
+			return nil, nil, err
+		}
+		env = mergeEnv(env, fe)
+	}
+	env = mergeEnv(env, profile.env())
+	env = mergeEnv(w.limitEnv.env(rc), env)
+	if rc.StdoutBuffering != StdoutBufferingDefault {
+		env = withStdbuf(env, w.stdbufLibrary, rc.StdoutBuffering)
+	}
+
+	var stdinTee *envexec.PipeCollector
+	if t := rc.StdinTee; t != nil {
+		if rc.TTY {
+			return nil, nil, reject(RejectInvalidArgument, "stdinTee", "stdin tee is not supported with tty")
+		}
+		if pipeFileName[t.Name] {
+			return nil, nil, reject(RejectInvalidArgument, "stdinTee", "stdin tee %s conflicts with pipe collector", t.Name)
+		}
+		stdinTee = &envexec.PipeCollector{Name: t.Name, SizeLimit: t.Max}
+		pipeFileName[t.Name] = true
+	}
+
 	copyOutSet := make(map[string]bool)
 	// pipe default copyout
 	for k := range pipeFileName {
@@ -264,15 +792,31 @@ func (w *worker) prepareCmd(rc Cmd) (*envexec.Cmd, map[string]bool, error) {
 	for _, fn := range rc.CopyOutCached {
 		if !pipeFileName[fn] {
 			copyOut = append(copyOut, fn)
+			if _, ok := copyOutSet[fn]; !ok {
+				copyOutSet[fn] = false
+			}
 		} else {
-			delete(copyOutSet, fn)
+			copyOutSet[fn] = false
 		}
 	}
 
 	wait := &waiter{
 		tickInterval:  w.timeLimitTickInterval,
 		timeLimit:     time.Duration(rc.CPULimit),
-		realTimeLimit: time.Duration(rc.ClockLimit),
+		realTimeLimit: clockLimit(rc),
+	}
+
+	copyOutDirs := make([]envexec.CopyOutDirSpec, 0, len(rc.CopyOutDirs))
+	for _, d := range rc.CopyOutDirs {
+		copyOutDirs = append(copyOutDirs, envexec.CopyOutDirSpec{
+			Src:    d.Src,
+			Prefix: d.Prefix,
+		})
+	}
+
+	var preserveDir string
+	if rc.preserveID != "" {
+		preserveDir = filepath.Join(w.preserveDir, rc.preserveID)
 	}
 
 	var copyOutDir string
@@ -285,18 +829,29 @@ func (w *worker) prepareCmd(rc Cmd) (*envexec.Cmd, map[string]bool, error) {
 	}
 
 	timeLimit := time.Duration(rc.CPULimit)
+	var killSignal os.Signal
+	if rc.KillSignal > 0 {
+		killSignal = syscall.Signal(rc.KillSignal)
+	}
+
 	copyOutMax := w.copyOutLimit
 	if rc.CopyOutMax > 0 {
 		copyOutMax = envexec.Size(rc.CopyOutMax)
 	}
+	copyOutTotalMax := w.copyOutTotalLimit
+	if rc.CopyOutTotalMax > 0 {
+		copyOutTotalMax = envexec.Size(rc.CopyOutTotalMax)
+	}
 
 	return &envexec.Cmd{
-		Args:              rc.Args,
-		Env:               rc.Env,
+		Args:              args,
+		Env:               env,
 		Files:             files,
 		TTY:               rc.TTY,
+		StdinTee:          stdinTee,
 		TimeLimit:         timeLimit,
 		MemoryLimit:       envexec.Size(rc.MemoryLimit),
+		MemorySoftLimit:   rc.MemorySoftLimit,
 		StackLimit:        envexec.Size(rc.StackLimit),
 		ExtraMemoryLimit:  w.extraMemoryLimit,
 		OutputLimit:       w.outputLimit,
@@ -306,8 +861,52 @@ func (w *worker) prepareCmd(rc Cmd) (*envexec.Cmd, map[string]bool, error) {
 		CopyIn:            copyIn,
 		CopyOut:           copyOut,
 		CopyOutDir:        copyOutDir,
+		CopyOutDirs:       copyOutDirs,
+		PreserveDir:       preserveDir,
+		PreserveMax:       w.copyOutTotalLimit,
 		CopyOutMax:        copyOutMax,
 		Waiter:            wait.Wait,
+		BufferPool:        w.bufferPool,
+
+		SyscallRateLimit:  rc.SyscallRateLimit,
+		KillOnSyscallRate: rc.KillOnSyscallRate,
+
+		WriteLimit: rc.WriteLimit,
+		FileLimit:  rc.FileLimit,
+
+		SocketLimit:   rc.SocketLimit,
+		OpenFileLimit: rc.OpenFileLimit,
+		NprocLimit:    w.nprocLimit(rc),
+
+		FileSizeLimit: rc.FileSizeLimit,
+		FreshCgroup:   rc.FreshCgroup,
+
+		MemoryAccounting: rc.MemoryAccounting,
+		TimeAccounting:   rc.TimeAccounting,
+		InstructionLimit: rc.InstructionLimit,
+		Nice:             rc.Nice,
+
+		CopyOutCollision:       w.copyOutCollision,
+		CopyOutCaseInsensitive: w.caseInsensitive,
+		CopyOutFileLimit:       w.copyOutFileLimit,
+		CopyOutPartial:         rc.CopyOutPartial,
+		CopyOutTotalMax:        copyOutTotalMax,
+		CopyOutIncludePipes:    rc.CopyOutIncludePipes,
+		CleanWorkDir:           rc.CleanWorkDir,
+
+		EarlyResultQuietPeriod: rc.EarlyResultQuietPeriod,
+		SignalStatus:           w.signalStatus,
+		Devices:                profile.GPUDevices,
+		Groups:                 rc.Groups,
+		Capabilities:           profile.Capabilities,
+		CountSyscalls:          rc.CountSyscalls,
+		ReapDeadline:           w.reapDeadline,
+
+		PostExec:          rc.PostExec,
+		PostExecTimeLimit: w.postExecTimeLimit,
+
+		KillSignal:      killSignal,
+		KillGracePeriod: rc.KillGracePeriod,
 	}, copyOutSet, nil
 }
 
@@ -322,19 +921,27 @@ func preparePipeMapping(pm []PipeMap) []*envexec.Pipe {
 	return rt
 }
 
+// envFile prepares envexec file, url file is fetched if url fetching enabled
+func (w *worker) envFile(f CmdFile) (interface{}, error) {
+	if uf, ok := f.(*URLFile); ok && w.urlFetcher != nil {
+		return w.urlFetcher.file(uf)
+	}
+	return f.EnvFile(w.fs)
+}
+
 func (w *worker) prepareCopyIn(cf map[string]CmdFile) (map[string]file.File, error) {
 	rt := make(map[string]file.File)
 	for name, f := range cf {
 		if f == nil {
-			return nil, fmt.Errorf("nil type cannot be used for copyIn %s", name)
+			return nil, reject(RejectInvalidArgument, "copyIn."+name, "nil type cannot be used for copyIn %s", name)
 		}
-		pcf, err := f.EnvFile(w.fs)
+		pcf, err := w.envFile(f)
 		if err != nil {
-			return nil, err
+			return nil, withFieldPrefix(err, "copyIn."+name)
 		}
 		fi, ok := pcf.(file.File)
 		if !ok {
-			return nil, fmt.Errorf("pipe type cannot be used for copyIn %s", name)
+			return nil, reject(RejectInvalidArgument, "copyIn."+name, "pipe type cannot be used for copyIn %s", name)
 		}
 		rt[name] = fi
 	}
@@ -344,14 +951,14 @@ func (w *worker) prepareCopyIn(cf map[string]CmdFile) (map[string]file.File, err
 func (w *worker) prepareCmdFiles(files []CmdFile) ([]interface{}, map[string]bool, error) {
 	rt := make([]interface{}, 0, len(files))
 	pipeFileName := make(map[string]bool)
-	for _, f := range files {
+	for i, f := range files {
 		if f == nil {
 			rt = append(rt, nil)
 			continue
 		}
-		cf, err := f.EnvFile(w.fs)
+		cf, err := w.envFile(f)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, withFieldPrefix(err, fmt.Sprintf("files[%d]", i))
 		}
 		rt = append(rt, cf)
 		if t, ok := cf.(envexec.PipeCollector); ok {